@@ -117,6 +117,16 @@ func (api *API) AccessApplications(ctx context.Context, accountID string, pageOp
 	return api.accessApplications(ctx, accountID, pageOpts, AccountRouteRoot)
 }
 
+// AccessApplicationsIterator returns an Iterator over all applications within
+// an account, fetching each page of results as it is needed.
+//
+// API reference: https://api.cloudflare.com/#access-applications-list-access-applications
+func (api *API) AccessApplicationsIterator(ctx context.Context, accountID string, pageOpts PaginationOptions) *Iterator[AccessApplication] {
+	return newPaginationIterator(ctx, pageOpts, func(ctx context.Context, pageOpts PaginationOptions) ([]AccessApplication, ResultInfo, error) {
+		return api.AccessApplications(ctx, accountID, pageOpts)
+	})
+}
+
 // ZoneLevelAccessApplications returns all applications within a zone.
 //
 // API reference: https://api.cloudflare.com/#zone-level-access-applications-list-access-applications
@@ -124,6 +134,16 @@ func (api *API) ZoneLevelAccessApplications(ctx context.Context, zoneID string,
 	return api.accessApplications(ctx, zoneID, pageOpts, ZoneRouteRoot)
 }
 
+// ZoneLevelAccessApplicationsIterator returns an Iterator over all
+// applications within a zone, fetching each page of results as it is needed.
+//
+// API reference: https://api.cloudflare.com/#zone-level-access-applications-list-access-applications
+func (api *API) ZoneLevelAccessApplicationsIterator(ctx context.Context, zoneID string, pageOpts PaginationOptions) *Iterator[AccessApplication] {
+	return newPaginationIterator(ctx, pageOpts, func(ctx context.Context, pageOpts PaginationOptions) ([]AccessApplication, ResultInfo, error) {
+		return api.ZoneLevelAccessApplications(ctx, zoneID, pageOpts)
+	})
+}
+
 func (api *API) accessApplications(ctx context.Context, id string, pageOpts PaginationOptions, routeRoot RouteRoot) ([]AccessApplication, ResultInfo, error) {
 	uri := buildURI(fmt.Sprintf("/%s/%s/access/apps", routeRoot, id), pageOpts)
 
@@ -96,6 +96,32 @@ func TestAccessApplications(t *testing.T) {
 	}
 }
 
+func TestAccessApplicationsIterator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		page := r.URL.Query().Get("page")
+		fmt.Fprintf(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": [{"id": "app-%s"}],
+			"result_info": {"page": %s, "per_page": 1, "count": 1, "total_count": 3, "total_pages": 3}
+		}`, page, page)
+	}
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/access/apps", handler)
+
+	applications, err := client.AccessApplicationsIterator(context.Background(), testAccountID, PaginationOptions{PerPage: 1}).All()
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, []AccessApplication{{ID: "app-1"}, {ID: "app-2"}, {ID: "app-3"}}, applications)
+	}
+}
+
 func TestAccessApplication(t *testing.T) {
 	setup()
 	defer teardown()
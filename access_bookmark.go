@@ -41,6 +41,16 @@ func (api *API) AccessBookmarks(ctx context.Context, accountID string, pageOpts
 	return api.accessBookmarks(ctx, accountID, pageOpts, AccountRouteRoot)
 }
 
+// AccessBookmarksIterator returns an Iterator over all bookmarks within an
+// account, fetching each page of results as it is needed.
+//
+// API reference: https://api.cloudflare.com/#access-bookmarks-list-access-bookmarks
+func (api *API) AccessBookmarksIterator(ctx context.Context, accountID string, pageOpts PaginationOptions) *Iterator[AccessBookmark] {
+	return newPaginationIterator(ctx, pageOpts, func(ctx context.Context, pageOpts PaginationOptions) ([]AccessBookmark, ResultInfo, error) {
+		return api.AccessBookmarks(ctx, accountID, pageOpts)
+	})
+}
+
 // ZoneLevelAccessBookmarks returns all bookmarks within a zone.
 //
 // API reference: https://api.cloudflare.com/#zone-level-access-bookmarks-list-access-bookmarks
@@ -48,6 +58,16 @@ func (api *API) ZoneLevelAccessBookmarks(ctx context.Context, zoneID string, pag
 	return api.accessBookmarks(ctx, zoneID, pageOpts, ZoneRouteRoot)
 }
 
+// ZoneLevelAccessBookmarksIterator returns an Iterator over all bookmarks
+// within a zone, fetching each page of results as it is needed.
+//
+// API reference: https://api.cloudflare.com/#zone-level-access-bookmarks-list-access-bookmarks
+func (api *API) ZoneLevelAccessBookmarksIterator(ctx context.Context, zoneID string, pageOpts PaginationOptions) *Iterator[AccessBookmark] {
+	return newPaginationIterator(ctx, pageOpts, func(ctx context.Context, pageOpts PaginationOptions) ([]AccessBookmark, ResultInfo, error) {
+		return api.ZoneLevelAccessBookmarks(ctx, zoneID, pageOpts)
+	})
+}
+
 func (api *API) accessBookmarks(ctx context.Context, id string, pageOpts PaginationOptions, routeRoot RouteRoot) ([]AccessBookmark, ResultInfo, error) {
 	uri := buildURI(fmt.Sprintf("/%s/%s/access/bookmarks", routeRoot, id), pageOpts)
 
@@ -210,6 +210,16 @@ func (api *API) AccessGroups(ctx context.Context, accountID string, pageOpts Pag
 	return api.accessGroups(ctx, accountID, pageOpts, AccountRouteRoot)
 }
 
+// AccessGroupsIterator returns an Iterator over all access groups within an
+// account, fetching each page of results as it is needed.
+//
+// API reference: https://api.cloudflare.com/#access-groups-list-access-groups
+func (api *API) AccessGroupsIterator(ctx context.Context, accountID string, pageOpts PaginationOptions) *Iterator[AccessGroup] {
+	return newPaginationIterator(ctx, pageOpts, func(ctx context.Context, pageOpts PaginationOptions) ([]AccessGroup, ResultInfo, error) {
+		return api.AccessGroups(ctx, accountID, pageOpts)
+	})
+}
+
 // ZoneLevelAccessGroups returns all zone level access groups for an access application.
 //
 // API reference: https://api.cloudflare.com/#zone-level-access-groups-list-access-groups
@@ -217,6 +227,16 @@ func (api *API) ZoneLevelAccessGroups(ctx context.Context, zoneID string, pageOp
 	return api.accessGroups(ctx, zoneID, pageOpts, ZoneRouteRoot)
 }
 
+// ZoneLevelAccessGroupsIterator returns an Iterator over all zone level
+// access groups, fetching each page of results as it is needed.
+//
+// API reference: https://api.cloudflare.com/#zone-level-access-groups-list-access-groups
+func (api *API) ZoneLevelAccessGroupsIterator(ctx context.Context, zoneID string, pageOpts PaginationOptions) *Iterator[AccessGroup] {
+	return newPaginationIterator(ctx, pageOpts, func(ctx context.Context, pageOpts PaginationOptions) ([]AccessGroup, ResultInfo, error) {
+		return api.ZoneLevelAccessGroups(ctx, zoneID, pageOpts)
+	})
+}
+
 func (api *API) accessGroups(ctx context.Context, id string, pageOpts PaginationOptions, routeRoot RouteRoot) ([]AccessGroup, ResultInfo, error) {
 	uri := buildURI(
 		fmt.Sprintf(
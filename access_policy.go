@@ -66,6 +66,16 @@ func (api *API) AccessPolicies(ctx context.Context, accountID, applicationID str
 	return api.accessPolicies(ctx, accountID, applicationID, pageOpts, AccountRouteRoot)
 }
 
+// AccessPoliciesIterator returns an Iterator over all access policies for an
+// access application, fetching each page of results as it is needed.
+//
+// API reference: https://api.cloudflare.com/#access-policy-list-access-policies
+func (api *API) AccessPoliciesIterator(ctx context.Context, accountID, applicationID string, pageOpts PaginationOptions) *Iterator[AccessPolicy] {
+	return newPaginationIterator(ctx, pageOpts, func(ctx context.Context, pageOpts PaginationOptions) ([]AccessPolicy, ResultInfo, error) {
+		return api.AccessPolicies(ctx, accountID, applicationID, pageOpts)
+	})
+}
+
 // ZoneLevelAccessPolicies returns all zone level access policies for an access application.
 //
 // API reference: https://api.cloudflare.com/#zone-level-access-policy-list-access-policies
@@ -73,6 +83,17 @@ func (api *API) ZoneLevelAccessPolicies(ctx context.Context, zoneID, application
 	return api.accessPolicies(ctx, zoneID, applicationID, pageOpts, ZoneRouteRoot)
 }
 
+// ZoneLevelAccessPoliciesIterator returns an Iterator over all zone level
+// access policies for an access application, fetching each page of results
+// as it is needed.
+//
+// API reference: https://api.cloudflare.com/#zone-level-access-policy-list-access-policies
+func (api *API) ZoneLevelAccessPoliciesIterator(ctx context.Context, zoneID, applicationID string, pageOpts PaginationOptions) *Iterator[AccessPolicy] {
+	return newPaginationIterator(ctx, pageOpts, func(ctx context.Context, pageOpts PaginationOptions) ([]AccessPolicy, ResultInfo, error) {
+		return api.ZoneLevelAccessPolicies(ctx, zoneID, applicationID, pageOpts)
+	})
+}
+
 func (api *API) accessPolicies(ctx context.Context, id string, applicationID string, pageOpts PaginationOptions, routeRoot RouteRoot) ([]AccessPolicy, ResultInfo, error) {
 	uri := buildURI(
 		fmt.Sprintf(
@@ -75,6 +75,16 @@ func (api *API) AccountMembers(ctx context.Context, accountID string, pageOpts P
 	return accountMemberListresponse.Result, accountMemberListresponse.ResultInfo, nil
 }
 
+// AccountMembersIterator returns an Iterator over all members of an account,
+// fetching each page of results as it is needed.
+//
+// API reference: https://api.cloudflare.com/#accounts-list-accounts
+func (api *API) AccountMembersIterator(ctx context.Context, accountID string, pageOpts PaginationOptions) *Iterator[AccountMember] {
+	return newPaginationIterator(ctx, pageOpts, func(ctx context.Context, pageOpts PaginationOptions) ([]AccountMember, ResultInfo, error) {
+		return api.AccountMembers(ctx, accountID, pageOpts)
+	})
+}
+
 // CreateAccountMemberWithStatus invites a new member to join an account, allowing setting the status.
 //
 // Refer to the API reference for valid statuses.
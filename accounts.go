@@ -69,6 +69,17 @@ func (api *API) Accounts(ctx context.Context, params AccountsListParams) ([]Acco
 	return accListResponse.Result, accListResponse.ResultInfo, nil
 }
 
+// AccountsIterator returns an Iterator over all accounts the logged in user
+// has access to, fetching each page of results as it is needed.
+//
+// API reference: https://api.cloudflare.com/#accounts-list-accounts
+func (api *API) AccountsIterator(ctx context.Context, params AccountsListParams) *Iterator[Account] {
+	return newPaginationIterator(ctx, params.PaginationOptions, func(ctx context.Context, pageOpts PaginationOptions) ([]Account, ResultInfo, error) {
+		params.PaginationOptions = pageOpts
+		return api.Accounts(ctx, params)
+	})
+}
+
 // Account returns a single account based on the ID.
 //
 // API reference: https://api.cloudflare.com/#accounts-account-details
@@ -0,0 +1,391 @@
+package cloudflare
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CassetteMode controls whether a Cassette records or replays interactions.
+type CassetteMode int
+
+const (
+	// CassetteReplay serves responses from a previously recorded cassette
+	// without sending any requests.
+	CassetteReplay CassetteMode = iota
+
+	// CassetteRecord sends requests to the API and records the interactions.
+	CassetteRecord
+)
+
+const errCassetteInteractionNotFound = "no recorded interaction matches the request"
+
+// ErrCassetteInteractionNotFound is returned when replaying a request that
+// wasn't recorded in the cassette.
+var ErrCassetteInteractionNotFound = errors.New(errCassetteInteractionNotFound)
+
+// CassetteParams configures a Cassette.
+type CassetteParams struct {
+	// Path is the JSONL file the interactions are recorded to or replayed
+	// from.
+	Path string
+
+	// Mode is whether to record or replay interactions.
+	Mode CassetteMode
+
+	// Scrub maps sensitive values to the placeholders that replace them in
+	// the recorded interactions (e.g. an email address to
+	// "user@example.com"). Credentials, secrets and account and zone IDs are
+	// always scrubbed.
+	Scrub map[string]string
+}
+
+// CassetteInteraction is a single recorded request and response. A cassette
+// file holds one interaction per line.
+type CassetteInteraction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest is the recorded part of a request.
+type CassetteRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+// CassetteResponse is a recorded response.
+type CassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Cassette records API interactions to a file and replays them, so that
+// tests can run against real responses without network access. Credentials,
+// known secret fields and the account and zone IDs in request paths are
+// replaced with placeholders in the recording. IDs are numbered in the order
+// they are first seen (e.g. "REDACTED_ZONE_ID_1") so a replayed test must
+// make its requests in the same order as when it was recorded.
+//
+// A Cassette is used as middleware, either with `UsingCassette` for the API
+// or in `ClientParams.Middleware` for the experimental Client.
+type Cassette struct {
+	mu           sync.Mutex
+	params       CassetteParams
+	interactions []CassetteInteraction
+	used         []bool
+	scrub        map[string]string
+	placeholders map[string]bool
+	counts       map[RouteLevel]int
+}
+
+// NewCassette creates a Cassette. In replay mode the interactions are loaded
+// from `params.Path` straight away.
+func NewCassette(params CassetteParams) (*Cassette, error) {
+	if params.Path == "" {
+		return nil, errors.New("cassette path must not be empty")
+	}
+
+	c := &Cassette{
+		params:       params,
+		scrub:        make(map[string]string),
+		placeholders: make(map[string]bool),
+		counts:       make(map[RouteLevel]int),
+	}
+
+	for value, placeholder := range params.Scrub {
+		c.scrub[value] = placeholder
+		c.placeholders[placeholder] = true
+	}
+
+	if params.Mode == CassetteReplay {
+		if err := c.load(); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// Interactions returns the interactions recorded (or loaded) so far, for
+// example to refresh the fixtures under testdata/fixtures.
+func (c *Cassette) Interactions() []CassetteInteraction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]CassetteInteraction(nil), c.interactions...)
+}
+
+// Close writes the recorded interactions to the cassette file. It does
+// nothing in replay mode. The recording is scrubbed as it is written, so
+// IDs first seen in later requests are also scrubbed from earlier responses.
+func (c *Cassette) Close() error {
+	if c.params.Mode != CassetteRecord {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	for _, interaction := range c.interactions {
+		if err := enc.Encode(c.scrubInteraction(interaction)); err != nil {
+			return fmt.Errorf("failed to encode cassette interaction: %w", err)
+		}
+	}
+
+	return os.WriteFile(c.params.Path, buf.Bytes(), 0600)
+}
+
+// Middleware returns the middleware that records or replays requests.
+func (c *Cassette) Middleware() Middleware {
+	return func(next RequestHandler) RequestHandler {
+		return func(req *MiddlewareRequest) (*MiddlewareResponse, error) {
+			recorded, err := newCassetteRequest(req)
+			if err != nil {
+				return nil, err
+			}
+
+			if c.params.Mode == CassetteReplay {
+				return c.replay(req, recorded)
+			}
+
+			res, err := next(req)
+			if err != nil {
+				return nil, err
+			}
+
+			return res, c.record(req, recorded, res)
+		}
+	}
+}
+
+func newCassetteRequest(req *MiddlewareRequest) (CassetteRequest, error) {
+	var body []byte
+	if r := req.Request; r.Body != nil && r.Body != http.NoBody {
+		var err error
+		body, err = ioutil.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return CassetteRequest{}, fmt.Errorf("could not read request body: %w", err)
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	path, query, _ := strings.Cut(req.URI, "?")
+
+	return CassetteRequest{
+		Method: req.Method,
+		Path:   path,
+		Query:  query,
+		Body:   string(body),
+	}, nil
+}
+
+func (c *Cassette) record(req *MiddlewareRequest, recorded CassetteRequest, res *MiddlewareResponse) error {
+	if res == nil || res.Response == nil {
+		return nil
+	}
+
+	resp := res.Response
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return fmt.Errorf("could not read response body: %w", err)
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.learnIDs(req.URI)
+	c.interactions = append(c.interactions, CassetteInteraction{
+		Request: recorded,
+		Response: CassetteResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    resp.Header.Clone(),
+			Body:       string(body),
+		},
+	})
+
+	return nil
+}
+
+func (c *Cassette) replay(req *MiddlewareRequest, recorded CassetteRequest) (*MiddlewareResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.learnIDs(req.URI)
+	key := cassetteMatchKey(c.scrubRequest(recorded))
+
+	// prefer interactions that haven't been replayed yet so that repeated
+	// requests (e.g. polling) get their responses in order, falling back to
+	// the last matching one.
+	match := -1
+	for i, interaction := range c.interactions {
+		if cassetteMatchKey(interaction.Request) != key {
+			continue
+		}
+
+		match = i
+		if !c.used[i] {
+			break
+		}
+	}
+
+	if match < 0 {
+		return nil, fmt.Errorf("%w: %s %s", ErrCassetteInteractionNotFound, req.Method, req.URI)
+	}
+	c.used[match] = true
+
+	recordedResp := c.interactions[match].Response
+	resp := &http.Response{
+		Status:        fmt.Sprintf("%d %s", recordedResp.StatusCode, http.StatusText(recordedResp.StatusCode)),
+		StatusCode:    recordedResp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recordedResp.Headers.Clone(),
+		Body:          ioutil.NopCloser(strings.NewReader(recordedResp.Body)),
+		ContentLength: int64(len(recordedResp.Body)),
+		Request:       req.Request,
+	}
+	if resp.Header == nil {
+		resp.Header = make(http.Header)
+	}
+
+	var envelope *Response
+	if err := json.Unmarshal([]byte(recordedResp.Body), &envelope); err != nil {
+		envelope = nil
+	}
+
+	return &MiddlewareResponse{Response: resp, Envelope: envelope}, nil
+}
+
+func (c *Cassette) load() error {
+	f, err := os.Open(c.params.Path)
+	if err != nil {
+		return fmt.Errorf("failed to open cassette: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var interaction CassetteInteraction
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return fmt.Errorf("failed to decode cassette line %d: %w", line, err)
+		}
+		c.interactions = append(c.interactions, interaction)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read cassette: %w", err)
+	}
+
+	c.used = make([]bool, len(c.interactions))
+
+	return nil
+}
+
+// learnIDs registers the account or zone ID in `uri` for scrubbing. IDs that
+// are already placeholders (e.g. taken from a replayed response) are
+// ignored.
+func (c *Cassette) learnIDs(uri string) {
+	rc := resourceContainerFromURI(uri)
+	if rc == nil || rc.Identifier == "" || c.placeholders[rc.Identifier] {
+		return
+	}
+
+	if _, ok := c.scrub[rc.Identifier]; ok {
+		return
+	}
+
+	var name string
+	switch rc.Level {
+	case AccountRouteLevel:
+		name = "ACCOUNT"
+	case ZoneRouteLevel:
+		name = "ZONE"
+	default:
+		return
+	}
+
+	c.counts[rc.Level]++
+	placeholder := fmt.Sprintf("REDACTED_%s_ID_%d", name, c.counts[rc.Level])
+	c.scrub[rc.Identifier] = placeholder
+	c.placeholders[placeholder] = true
+}
+
+func (c *Cassette) scrubString(s string) string {
+	// replace longer values first so that a value containing another isn't
+	// partially replaced.
+	values := make([]string, 0, len(c.scrub))
+	for value := range c.scrub {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+
+	for _, value := range values {
+		s = strings.ReplaceAll(s, value, c.scrub[value])
+	}
+
+	return s
+}
+
+func (c *Cassette) scrubRequest(r CassetteRequest) CassetteRequest {
+	r.Path = c.scrubString(r.Path)
+	r.Query = c.scrubString(r.Query)
+	r.Body = c.scrubString(redactBody([]byte(r.Body)))
+	return r
+}
+
+func (c *Cassette) scrubInteraction(interaction CassetteInteraction) CassetteInteraction {
+	interaction.Request = c.scrubRequest(interaction.Request)
+
+	headers := redactHeaders(interaction.Response.Headers)
+	for name, values := range headers {
+		for i, v := range values {
+			values[i] = c.scrubString(v)
+		}
+		headers[name] = values
+	}
+	interaction.Response.Headers = headers
+	interaction.Response.Body = c.scrubString(redactBody([]byte(interaction.Response.Body)))
+
+	return interaction
+}
+
+// cassetteMatchKey identifies a request by its method, path, query and body.
+// The query parameters are sorted and JSON bodies are compacted so that
+// insignificant differences don't prevent a match.
+func cassetteMatchKey(r CassetteRequest) string {
+	query := r.Query
+	if values, err := url.ParseQuery(r.Query); err == nil {
+		query = values.Encode()
+	}
+
+	body := []byte(r.Body)
+	compacted := &bytes.Buffer{}
+	if err := json.Compact(compacted, body); err == nil {
+		body = compacted.Bytes()
+	}
+
+	return strings.Join([]string{r.Method, r.Path, query, string(body)}, "\n")
+}
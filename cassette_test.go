@@ -0,0 +1,128 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCassette_RecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+
+	recorder, err := NewCassette(CassetteParams{
+		Path:  path,
+		Mode:  CassetteRecord,
+		Scrub: map[string]string{"cloudflare@example.org": "user@example.com"},
+	})
+	require.NoError(t, err)
+
+	setup(UsingCassette(recorder))
+
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		w.Header().Set("cf-ray", "7a5d8d4e8b2b0b4f-LHR")
+
+		if r.Method == http.MethodPost {
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "372e67954025e0ba6aaa6d586b9e0b59", "zone_id": "`+testZoneID+`", "type": "A", "name": "example.com", "content": "198.51.100.4"}}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [{"id": "372e67954025e0ba6aaa6d586b9e0b59", "zone_id": "`+testZoneID+`", "type": "A", "name": "example.com", "content": "198.51.100.4", "meta": {"modified_by": "cloudflare@example.org"}}], "result_info": {"page": 1, "per_page": 100, "count": 1, "total_count": 1, "total_pages": 1}}`)
+	})
+
+	_, err = client.CreateDNSRecord(context.Background(), testZoneID, DNSRecord{Type: "A", Name: "example.com", Content: "198.51.100.4"})
+	require.NoError(t, err)
+	_, err = client.DNSRecords(context.Background(), testZoneID, DNSRecord{Type: "A"})
+	require.NoError(t, err)
+
+	teardown()
+	require.NoError(t, recorder.Close())
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), testZoneID)
+	assert.NotContains(t, string(raw), "cloudflare@example.org")
+	assert.NotContains(t, string(raw), "deadbeef")
+	assert.Contains(t, string(raw), "/zones/REDACTED_ZONE_ID_1/dns_records")
+	assert.Contains(t, string(raw), "user@example.com")
+
+	player, err := NewCassette(CassetteParams{Path: path, Mode: CassetteReplay})
+	require.NoError(t, err)
+	assert.Len(t, player.Interactions(), 2)
+
+	// nothing listens on the base URL so every response has to come from the
+	// cassette.
+	api, err := New("deadbeef", "cloudflare@example.org", UsingCassette(player), BaseURL("http://127.0.0.1:1"), UsingRetryPolicy(0, 0, 0))
+	require.NoError(t, err)
+
+	created, err := api.CreateDNSRecord(context.Background(), testZoneID, DNSRecord{Type: "A", Name: "example.com", Content: "198.51.100.4"})
+	require.NoError(t, err)
+	assert.Equal(t, "372e67954025e0ba6aaa6d586b9e0b59", created.Result.ID)
+	assert.Equal(t, "REDACTED_ZONE_ID_1", created.Result.ZoneID)
+
+	records, err := api.DNSRecords(context.Background(), testZoneID, DNSRecord{Type: "A"})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "198.51.100.4", records[0].Content)
+
+	_, err = api.DNSRecords(context.Background(), testZoneID, DNSRecord{Type: "AAAA"})
+	assert.ErrorIs(t, err, ErrCassetteInteractionNotFound)
+}
+
+func TestCassette_ReplayExperimentalClient(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+	cassette := `{"request":{"method":"GET","path":"/zones/REDACTED_ZONE_ID_1"},"response":{"status_code":200,"headers":{"Content-Type":["application/json"]},"body":"{\"success\":true,\"errors\":[],\"messages\":[],\"result\":{\"id\":\"REDACTED_ZONE_ID_1\",\"name\":\"example.com\"}}"}}` + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(cassette), 0600))
+
+	player, err := NewCassette(CassetteParams{Path: path, Mode: CassetteReplay})
+	require.NoError(t, err)
+
+	baseURL, _ := url.Parse("http://127.0.0.1:1")
+	client, err := NewExperimental(&ClientParams{
+		Token:       "deadbeef",
+		BaseURL:     baseURL,
+		Middleware:  []Middleware{player.Middleware()},
+		RetryPolicy: noRetries{},
+	})
+	require.NoError(t, err)
+
+	zone, err := client.Zones.Get(context.Background(), ZoneIdentifier(testZoneID))
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", zone.Name)
+}
+
+func TestCassette_RepeatedRequestsReplayInOrder(t *testing.T) {
+	c := &Cassette{
+		scrub:        map[string]string{},
+		placeholders: map[string]bool{},
+		counts:       map[RouteLevel]int{},
+		interactions: []CassetteInteraction{
+			{Request: CassetteRequest{Method: http.MethodGet, Path: "/user"}, Response: CassetteResponse{StatusCode: http.StatusAccepted}},
+			{Request: CassetteRequest{Method: http.MethodGet, Path: "/user"}, Response: CassetteResponse{StatusCode: http.StatusOK}},
+		},
+		used: make([]bool, 2),
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://127.0.0.1:1/user", nil)
+	for _, expected := range []int{http.StatusAccepted, http.StatusOK, http.StatusOK} {
+		res, err := c.replay(&MiddlewareRequest{Method: http.MethodGet, URI: "/user", Request: req}, CassetteRequest{Method: http.MethodGet, Path: "/user"})
+		require.NoError(t, err)
+		assert.Equal(t, expected, res.Response.StatusCode)
+	}
+}
+
+func TestCassetteMatchKey(t *testing.T) {
+	a := CassetteRequest{Method: http.MethodPost, Path: "/zones", Query: "b=2&a=1", Body: `{"name": "example.com"}`}
+	b := CassetteRequest{Method: http.MethodPost, Path: "/zones", Query: "a=1&b=2", Body: `{"name":"example.com"}`}
+	assert.Equal(t, cassetteMatchKey(a), cassetteMatchKey(b))
+
+	b.Method = http.MethodPut
+	assert.NotEqual(t, cassetteMatchKey(a), cassetteMatchKey(b))
+}
@@ -0,0 +1,93 @@
+// Package cfmock provides mock implementations of the narrow per-product
+// interfaces declared by the cloudflare package, such as
+// cloudflare.DNSRecordsAPI and cloudflare.WorkersKVAPI.
+//
+// Each mock has one function field per method. Set the fields the code under
+// test relies on; methods without one return ErrNotMocked:
+//
+//	dns := &cfmock.DNSRecordsAPI{
+//		DNSRecordsFunc: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+//			return []cloudflare.DNSRecord{{Type: "A", Name: "www.example.com"}}, nil
+//		},
+//	}
+//	_ = syncRecords(ctx, dns)
+//	calls := dns.CallsTo("DNSRecords")
+//
+// The mocks are generated from the interfaces in the cloudflare package and
+// must not be edited by hand.
+package cfmock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// ErrNotMocked is returned by a mock method whose function field is unset.
+var ErrNotMocked = errors.New("method not mocked")
+
+// Call is a single recorded method call.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Recorder keeps the calls made to a mock. It is embedded in every mock and
+// is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Calls returns every call made to the mock, in order.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsTo returns the calls made to method, in order.
+func (r *Recorder) CallsTo(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var calls []Call
+	for _, c := range r.calls {
+		if c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+
+	return calls
+}
+
+// Reset forgets every recorded call.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = nil
+}
+
+func (r *Recorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+func notMocked(mock, method string) error {
+	return fmt.Errorf("%s.%s: %w", mock, method, ErrNotMocked)
+}
+
+// notMockedIterator returns an iterator that yields no items and whose Err
+// method reports ErrNotMocked.
+func notMockedIterator[T any](mock, method string) *cloudflare.Iterator[T] {
+	return cloudflare.NewIterator(context.Background(), func(ctx context.Context, info cloudflare.ResultInfo) ([]T, cloudflare.ResultInfo, error) {
+		return nil, info, notMocked(mock, method)
+	})
+}
@@ -0,0 +1,40 @@
+package cfmock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNSRecordsAPI(t *testing.T) {
+	var api cloudflare.DNSRecordsAPI = &DNSRecordsAPI{
+		DNSRecordsFunc: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+			return []cloudflare.DNSRecord{{Type: "A", Name: "www.example.com", Content: "198.51.100.4"}}, nil
+		},
+	}
+
+	records, err := api.DNSRecords(context.Background(), "023e105f4ecef8ad9ca31a8372d0c353", cloudflare.DNSRecord{Type: "A"})
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+
+	err = api.DeleteDNSRecord(context.Background(), "023e105f4ecef8ad9ca31a8372d0c353", "372e67954025e0ba6aaa6d586b9e0b59")
+	assert.ErrorIs(t, err, ErrNotMocked)
+	assert.EqualError(t, err, "DNSRecordsAPI.DeleteDNSRecord: method not mocked")
+
+	it := api.DNSRecordsIterator(context.Background(), "023e105f4ecef8ad9ca31a8372d0c353", cloudflare.DNSRecord{})
+	assert.False(t, it.Next())
+	assert.ErrorIs(t, it.Err(), ErrNotMocked)
+
+	m := api.(*DNSRecordsAPI)
+	require.Len(t, m.Calls(), 3)
+	calls := m.CallsTo("DNSRecords")
+	require.Len(t, calls, 1)
+	assert.Equal(t, "023e105f4ecef8ad9ca31a8372d0c353", calls[0].Args[1])
+	assert.Equal(t, cloudflare.DNSRecord{Type: "A"}, calls[0].Args[2])
+
+	m.Reset()
+	assert.Empty(t, m.Calls())
+}
@@ -0,0 +1,808 @@
+// Code generated by mockgen from interfaces.go; DO NOT EDIT.
+
+package cfmock
+
+import (
+	"context"
+	"io"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// ZonesAPI is a mock implementation of cloudflare.ZonesAPI.
+type ZonesAPI struct {
+	Recorder
+
+	CreateZoneFunc       func(ctx context.Context, name string, jumpstart bool, account cloudflare.Account, zoneType string) (cloudflare.Zone, error)
+	ZoneIDByNameFunc     func(zoneName string) (string, error)
+	ListZonesFunc        func(ctx context.Context, z ...string) ([]cloudflare.Zone, error)
+	ListZonesContextFunc func(ctx context.Context, opts ...cloudflare.ReqOption) (cloudflare.ZonesResponse, error)
+	ZoneDetailsFunc      func(ctx context.Context, zoneID string) (cloudflare.Zone, error)
+	EditZoneFunc         func(ctx context.Context, zoneID string, zoneOpts cloudflare.ZoneOptions) (cloudflare.Zone, error)
+	DeleteZoneFunc       func(ctx context.Context, zoneID string) (cloudflare.ZoneID, error)
+	PurgeCacheFunc       func(ctx context.Context, zoneID string, pcr cloudflare.PurgeCacheRequest) (cloudflare.PurgeCacheResponse, error)
+	PurgeEverythingFunc  func(ctx context.Context, zoneID string) (cloudflare.PurgeCacheResponse, error)
+	ZoneExportFunc       func(ctx context.Context, zoneID string) (string, error)
+}
+
+var _ cloudflare.ZonesAPI = (*ZonesAPI)(nil)
+
+// CreateZone records the call and invokes CreateZoneFunc.
+func (m *ZonesAPI) CreateZone(ctx context.Context, name string, jumpstart bool, account cloudflare.Account, zoneType string) (cloudflare.Zone, error) {
+	m.record("CreateZone", ctx, name, jumpstart, account, zoneType)
+	if m.CreateZoneFunc == nil {
+		var r0 cloudflare.Zone
+		return r0, notMocked("ZonesAPI", "CreateZone")
+	}
+	return m.CreateZoneFunc(ctx, name, jumpstart, account, zoneType)
+}
+
+// ZoneIDByName records the call and invokes ZoneIDByNameFunc.
+func (m *ZonesAPI) ZoneIDByName(zoneName string) (string, error) {
+	m.record("ZoneIDByName", zoneName)
+	if m.ZoneIDByNameFunc == nil {
+		var r0 string
+		return r0, notMocked("ZonesAPI", "ZoneIDByName")
+	}
+	return m.ZoneIDByNameFunc(zoneName)
+}
+
+// ListZones records the call and invokes ListZonesFunc.
+func (m *ZonesAPI) ListZones(ctx context.Context, z ...string) ([]cloudflare.Zone, error) {
+	m.record("ListZones", ctx, z)
+	if m.ListZonesFunc == nil {
+		var r0 []cloudflare.Zone
+		return r0, notMocked("ZonesAPI", "ListZones")
+	}
+	return m.ListZonesFunc(ctx, z...)
+}
+
+// ListZonesContext records the call and invokes ListZonesContextFunc.
+func (m *ZonesAPI) ListZonesContext(ctx context.Context, opts ...cloudflare.ReqOption) (cloudflare.ZonesResponse, error) {
+	m.record("ListZonesContext", ctx, opts)
+	if m.ListZonesContextFunc == nil {
+		var r0 cloudflare.ZonesResponse
+		return r0, notMocked("ZonesAPI", "ListZonesContext")
+	}
+	return m.ListZonesContextFunc(ctx, opts...)
+}
+
+// ZoneDetails records the call and invokes ZoneDetailsFunc.
+func (m *ZonesAPI) ZoneDetails(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+	m.record("ZoneDetails", ctx, zoneID)
+	if m.ZoneDetailsFunc == nil {
+		var r0 cloudflare.Zone
+		return r0, notMocked("ZonesAPI", "ZoneDetails")
+	}
+	return m.ZoneDetailsFunc(ctx, zoneID)
+}
+
+// EditZone records the call and invokes EditZoneFunc.
+func (m *ZonesAPI) EditZone(ctx context.Context, zoneID string, zoneOpts cloudflare.ZoneOptions) (cloudflare.Zone, error) {
+	m.record("EditZone", ctx, zoneID, zoneOpts)
+	if m.EditZoneFunc == nil {
+		var r0 cloudflare.Zone
+		return r0, notMocked("ZonesAPI", "EditZone")
+	}
+	return m.EditZoneFunc(ctx, zoneID, zoneOpts)
+}
+
+// DeleteZone records the call and invokes DeleteZoneFunc.
+func (m *ZonesAPI) DeleteZone(ctx context.Context, zoneID string) (cloudflare.ZoneID, error) {
+	m.record("DeleteZone", ctx, zoneID)
+	if m.DeleteZoneFunc == nil {
+		var r0 cloudflare.ZoneID
+		return r0, notMocked("ZonesAPI", "DeleteZone")
+	}
+	return m.DeleteZoneFunc(ctx, zoneID)
+}
+
+// PurgeCache records the call and invokes PurgeCacheFunc.
+func (m *ZonesAPI) PurgeCache(ctx context.Context, zoneID string, pcr cloudflare.PurgeCacheRequest) (cloudflare.PurgeCacheResponse, error) {
+	m.record("PurgeCache", ctx, zoneID, pcr)
+	if m.PurgeCacheFunc == nil {
+		var r0 cloudflare.PurgeCacheResponse
+		return r0, notMocked("ZonesAPI", "PurgeCache")
+	}
+	return m.PurgeCacheFunc(ctx, zoneID, pcr)
+}
+
+// PurgeEverything records the call and invokes PurgeEverythingFunc.
+func (m *ZonesAPI) PurgeEverything(ctx context.Context, zoneID string) (cloudflare.PurgeCacheResponse, error) {
+	m.record("PurgeEverything", ctx, zoneID)
+	if m.PurgeEverythingFunc == nil {
+		var r0 cloudflare.PurgeCacheResponse
+		return r0, notMocked("ZonesAPI", "PurgeEverything")
+	}
+	return m.PurgeEverythingFunc(ctx, zoneID)
+}
+
+// ZoneExport records the call and invokes ZoneExportFunc.
+func (m *ZonesAPI) ZoneExport(ctx context.Context, zoneID string) (string, error) {
+	m.record("ZoneExport", ctx, zoneID)
+	if m.ZoneExportFunc == nil {
+		var r0 string
+		return r0, notMocked("ZonesAPI", "ZoneExport")
+	}
+	return m.ZoneExportFunc(ctx, zoneID)
+}
+
+// DNSRecordsAPI is a mock implementation of cloudflare.DNSRecordsAPI.
+type DNSRecordsAPI struct {
+	Recorder
+
+	CreateDNSRecordFunc        func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) (*cloudflare.DNSRecordResponse, error)
+	DNSRecordsFunc             func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error)
+	DNSRecordsIteratorFunc     func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) *cloudflare.Iterator[cloudflare.DNSRecord]
+	ListDNSRecordsFunc         func(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.DNSListParams) ([]cloudflare.DNSRecord, *cloudflare.ResultInfo, error)
+	ListDNSRecordsIteratorFunc func(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.DNSListParams) *cloudflare.Iterator[cloudflare.DNSRecord]
+	DNSRecordFunc              func(ctx context.Context, zoneID string, recordID string) (cloudflare.DNSRecord, error)
+	UpdateDNSRecordFunc        func(ctx context.Context, zoneID string, recordID string, rr cloudflare.DNSRecord) error
+	DeleteDNSRecordFunc        func(ctx context.Context, zoneID string, recordID string) error
+	ImportZoneFileFunc         func(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ImportZoneFileParams) (cloudflare.ImportZoneFileResult, error)
+}
+
+var _ cloudflare.DNSRecordsAPI = (*DNSRecordsAPI)(nil)
+
+// CreateDNSRecord records the call and invokes CreateDNSRecordFunc.
+func (m *DNSRecordsAPI) CreateDNSRecord(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) (*cloudflare.DNSRecordResponse, error) {
+	m.record("CreateDNSRecord", ctx, zoneID, rr)
+	if m.CreateDNSRecordFunc == nil {
+		var r0 *cloudflare.DNSRecordResponse
+		return r0, notMocked("DNSRecordsAPI", "CreateDNSRecord")
+	}
+	return m.CreateDNSRecordFunc(ctx, zoneID, rr)
+}
+
+// DNSRecords records the call and invokes DNSRecordsFunc.
+func (m *DNSRecordsAPI) DNSRecords(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+	m.record("DNSRecords", ctx, zoneID, rr)
+	if m.DNSRecordsFunc == nil {
+		var r0 []cloudflare.DNSRecord
+		return r0, notMocked("DNSRecordsAPI", "DNSRecords")
+	}
+	return m.DNSRecordsFunc(ctx, zoneID, rr)
+}
+
+// DNSRecordsIterator records the call and invokes DNSRecordsIteratorFunc.
+func (m *DNSRecordsAPI) DNSRecordsIterator(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) *cloudflare.Iterator[cloudflare.DNSRecord] {
+	m.record("DNSRecordsIterator", ctx, zoneID, rr)
+	if m.DNSRecordsIteratorFunc == nil {
+		return notMockedIterator[cloudflare.DNSRecord]("DNSRecordsAPI", "DNSRecordsIterator")
+	}
+	return m.DNSRecordsIteratorFunc(ctx, zoneID, rr)
+}
+
+// ListDNSRecords records the call and invokes ListDNSRecordsFunc.
+func (m *DNSRecordsAPI) ListDNSRecords(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.DNSListParams) ([]cloudflare.DNSRecord, *cloudflare.ResultInfo, error) {
+	m.record("ListDNSRecords", ctx, rc, params)
+	if m.ListDNSRecordsFunc == nil {
+		var r0 []cloudflare.DNSRecord
+		var r1 *cloudflare.ResultInfo
+		return r0, r1, notMocked("DNSRecordsAPI", "ListDNSRecords")
+	}
+	return m.ListDNSRecordsFunc(ctx, rc, params)
+}
+
+// ListDNSRecordsIterator records the call and invokes ListDNSRecordsIteratorFunc.
+func (m *DNSRecordsAPI) ListDNSRecordsIterator(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.DNSListParams) *cloudflare.Iterator[cloudflare.DNSRecord] {
+	m.record("ListDNSRecordsIterator", ctx, rc, params)
+	if m.ListDNSRecordsIteratorFunc == nil {
+		return notMockedIterator[cloudflare.DNSRecord]("DNSRecordsAPI", "ListDNSRecordsIterator")
+	}
+	return m.ListDNSRecordsIteratorFunc(ctx, rc, params)
+}
+
+// DNSRecord records the call and invokes DNSRecordFunc.
+func (m *DNSRecordsAPI) DNSRecord(ctx context.Context, zoneID string, recordID string) (cloudflare.DNSRecord, error) {
+	m.record("DNSRecord", ctx, zoneID, recordID)
+	if m.DNSRecordFunc == nil {
+		var r0 cloudflare.DNSRecord
+		return r0, notMocked("DNSRecordsAPI", "DNSRecord")
+	}
+	return m.DNSRecordFunc(ctx, zoneID, recordID)
+}
+
+// UpdateDNSRecord records the call and invokes UpdateDNSRecordFunc.
+func (m *DNSRecordsAPI) UpdateDNSRecord(ctx context.Context, zoneID string, recordID string, rr cloudflare.DNSRecord) error {
+	m.record("UpdateDNSRecord", ctx, zoneID, recordID, rr)
+	if m.UpdateDNSRecordFunc == nil {
+		return notMocked("DNSRecordsAPI", "UpdateDNSRecord")
+	}
+	return m.UpdateDNSRecordFunc(ctx, zoneID, recordID, rr)
+}
+
+// DeleteDNSRecord records the call and invokes DeleteDNSRecordFunc.
+func (m *DNSRecordsAPI) DeleteDNSRecord(ctx context.Context, zoneID string, recordID string) error {
+	m.record("DeleteDNSRecord", ctx, zoneID, recordID)
+	if m.DeleteDNSRecordFunc == nil {
+		return notMocked("DNSRecordsAPI", "DeleteDNSRecord")
+	}
+	return m.DeleteDNSRecordFunc(ctx, zoneID, recordID)
+}
+
+// ImportZoneFile records the call and invokes ImportZoneFileFunc.
+func (m *DNSRecordsAPI) ImportZoneFile(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ImportZoneFileParams) (cloudflare.ImportZoneFileResult, error) {
+	m.record("ImportZoneFile", ctx, rc, params)
+	if m.ImportZoneFileFunc == nil {
+		var r0 cloudflare.ImportZoneFileResult
+		return r0, notMocked("DNSRecordsAPI", "ImportZoneFile")
+	}
+	return m.ImportZoneFileFunc(ctx, rc, params)
+}
+
+// WorkersKVAPI is a mock implementation of cloudflare.WorkersKVAPI.
+type WorkersKVAPI struct {
+	Recorder
+
+	CreateWorkersKVNamespaceFunc  func(ctx context.Context, req *cloudflare.WorkersKVNamespaceRequest) (cloudflare.WorkersKVNamespaceResponse, error)
+	ListWorkersKVNamespacesFunc   func(ctx context.Context) ([]cloudflare.WorkersKVNamespace, error)
+	DeleteWorkersKVNamespaceFunc  func(ctx context.Context, namespaceID string) (cloudflare.Response, error)
+	UpdateWorkersKVNamespaceFunc  func(ctx context.Context, namespaceID string, req *cloudflare.WorkersKVNamespaceRequest) (cloudflare.Response, error)
+	WriteWorkersKVFunc            func(ctx context.Context, namespaceID string, key string, value []byte) (cloudflare.Response, error)
+	WriteWorkersKVBulkFunc        func(ctx context.Context, namespaceID string, kvs cloudflare.WorkersKVBulkWriteRequest) (cloudflare.Response, error)
+	ReadWorkersKVFunc             func(ctx context.Context, namespaceID string, key string) ([]byte, error)
+	ReadWorkersKVStreamFunc       func(ctx context.Context, namespaceID string, key string) (io.ReadCloser, error)
+	DeleteWorkersKVFunc           func(ctx context.Context, namespaceID string, key string) (cloudflare.Response, error)
+	DeleteWorkersKVBulkFunc       func(ctx context.Context, namespaceID string, keys []string) (cloudflare.Response, error)
+	ListWorkersKVsFunc            func(ctx context.Context, namespaceID string) (cloudflare.ListStorageKeysResponse, error)
+	ListWorkersKVsWithOptionsFunc func(ctx context.Context, namespaceID string, o cloudflare.ListWorkersKVsOptions) (cloudflare.ListStorageKeysResponse, error)
+}
+
+var _ cloudflare.WorkersKVAPI = (*WorkersKVAPI)(nil)
+
+// CreateWorkersKVNamespace records the call and invokes CreateWorkersKVNamespaceFunc.
+func (m *WorkersKVAPI) CreateWorkersKVNamespace(ctx context.Context, req *cloudflare.WorkersKVNamespaceRequest) (cloudflare.WorkersKVNamespaceResponse, error) {
+	m.record("CreateWorkersKVNamespace", ctx, req)
+	if m.CreateWorkersKVNamespaceFunc == nil {
+		var r0 cloudflare.WorkersKVNamespaceResponse
+		return r0, notMocked("WorkersKVAPI", "CreateWorkersKVNamespace")
+	}
+	return m.CreateWorkersKVNamespaceFunc(ctx, req)
+}
+
+// ListWorkersKVNamespaces records the call and invokes ListWorkersKVNamespacesFunc.
+func (m *WorkersKVAPI) ListWorkersKVNamespaces(ctx context.Context) ([]cloudflare.WorkersKVNamespace, error) {
+	m.record("ListWorkersKVNamespaces", ctx)
+	if m.ListWorkersKVNamespacesFunc == nil {
+		var r0 []cloudflare.WorkersKVNamespace
+		return r0, notMocked("WorkersKVAPI", "ListWorkersKVNamespaces")
+	}
+	return m.ListWorkersKVNamespacesFunc(ctx)
+}
+
+// DeleteWorkersKVNamespace records the call and invokes DeleteWorkersKVNamespaceFunc.
+func (m *WorkersKVAPI) DeleteWorkersKVNamespace(ctx context.Context, namespaceID string) (cloudflare.Response, error) {
+	m.record("DeleteWorkersKVNamespace", ctx, namespaceID)
+	if m.DeleteWorkersKVNamespaceFunc == nil {
+		var r0 cloudflare.Response
+		return r0, notMocked("WorkersKVAPI", "DeleteWorkersKVNamespace")
+	}
+	return m.DeleteWorkersKVNamespaceFunc(ctx, namespaceID)
+}
+
+// UpdateWorkersKVNamespace records the call and invokes UpdateWorkersKVNamespaceFunc.
+func (m *WorkersKVAPI) UpdateWorkersKVNamespace(ctx context.Context, namespaceID string, req *cloudflare.WorkersKVNamespaceRequest) (cloudflare.Response, error) {
+	m.record("UpdateWorkersKVNamespace", ctx, namespaceID, req)
+	if m.UpdateWorkersKVNamespaceFunc == nil {
+		var r0 cloudflare.Response
+		return r0, notMocked("WorkersKVAPI", "UpdateWorkersKVNamespace")
+	}
+	return m.UpdateWorkersKVNamespaceFunc(ctx, namespaceID, req)
+}
+
+// WriteWorkersKV records the call and invokes WriteWorkersKVFunc.
+func (m *WorkersKVAPI) WriteWorkersKV(ctx context.Context, namespaceID string, key string, value []byte) (cloudflare.Response, error) {
+	m.record("WriteWorkersKV", ctx, namespaceID, key, value)
+	if m.WriteWorkersKVFunc == nil {
+		var r0 cloudflare.Response
+		return r0, notMocked("WorkersKVAPI", "WriteWorkersKV")
+	}
+	return m.WriteWorkersKVFunc(ctx, namespaceID, key, value)
+}
+
+// WriteWorkersKVBulk records the call and invokes WriteWorkersKVBulkFunc.
+func (m *WorkersKVAPI) WriteWorkersKVBulk(ctx context.Context, namespaceID string, kvs cloudflare.WorkersKVBulkWriteRequest) (cloudflare.Response, error) {
+	m.record("WriteWorkersKVBulk", ctx, namespaceID, kvs)
+	if m.WriteWorkersKVBulkFunc == nil {
+		var r0 cloudflare.Response
+		return r0, notMocked("WorkersKVAPI", "WriteWorkersKVBulk")
+	}
+	return m.WriteWorkersKVBulkFunc(ctx, namespaceID, kvs)
+}
+
+// ReadWorkersKV records the call and invokes ReadWorkersKVFunc.
+func (m *WorkersKVAPI) ReadWorkersKV(ctx context.Context, namespaceID string, key string) ([]byte, error) {
+	m.record("ReadWorkersKV", ctx, namespaceID, key)
+	if m.ReadWorkersKVFunc == nil {
+		var r0 []byte
+		return r0, notMocked("WorkersKVAPI", "ReadWorkersKV")
+	}
+	return m.ReadWorkersKVFunc(ctx, namespaceID, key)
+}
+
+// ReadWorkersKVStream records the call and invokes ReadWorkersKVStreamFunc.
+func (m *WorkersKVAPI) ReadWorkersKVStream(ctx context.Context, namespaceID string, key string) (io.ReadCloser, error) {
+	m.record("ReadWorkersKVStream", ctx, namespaceID, key)
+	if m.ReadWorkersKVStreamFunc == nil {
+		var r0 io.ReadCloser
+		return r0, notMocked("WorkersKVAPI", "ReadWorkersKVStream")
+	}
+	return m.ReadWorkersKVStreamFunc(ctx, namespaceID, key)
+}
+
+// DeleteWorkersKV records the call and invokes DeleteWorkersKVFunc.
+func (m *WorkersKVAPI) DeleteWorkersKV(ctx context.Context, namespaceID string, key string) (cloudflare.Response, error) {
+	m.record("DeleteWorkersKV", ctx, namespaceID, key)
+	if m.DeleteWorkersKVFunc == nil {
+		var r0 cloudflare.Response
+		return r0, notMocked("WorkersKVAPI", "DeleteWorkersKV")
+	}
+	return m.DeleteWorkersKVFunc(ctx, namespaceID, key)
+}
+
+// DeleteWorkersKVBulk records the call and invokes DeleteWorkersKVBulkFunc.
+func (m *WorkersKVAPI) DeleteWorkersKVBulk(ctx context.Context, namespaceID string, keys []string) (cloudflare.Response, error) {
+	m.record("DeleteWorkersKVBulk", ctx, namespaceID, keys)
+	if m.DeleteWorkersKVBulkFunc == nil {
+		var r0 cloudflare.Response
+		return r0, notMocked("WorkersKVAPI", "DeleteWorkersKVBulk")
+	}
+	return m.DeleteWorkersKVBulkFunc(ctx, namespaceID, keys)
+}
+
+// ListWorkersKVs records the call and invokes ListWorkersKVsFunc.
+func (m *WorkersKVAPI) ListWorkersKVs(ctx context.Context, namespaceID string) (cloudflare.ListStorageKeysResponse, error) {
+	m.record("ListWorkersKVs", ctx, namespaceID)
+	if m.ListWorkersKVsFunc == nil {
+		var r0 cloudflare.ListStorageKeysResponse
+		return r0, notMocked("WorkersKVAPI", "ListWorkersKVs")
+	}
+	return m.ListWorkersKVsFunc(ctx, namespaceID)
+}
+
+// ListWorkersKVsWithOptions records the call and invokes ListWorkersKVsWithOptionsFunc.
+func (m *WorkersKVAPI) ListWorkersKVsWithOptions(ctx context.Context, namespaceID string, o cloudflare.ListWorkersKVsOptions) (cloudflare.ListStorageKeysResponse, error) {
+	m.record("ListWorkersKVsWithOptions", ctx, namespaceID, o)
+	if m.ListWorkersKVsWithOptionsFunc == nil {
+		var r0 cloudflare.ListStorageKeysResponse
+		return r0, notMocked("WorkersKVAPI", "ListWorkersKVsWithOptions")
+	}
+	return m.ListWorkersKVsWithOptionsFunc(ctx, namespaceID, o)
+}
+
+// RulesetsAPI is a mock implementation of cloudflare.RulesetsAPI.
+type RulesetsAPI struct {
+	Recorder
+
+	ListZoneRulesetsFunc          func(ctx context.Context, zoneID string) ([]cloudflare.Ruleset, error)
+	ListAccountRulesetsFunc       func(ctx context.Context, accountID string) ([]cloudflare.Ruleset, error)
+	GetZoneRulesetFunc            func(ctx context.Context, zoneID string, rulesetID string) (cloudflare.Ruleset, error)
+	GetAccountRulesetFunc         func(ctx context.Context, accountID string, rulesetID string) (cloudflare.Ruleset, error)
+	CreateZoneRulesetFunc         func(ctx context.Context, zoneID string, ruleset cloudflare.Ruleset) (cloudflare.Ruleset, error)
+	CreateAccountRulesetFunc      func(ctx context.Context, accountID string, ruleset cloudflare.Ruleset) (cloudflare.Ruleset, error)
+	DeleteZoneRulesetFunc         func(ctx context.Context, zoneID string, rulesetID string) error
+	DeleteAccountRulesetFunc      func(ctx context.Context, accountID string, rulesetID string) error
+	UpdateZoneRulesetFunc         func(ctx context.Context, zoneID string, rulesetID string, description string, rules []cloudflare.RulesetRule) (cloudflare.Ruleset, error)
+	UpdateAccountRulesetFunc      func(ctx context.Context, accountID string, rulesetID string, description string, rules []cloudflare.RulesetRule) (cloudflare.Ruleset, error)
+	GetZoneRulesetPhaseFunc       func(ctx context.Context, zoneID string, rulesetPhase string) (cloudflare.Ruleset, error)
+	GetAccountRulesetPhaseFunc    func(ctx context.Context, accountID string, rulesetPhase string) (cloudflare.Ruleset, error)
+	UpdateZoneRulesetPhaseFunc    func(ctx context.Context, zoneID string, rulesetPhase string, ruleset cloudflare.Ruleset) (cloudflare.Ruleset, error)
+	UpdateAccountRulesetPhaseFunc func(ctx context.Context, accountID string, rulesetPhase string, ruleset cloudflare.Ruleset) (cloudflare.Ruleset, error)
+}
+
+var _ cloudflare.RulesetsAPI = (*RulesetsAPI)(nil)
+
+// ListZoneRulesets records the call and invokes ListZoneRulesetsFunc.
+func (m *RulesetsAPI) ListZoneRulesets(ctx context.Context, zoneID string) ([]cloudflare.Ruleset, error) {
+	m.record("ListZoneRulesets", ctx, zoneID)
+	if m.ListZoneRulesetsFunc == nil {
+		var r0 []cloudflare.Ruleset
+		return r0, notMocked("RulesetsAPI", "ListZoneRulesets")
+	}
+	return m.ListZoneRulesetsFunc(ctx, zoneID)
+}
+
+// ListAccountRulesets records the call and invokes ListAccountRulesetsFunc.
+func (m *RulesetsAPI) ListAccountRulesets(ctx context.Context, accountID string) ([]cloudflare.Ruleset, error) {
+	m.record("ListAccountRulesets", ctx, accountID)
+	if m.ListAccountRulesetsFunc == nil {
+		var r0 []cloudflare.Ruleset
+		return r0, notMocked("RulesetsAPI", "ListAccountRulesets")
+	}
+	return m.ListAccountRulesetsFunc(ctx, accountID)
+}
+
+// GetZoneRuleset records the call and invokes GetZoneRulesetFunc.
+func (m *RulesetsAPI) GetZoneRuleset(ctx context.Context, zoneID string, rulesetID string) (cloudflare.Ruleset, error) {
+	m.record("GetZoneRuleset", ctx, zoneID, rulesetID)
+	if m.GetZoneRulesetFunc == nil {
+		var r0 cloudflare.Ruleset
+		return r0, notMocked("RulesetsAPI", "GetZoneRuleset")
+	}
+	return m.GetZoneRulesetFunc(ctx, zoneID, rulesetID)
+}
+
+// GetAccountRuleset records the call and invokes GetAccountRulesetFunc.
+func (m *RulesetsAPI) GetAccountRuleset(ctx context.Context, accountID string, rulesetID string) (cloudflare.Ruleset, error) {
+	m.record("GetAccountRuleset", ctx, accountID, rulesetID)
+	if m.GetAccountRulesetFunc == nil {
+		var r0 cloudflare.Ruleset
+		return r0, notMocked("RulesetsAPI", "GetAccountRuleset")
+	}
+	return m.GetAccountRulesetFunc(ctx, accountID, rulesetID)
+}
+
+// CreateZoneRuleset records the call and invokes CreateZoneRulesetFunc.
+func (m *RulesetsAPI) CreateZoneRuleset(ctx context.Context, zoneID string, ruleset cloudflare.Ruleset) (cloudflare.Ruleset, error) {
+	m.record("CreateZoneRuleset", ctx, zoneID, ruleset)
+	if m.CreateZoneRulesetFunc == nil {
+		var r0 cloudflare.Ruleset
+		return r0, notMocked("RulesetsAPI", "CreateZoneRuleset")
+	}
+	return m.CreateZoneRulesetFunc(ctx, zoneID, ruleset)
+}
+
+// CreateAccountRuleset records the call and invokes CreateAccountRulesetFunc.
+func (m *RulesetsAPI) CreateAccountRuleset(ctx context.Context, accountID string, ruleset cloudflare.Ruleset) (cloudflare.Ruleset, error) {
+	m.record("CreateAccountRuleset", ctx, accountID, ruleset)
+	if m.CreateAccountRulesetFunc == nil {
+		var r0 cloudflare.Ruleset
+		return r0, notMocked("RulesetsAPI", "CreateAccountRuleset")
+	}
+	return m.CreateAccountRulesetFunc(ctx, accountID, ruleset)
+}
+
+// DeleteZoneRuleset records the call and invokes DeleteZoneRulesetFunc.
+func (m *RulesetsAPI) DeleteZoneRuleset(ctx context.Context, zoneID string, rulesetID string) error {
+	m.record("DeleteZoneRuleset", ctx, zoneID, rulesetID)
+	if m.DeleteZoneRulesetFunc == nil {
+		return notMocked("RulesetsAPI", "DeleteZoneRuleset")
+	}
+	return m.DeleteZoneRulesetFunc(ctx, zoneID, rulesetID)
+}
+
+// DeleteAccountRuleset records the call and invokes DeleteAccountRulesetFunc.
+func (m *RulesetsAPI) DeleteAccountRuleset(ctx context.Context, accountID string, rulesetID string) error {
+	m.record("DeleteAccountRuleset", ctx, accountID, rulesetID)
+	if m.DeleteAccountRulesetFunc == nil {
+		return notMocked("RulesetsAPI", "DeleteAccountRuleset")
+	}
+	return m.DeleteAccountRulesetFunc(ctx, accountID, rulesetID)
+}
+
+// UpdateZoneRuleset records the call and invokes UpdateZoneRulesetFunc.
+func (m *RulesetsAPI) UpdateZoneRuleset(ctx context.Context, zoneID string, rulesetID string, description string, rules []cloudflare.RulesetRule) (cloudflare.Ruleset, error) {
+	m.record("UpdateZoneRuleset", ctx, zoneID, rulesetID, description, rules)
+	if m.UpdateZoneRulesetFunc == nil {
+		var r0 cloudflare.Ruleset
+		return r0, notMocked("RulesetsAPI", "UpdateZoneRuleset")
+	}
+	return m.UpdateZoneRulesetFunc(ctx, zoneID, rulesetID, description, rules)
+}
+
+// UpdateAccountRuleset records the call and invokes UpdateAccountRulesetFunc.
+func (m *RulesetsAPI) UpdateAccountRuleset(ctx context.Context, accountID string, rulesetID string, description string, rules []cloudflare.RulesetRule) (cloudflare.Ruleset, error) {
+	m.record("UpdateAccountRuleset", ctx, accountID, rulesetID, description, rules)
+	if m.UpdateAccountRulesetFunc == nil {
+		var r0 cloudflare.Ruleset
+		return r0, notMocked("RulesetsAPI", "UpdateAccountRuleset")
+	}
+	return m.UpdateAccountRulesetFunc(ctx, accountID, rulesetID, description, rules)
+}
+
+// GetZoneRulesetPhase records the call and invokes GetZoneRulesetPhaseFunc.
+func (m *RulesetsAPI) GetZoneRulesetPhase(ctx context.Context, zoneID string, rulesetPhase string) (cloudflare.Ruleset, error) {
+	m.record("GetZoneRulesetPhase", ctx, zoneID, rulesetPhase)
+	if m.GetZoneRulesetPhaseFunc == nil {
+		var r0 cloudflare.Ruleset
+		return r0, notMocked("RulesetsAPI", "GetZoneRulesetPhase")
+	}
+	return m.GetZoneRulesetPhaseFunc(ctx, zoneID, rulesetPhase)
+}
+
+// GetAccountRulesetPhase records the call and invokes GetAccountRulesetPhaseFunc.
+func (m *RulesetsAPI) GetAccountRulesetPhase(ctx context.Context, accountID string, rulesetPhase string) (cloudflare.Ruleset, error) {
+	m.record("GetAccountRulesetPhase", ctx, accountID, rulesetPhase)
+	if m.GetAccountRulesetPhaseFunc == nil {
+		var r0 cloudflare.Ruleset
+		return r0, notMocked("RulesetsAPI", "GetAccountRulesetPhase")
+	}
+	return m.GetAccountRulesetPhaseFunc(ctx, accountID, rulesetPhase)
+}
+
+// UpdateZoneRulesetPhase records the call and invokes UpdateZoneRulesetPhaseFunc.
+func (m *RulesetsAPI) UpdateZoneRulesetPhase(ctx context.Context, zoneID string, rulesetPhase string, ruleset cloudflare.Ruleset) (cloudflare.Ruleset, error) {
+	m.record("UpdateZoneRulesetPhase", ctx, zoneID, rulesetPhase, ruleset)
+	if m.UpdateZoneRulesetPhaseFunc == nil {
+		var r0 cloudflare.Ruleset
+		return r0, notMocked("RulesetsAPI", "UpdateZoneRulesetPhase")
+	}
+	return m.UpdateZoneRulesetPhaseFunc(ctx, zoneID, rulesetPhase, ruleset)
+}
+
+// UpdateAccountRulesetPhase records the call and invokes UpdateAccountRulesetPhaseFunc.
+func (m *RulesetsAPI) UpdateAccountRulesetPhase(ctx context.Context, accountID string, rulesetPhase string, ruleset cloudflare.Ruleset) (cloudflare.Ruleset, error) {
+	m.record("UpdateAccountRulesetPhase", ctx, accountID, rulesetPhase, ruleset)
+	if m.UpdateAccountRulesetPhaseFunc == nil {
+		var r0 cloudflare.Ruleset
+		return r0, notMocked("RulesetsAPI", "UpdateAccountRulesetPhase")
+	}
+	return m.UpdateAccountRulesetPhaseFunc(ctx, accountID, rulesetPhase, ruleset)
+}
+
+// ListsAPI is a mock implementation of cloudflare.ListsAPI.
+type ListsAPI struct {
+	Recorder
+
+	ListListsFunc             func(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListListsParams) ([]cloudflare.List, error)
+	CreateListFunc            func(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListCreateParams) (cloudflare.List, error)
+	GetListFunc               func(ctx context.Context, rc *cloudflare.ResourceContainer, listID string) (cloudflare.List, error)
+	UpdateListFunc            func(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListUpdateParams) (cloudflare.List, error)
+	DeleteListFunc            func(ctx context.Context, rc *cloudflare.ResourceContainer, listID string) (cloudflare.ListDeleteResponse, error)
+	ListListItemsFunc         func(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListListItemsParams) ([]cloudflare.ListItem, error)
+	ListListItemsIteratorFunc func(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListListItemsParams) *cloudflare.Iterator[cloudflare.ListItem]
+	GetListItemFunc           func(ctx context.Context, rc *cloudflare.ResourceContainer, listID string, itemID string) (cloudflare.ListItem, error)
+	CreateListItemFunc        func(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListCreateItemParams) ([]cloudflare.ListItem, error)
+	CreateListItemsFunc       func(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListCreateItemsParams) ([]cloudflare.ListItem, error)
+	ReplaceListItemsFunc      func(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListReplaceItemsParams) ([]cloudflare.ListItem, error)
+	DeleteListItemsFunc       func(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListDeleteItemsParams) ([]cloudflare.ListItem, error)
+	GetListBulkOperationFunc  func(ctx context.Context, rc *cloudflare.ResourceContainer, ID string) (cloudflare.ListBulkOperation, error)
+}
+
+var _ cloudflare.ListsAPI = (*ListsAPI)(nil)
+
+// ListLists records the call and invokes ListListsFunc.
+func (m *ListsAPI) ListLists(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListListsParams) ([]cloudflare.List, error) {
+	m.record("ListLists", ctx, rc, params)
+	if m.ListListsFunc == nil {
+		var r0 []cloudflare.List
+		return r0, notMocked("ListsAPI", "ListLists")
+	}
+	return m.ListListsFunc(ctx, rc, params)
+}
+
+// CreateList records the call and invokes CreateListFunc.
+func (m *ListsAPI) CreateList(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListCreateParams) (cloudflare.List, error) {
+	m.record("CreateList", ctx, rc, params)
+	if m.CreateListFunc == nil {
+		var r0 cloudflare.List
+		return r0, notMocked("ListsAPI", "CreateList")
+	}
+	return m.CreateListFunc(ctx, rc, params)
+}
+
+// GetList records the call and invokes GetListFunc.
+func (m *ListsAPI) GetList(ctx context.Context, rc *cloudflare.ResourceContainer, listID string) (cloudflare.List, error) {
+	m.record("GetList", ctx, rc, listID)
+	if m.GetListFunc == nil {
+		var r0 cloudflare.List
+		return r0, notMocked("ListsAPI", "GetList")
+	}
+	return m.GetListFunc(ctx, rc, listID)
+}
+
+// UpdateList records the call and invokes UpdateListFunc.
+func (m *ListsAPI) UpdateList(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListUpdateParams) (cloudflare.List, error) {
+	m.record("UpdateList", ctx, rc, params)
+	if m.UpdateListFunc == nil {
+		var r0 cloudflare.List
+		return r0, notMocked("ListsAPI", "UpdateList")
+	}
+	return m.UpdateListFunc(ctx, rc, params)
+}
+
+// DeleteList records the call and invokes DeleteListFunc.
+func (m *ListsAPI) DeleteList(ctx context.Context, rc *cloudflare.ResourceContainer, listID string) (cloudflare.ListDeleteResponse, error) {
+	m.record("DeleteList", ctx, rc, listID)
+	if m.DeleteListFunc == nil {
+		var r0 cloudflare.ListDeleteResponse
+		return r0, notMocked("ListsAPI", "DeleteList")
+	}
+	return m.DeleteListFunc(ctx, rc, listID)
+}
+
+// ListListItems records the call and invokes ListListItemsFunc.
+func (m *ListsAPI) ListListItems(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListListItemsParams) ([]cloudflare.ListItem, error) {
+	m.record("ListListItems", ctx, rc, params)
+	if m.ListListItemsFunc == nil {
+		var r0 []cloudflare.ListItem
+		return r0, notMocked("ListsAPI", "ListListItems")
+	}
+	return m.ListListItemsFunc(ctx, rc, params)
+}
+
+// ListListItemsIterator records the call and invokes ListListItemsIteratorFunc.
+func (m *ListsAPI) ListListItemsIterator(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListListItemsParams) *cloudflare.Iterator[cloudflare.ListItem] {
+	m.record("ListListItemsIterator", ctx, rc, params)
+	if m.ListListItemsIteratorFunc == nil {
+		return notMockedIterator[cloudflare.ListItem]("ListsAPI", "ListListItemsIterator")
+	}
+	return m.ListListItemsIteratorFunc(ctx, rc, params)
+}
+
+// GetListItem records the call and invokes GetListItemFunc.
+func (m *ListsAPI) GetListItem(ctx context.Context, rc *cloudflare.ResourceContainer, listID string, itemID string) (cloudflare.ListItem, error) {
+	m.record("GetListItem", ctx, rc, listID, itemID)
+	if m.GetListItemFunc == nil {
+		var r0 cloudflare.ListItem
+		return r0, notMocked("ListsAPI", "GetListItem")
+	}
+	return m.GetListItemFunc(ctx, rc, listID, itemID)
+}
+
+// CreateListItem records the call and invokes CreateListItemFunc.
+func (m *ListsAPI) CreateListItem(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListCreateItemParams) ([]cloudflare.ListItem, error) {
+	m.record("CreateListItem", ctx, rc, params)
+	if m.CreateListItemFunc == nil {
+		var r0 []cloudflare.ListItem
+		return r0, notMocked("ListsAPI", "CreateListItem")
+	}
+	return m.CreateListItemFunc(ctx, rc, params)
+}
+
+// CreateListItems records the call and invokes CreateListItemsFunc.
+func (m *ListsAPI) CreateListItems(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListCreateItemsParams) ([]cloudflare.ListItem, error) {
+	m.record("CreateListItems", ctx, rc, params)
+	if m.CreateListItemsFunc == nil {
+		var r0 []cloudflare.ListItem
+		return r0, notMocked("ListsAPI", "CreateListItems")
+	}
+	return m.CreateListItemsFunc(ctx, rc, params)
+}
+
+// ReplaceListItems records the call and invokes ReplaceListItemsFunc.
+func (m *ListsAPI) ReplaceListItems(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListReplaceItemsParams) ([]cloudflare.ListItem, error) {
+	m.record("ReplaceListItems", ctx, rc, params)
+	if m.ReplaceListItemsFunc == nil {
+		var r0 []cloudflare.ListItem
+		return r0, notMocked("ListsAPI", "ReplaceListItems")
+	}
+	return m.ReplaceListItemsFunc(ctx, rc, params)
+}
+
+// DeleteListItems records the call and invokes DeleteListItemsFunc.
+func (m *ListsAPI) DeleteListItems(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListDeleteItemsParams) ([]cloudflare.ListItem, error) {
+	m.record("DeleteListItems", ctx, rc, params)
+	if m.DeleteListItemsFunc == nil {
+		var r0 []cloudflare.ListItem
+		return r0, notMocked("ListsAPI", "DeleteListItems")
+	}
+	return m.DeleteListItemsFunc(ctx, rc, params)
+}
+
+// GetListBulkOperation records the call and invokes GetListBulkOperationFunc.
+func (m *ListsAPI) GetListBulkOperation(ctx context.Context, rc *cloudflare.ResourceContainer, ID string) (cloudflare.ListBulkOperation, error) {
+	m.record("GetListBulkOperation", ctx, rc, ID)
+	if m.GetListBulkOperationFunc == nil {
+		var r0 cloudflare.ListBulkOperation
+		return r0, notMocked("ListsAPI", "GetListBulkOperation")
+	}
+	return m.GetListBulkOperationFunc(ctx, rc, ID)
+}
+
+// TunnelsAPI is a mock implementation of cloudflare.TunnelsAPI.
+type TunnelsAPI struct {
+	Recorder
+
+	TunnelsFunc                   func(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.TunnelListParams) ([]cloudflare.Tunnel, error)
+	TunnelsIteratorFunc           func(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.TunnelListParams) *cloudflare.Iterator[cloudflare.Tunnel]
+	TunnelFunc                    func(ctx context.Context, rc *cloudflare.ResourceContainer, tunnelID string) (cloudflare.Tunnel, error)
+	CreateTunnelFunc              func(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.TunnelCreateParams) (cloudflare.Tunnel, error)
+	UpdateTunnelFunc              func(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.TunnelUpdateParams) (cloudflare.Tunnel, error)
+	DeleteTunnelFunc              func(ctx context.Context, rc *cloudflare.ResourceContainer, tunnelID string) error
+	TunnelConnectionsFunc         func(ctx context.Context, rc *cloudflare.ResourceContainer, tunnelID string) ([]cloudflare.Connection, error)
+	CleanupTunnelConnectionsFunc  func(ctx context.Context, rc *cloudflare.ResourceContainer, tunnelID string) error
+	TunnelTokenFunc               func(ctx context.Context, rc *cloudflare.ResourceContainer, tunnelID string) (string, error)
+	GetTunnelConfigurationFunc    func(ctx context.Context, rc *cloudflare.ResourceContainer, tunnelID string) (cloudflare.TunnelConfigurationResult, error)
+	UpdateTunnelConfigurationFunc func(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.TunnelConfigurationParams) (cloudflare.TunnelConfigurationResult, error)
+}
+
+var _ cloudflare.TunnelsAPI = (*TunnelsAPI)(nil)
+
+// Tunnels records the call and invokes TunnelsFunc.
+func (m *TunnelsAPI) Tunnels(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.TunnelListParams) ([]cloudflare.Tunnel, error) {
+	m.record("Tunnels", ctx, rc, params)
+	if m.TunnelsFunc == nil {
+		var r0 []cloudflare.Tunnel
+		return r0, notMocked("TunnelsAPI", "Tunnels")
+	}
+	return m.TunnelsFunc(ctx, rc, params)
+}
+
+// TunnelsIterator records the call and invokes TunnelsIteratorFunc.
+func (m *TunnelsAPI) TunnelsIterator(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.TunnelListParams) *cloudflare.Iterator[cloudflare.Tunnel] {
+	m.record("TunnelsIterator", ctx, rc, params)
+	if m.TunnelsIteratorFunc == nil {
+		return notMockedIterator[cloudflare.Tunnel]("TunnelsAPI", "TunnelsIterator")
+	}
+	return m.TunnelsIteratorFunc(ctx, rc, params)
+}
+
+// Tunnel records the call and invokes TunnelFunc.
+func (m *TunnelsAPI) Tunnel(ctx context.Context, rc *cloudflare.ResourceContainer, tunnelID string) (cloudflare.Tunnel, error) {
+	m.record("Tunnel", ctx, rc, tunnelID)
+	if m.TunnelFunc == nil {
+		var r0 cloudflare.Tunnel
+		return r0, notMocked("TunnelsAPI", "Tunnel")
+	}
+	return m.TunnelFunc(ctx, rc, tunnelID)
+}
+
+// CreateTunnel records the call and invokes CreateTunnelFunc.
+func (m *TunnelsAPI) CreateTunnel(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.TunnelCreateParams) (cloudflare.Tunnel, error) {
+	m.record("CreateTunnel", ctx, rc, params)
+	if m.CreateTunnelFunc == nil {
+		var r0 cloudflare.Tunnel
+		return r0, notMocked("TunnelsAPI", "CreateTunnel")
+	}
+	return m.CreateTunnelFunc(ctx, rc, params)
+}
+
+// UpdateTunnel records the call and invokes UpdateTunnelFunc.
+func (m *TunnelsAPI) UpdateTunnel(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.TunnelUpdateParams) (cloudflare.Tunnel, error) {
+	m.record("UpdateTunnel", ctx, rc, params)
+	if m.UpdateTunnelFunc == nil {
+		var r0 cloudflare.Tunnel
+		return r0, notMocked("TunnelsAPI", "UpdateTunnel")
+	}
+	return m.UpdateTunnelFunc(ctx, rc, params)
+}
+
+// DeleteTunnel records the call and invokes DeleteTunnelFunc.
+func (m *TunnelsAPI) DeleteTunnel(ctx context.Context, rc *cloudflare.ResourceContainer, tunnelID string) error {
+	m.record("DeleteTunnel", ctx, rc, tunnelID)
+	if m.DeleteTunnelFunc == nil {
+		return notMocked("TunnelsAPI", "DeleteTunnel")
+	}
+	return m.DeleteTunnelFunc(ctx, rc, tunnelID)
+}
+
+// TunnelConnections records the call and invokes TunnelConnectionsFunc.
+func (m *TunnelsAPI) TunnelConnections(ctx context.Context, rc *cloudflare.ResourceContainer, tunnelID string) ([]cloudflare.Connection, error) {
+	m.record("TunnelConnections", ctx, rc, tunnelID)
+	if m.TunnelConnectionsFunc == nil {
+		var r0 []cloudflare.Connection
+		return r0, notMocked("TunnelsAPI", "TunnelConnections")
+	}
+	return m.TunnelConnectionsFunc(ctx, rc, tunnelID)
+}
+
+// CleanupTunnelConnections records the call and invokes CleanupTunnelConnectionsFunc.
+func (m *TunnelsAPI) CleanupTunnelConnections(ctx context.Context, rc *cloudflare.ResourceContainer, tunnelID string) error {
+	m.record("CleanupTunnelConnections", ctx, rc, tunnelID)
+	if m.CleanupTunnelConnectionsFunc == nil {
+		return notMocked("TunnelsAPI", "CleanupTunnelConnections")
+	}
+	return m.CleanupTunnelConnectionsFunc(ctx, rc, tunnelID)
+}
+
+// TunnelToken records the call and invokes TunnelTokenFunc.
+func (m *TunnelsAPI) TunnelToken(ctx context.Context, rc *cloudflare.ResourceContainer, tunnelID string) (string, error) {
+	m.record("TunnelToken", ctx, rc, tunnelID)
+	if m.TunnelTokenFunc == nil {
+		var r0 string
+		return r0, notMocked("TunnelsAPI", "TunnelToken")
+	}
+	return m.TunnelTokenFunc(ctx, rc, tunnelID)
+}
+
+// GetTunnelConfiguration records the call and invokes GetTunnelConfigurationFunc.
+func (m *TunnelsAPI) GetTunnelConfiguration(ctx context.Context, rc *cloudflare.ResourceContainer, tunnelID string) (cloudflare.TunnelConfigurationResult, error) {
+	m.record("GetTunnelConfiguration", ctx, rc, tunnelID)
+	if m.GetTunnelConfigurationFunc == nil {
+		var r0 cloudflare.TunnelConfigurationResult
+		return r0, notMocked("TunnelsAPI", "GetTunnelConfiguration")
+	}
+	return m.GetTunnelConfigurationFunc(ctx, rc, tunnelID)
+}
+
+// UpdateTunnelConfiguration records the call and invokes UpdateTunnelConfigurationFunc.
+func (m *TunnelsAPI) UpdateTunnelConfiguration(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.TunnelConfigurationParams) (cloudflare.TunnelConfigurationResult, error) {
+	m.record("UpdateTunnelConfiguration", ctx, rc, params)
+	if m.UpdateTunnelConfigurationFunc == nil {
+		var r0 cloudflare.TunnelConfigurationResult
+		return r0, notMocked("TunnelsAPI", "UpdateTunnelConfiguration")
+	}
+	return m.UpdateTunnelConfigurationFunc(ctx, rc, params)
+}
@@ -0,0 +1,166 @@
+// Package cfotel traces and measures the requests made by a cloudflare.API
+// with OpenTelemetry:
+//
+//	t, err := cfotel.New(otel.GetTracerProvider(), otel.GetMeterProvider())
+//	if err != nil {
+//		return err
+//	}
+//	api, err := cloudflare.NewWithAPIToken(token, cloudflare.UsingTelemetry(t))
+//
+// Every API call is wrapped in a span named after the logical operation (e.g.
+// `dns_records.list`) with the zone or account ID, status code, `cf-ray`,
+// retry count and rate limiter wait time as attributes. Metrics are recorded
+// for request latency, retries, errors (by type) and time spent waiting on the
+// rate limiter.
+//
+// The package is a separate module so that the cloudflare package doesn't
+// depend on OpenTelemetry.
+package cfotel
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the tracer and meter used by the library.
+const instrumentationName = "github.com/cloudflare/cloudflare-go"
+
+// Telemetry is a cloudflare.Telemetry that reports requests as OpenTelemetry
+// spans and metrics.
+type Telemetry struct {
+	// tracer is nil when tracing is disabled.
+	tracer          trace.Tracer
+	duration        metric.Float64Histogram
+	retries         metric.Int64Counter
+	errors          metric.Int64Counter
+	rateLimiterWait metric.Float64Histogram
+}
+
+var _ cloudflare.Telemetry = (*Telemetry)(nil)
+
+// New returns a Telemetry that creates spans with `tp` and records metrics
+// with `mp`. Either may be nil to disable tracing or metrics.
+func New(tp trace.TracerProvider, mp metric.MeterProvider) (*Telemetry, error) {
+	if mp == nil {
+		mp = noop.NewMeterProvider()
+	}
+
+	t := &Telemetry{}
+	if tp != nil {
+		t.tracer = tp.Tracer(instrumentationName, trace.WithInstrumentationVersion(cloudflare.Version))
+	}
+
+	meter := mp.Meter(instrumentationName, metric.WithInstrumentationVersion(cloudflare.Version))
+
+	var err error
+	t.duration, err = meter.Float64Histogram("cloudflare.client.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of API requests, including retries and rate limiting."))
+	if err != nil {
+		return nil, err
+	}
+
+	t.retries, err = meter.Int64Counter("cloudflare.client.request.retries",
+		metric.WithDescription("Number of times API requests were retried."))
+	if err != nil {
+		return nil, err
+	}
+
+	t.errors, err = meter.Int64Counter("cloudflare.client.request.errors",
+		metric.WithDescription("Number of API requests that failed, by error type."))
+	if err != nil {
+		return nil, err
+	}
+
+	t.rateLimiterWait, err = meter.Float64Histogram("cloudflare.client.ratelimiter.wait",
+		metric.WithUnit("s"),
+		metric.WithDescription("Time API requests spent waiting on the client side rate limiter."))
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// StartRequest begins the span for a request. The context is returned
+// unchanged when tracing is disabled.
+func (t *Telemetry) StartRequest(ctx context.Context, req cloudflare.TelemetryRequest) context.Context {
+	if t.tracer == nil {
+		return ctx
+	}
+
+	attrs := []attribute.KeyValue{attribute.String("http.method", req.Method)}
+	if req.ZoneID != "" {
+		attrs = append(attrs, attribute.String("cloudflare.zone_id", req.ZoneID))
+	}
+	if req.AccountID != "" {
+		attrs = append(attrs, attribute.String("cloudflare.account_id", req.AccountID))
+	}
+
+	ctx, _ = t.tracer.Start(ctx, req.Operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+
+	return ctx
+}
+
+// RateLimiterWait records time spent blocked on the rate limiter.
+func (t *Telemetry) RateLimiterWait(ctx context.Context, req cloudflare.TelemetryRequest, wait time.Duration) {
+	t.rateLimiterWait.Record(ctx, wait.Seconds(),
+		metric.WithAttributes(attribute.String("cloudflare.operation", req.Operation)))
+}
+
+// EndRequest finishes the span and records the metrics for the request.
+func (t *Telemetry) EndRequest(ctx context.Context, req cloudflare.TelemetryRequest, res cloudflare.TelemetryResult) {
+	attrs := []attribute.KeyValue{
+		attribute.String("cloudflare.operation", req.Operation),
+		attribute.String("http.method", req.Method),
+	}
+	if res.StatusCode != 0 {
+		attrs = append(attrs, attribute.Int("http.status_code", res.StatusCode))
+	}
+
+	t.duration.Record(ctx, res.Duration.Seconds(), metric.WithAttributes(attrs...))
+	if res.Retries > 0 {
+		t.retries.Add(ctx, int64(res.Retries), metric.WithAttributes(attrs...))
+	}
+	if res.Err != nil {
+		attrs = append(attrs, attribute.String("error.type", res.ErrorType))
+		t.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
+
+	// without a tracer the span in `ctx` belongs to the caller.
+	if t.tracer == nil {
+		return
+	}
+
+	span := trace.SpanFromContext(ctx)
+	spanAttrs := []attribute.KeyValue{
+		attribute.Int("cloudflare.retry_count", res.Retries),
+		attribute.Float64("cloudflare.ratelimiter.wait", res.RateLimiterWait.Seconds()),
+	}
+	if res.StatusCode != 0 {
+		spanAttrs = append(spanAttrs, attribute.Int("http.status_code", res.StatusCode))
+	}
+	if res.RayID != "" {
+		spanAttrs = append(spanAttrs, attribute.String("cloudflare.ray_id", res.RayID))
+	}
+	span.SetAttributes(spanAttrs...)
+
+	if res.DryRun {
+		span.SetAttributes(attribute.Bool("cloudflare.dry_run", true))
+	} else if res.Err != nil {
+		span.SetAttributes(attribute.String("error.type", res.ErrorType))
+		span.RecordError(res.Err)
+		span.SetStatus(codes.Error, res.Err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,253 @@
+package cfotel
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	testZoneID   = "d56084adb405e0b7e32c52321bf07be6"
+	testRecordID = "372e67954025e0ba6aaa6d586b9e0b59"
+)
+
+// recordingTracer is a trace.TracerProvider and trace.Tracer that keeps every
+// span it starts.
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordingSpan
+}
+
+func (t *recordingTracer) Tracer(string, ...trace.TracerOption) trace.Tracer { return t }
+
+func (t *recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	config := trace.NewSpanStartConfig(opts...)
+	span := &recordingSpan{
+		Span:  trace.SpanFromContext(context.Background()),
+		name:  name,
+		kind:  config.SpanKind(),
+		attrs: map[attribute.Key]attribute.Value{},
+	}
+	span.SetAttributes(config.Attributes()...)
+
+	t.mu.Lock()
+	t.spans = append(t.spans, span)
+	t.mu.Unlock()
+
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+type recordingSpan struct {
+	trace.Span
+	name   string
+	kind   trace.SpanKind
+	attrs  map[attribute.Key]attribute.Value
+	status codes.Code
+	errs   []error
+	ended  bool
+}
+
+func (s *recordingSpan) SetAttributes(kv ...attribute.KeyValue) {
+	for _, a := range kv {
+		s.attrs[a.Key] = a.Value
+	}
+}
+
+func (s *recordingSpan) SetStatus(code codes.Code, _ string) { s.status = code }
+
+func (s *recordingSpan) RecordError(err error, _ ...trace.EventOption) { s.errs = append(s.errs, err) }
+
+func (s *recordingSpan) End(...trace.SpanEndOption) { s.ended = true }
+
+// recordingMeter is a metric.MeterProvider and metric.Meter that sums the
+// counters and histograms it hands out, keyed by instrument name.
+type recordingMeter struct {
+	noop.Meter
+
+	mu         sync.Mutex
+	counters   map[string]int64
+	histograms map[string][]float64
+	attrs      map[string][]attribute.Set
+}
+
+func newRecordingMeter() *recordingMeter {
+	return &recordingMeter{
+		counters:   map[string]int64{},
+		histograms: map[string][]float64{},
+		attrs:      map[string][]attribute.Set{},
+	}
+}
+
+type recordingMeterProvider struct {
+	noop.MeterProvider
+	meter *recordingMeter
+}
+
+func (p recordingMeterProvider) Meter(string, ...metric.MeterOption) metric.Meter { return p.meter }
+
+func (m *recordingMeter) Int64Counter(name string, _ ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	return recordingCounter{name: name, meter: m}, nil
+}
+
+func (m *recordingMeter) Float64Histogram(name string, _ ...metric.Float64HistogramOption) (metric.Float64Histogram, error) {
+	return recordingHistogram{name: name, meter: m}, nil
+}
+
+type recordingCounter struct {
+	noop.Int64Counter
+	name  string
+	meter *recordingMeter
+}
+
+func (c recordingCounter) Add(_ context.Context, incr int64, opts ...metric.AddOption) {
+	c.meter.mu.Lock()
+	defer c.meter.mu.Unlock()
+	c.meter.counters[c.name] += incr
+	c.meter.attrs[c.name] = append(c.meter.attrs[c.name], metric.NewAddConfig(opts).Attributes())
+}
+
+type recordingHistogram struct {
+	noop.Float64Histogram
+	name  string
+	meter *recordingMeter
+}
+
+func (h recordingHistogram) Record(_ context.Context, v float64, opts ...metric.RecordOption) {
+	h.meter.mu.Lock()
+	defer h.meter.mu.Unlock()
+	h.meter.histograms[h.name] = append(h.meter.histograms[h.name], v)
+	h.meter.attrs[h.name] = append(h.meter.attrs[h.name], metric.NewRecordConfig(opts).Attributes())
+}
+
+// newClient returns an API client instrumented with `telemetry` that sends
+// requests to `handler`.
+func newClient(t *testing.T, telemetry *Telemetry, handler http.HandlerFunc, opts ...cloudflare.Option) *cloudflare.API {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	opts = append([]cloudflare.Option{cloudflare.BaseURL(srv.URL), cloudflare.UsingTelemetry(telemetry)}, opts...)
+	api, err := cloudflare.NewWithAPIToken("token", opts...)
+	require.NoError(t, err)
+
+	return api
+}
+
+func TestTelemetry_Request(t *testing.T) {
+	tracer := &recordingTracer{}
+	meter := newRecordingMeter()
+	telemetry, err := New(tracer, recordingMeterProvider{meter: meter})
+	require.NoError(t, err)
+
+	requests := 0
+	api := newClient(t, telemetry, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("cf-ray", fmt.Sprintf("ray-%d", requests))
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "%s"}}`, testRecordID)
+	}, cloudflare.UsingRetryer(cloudflare.RetryPolicy{MaxRetries: 2}))
+
+	_, err = api.DNSRecord(context.Background(), testZoneID, testRecordID)
+	require.NoError(t, err)
+
+	require.Len(t, tracer.spans, 1)
+	span := tracer.spans[0]
+	assert.Equal(t, "dns_records.get", span.name)
+	assert.Equal(t, trace.SpanKindClient, span.kind)
+	assert.True(t, span.ended)
+	assert.Equal(t, codes.Unset, span.status)
+	assert.Equal(t, testZoneID, span.attrs["cloudflare.zone_id"].AsString())
+	assert.Equal(t, http.MethodGet, span.attrs["http.method"].AsString())
+	assert.Equal(t, int64(http.StatusOK), span.attrs["http.status_code"].AsInt64())
+	assert.Equal(t, "ray-2", span.attrs["cloudflare.ray_id"].AsString())
+	assert.Equal(t, int64(1), span.attrs["cloudflare.retry_count"].AsInt64())
+	assert.GreaterOrEqual(t, span.attrs["cloudflare.ratelimiter.wait"].AsFloat64(), float64(0))
+
+	assert.Len(t, meter.histograms["cloudflare.client.request.duration"], 1)
+	assert.Len(t, meter.histograms["cloudflare.client.ratelimiter.wait"], 2)
+	assert.Equal(t, int64(1), meter.counters["cloudflare.client.request.retries"])
+	assert.Equal(t, int64(0), meter.counters["cloudflare.client.request.errors"])
+}
+
+func TestTelemetry_RequestError(t *testing.T) {
+	tracer := &recordingTracer{}
+	meter := newRecordingMeter()
+	telemetry, err := New(tracer, recordingMeterProvider{meter: meter})
+	require.NoError(t, err)
+
+	api := newClient(t, telemetry, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"success": false, "errors": [{"code": 81044, "message": "Record does not exist."}], "messages": [], "result": null}`)
+	})
+
+	_, err = api.DNSRecord(context.Background(), testZoneID, testRecordID)
+	require.Error(t, err)
+
+	require.Len(t, tracer.spans, 1)
+	span := tracer.spans[0]
+	assert.Equal(t, codes.Error, span.status)
+	assert.Len(t, span.errs, 1)
+	assert.Equal(t, "not_found", span.attrs["error.type"].AsString())
+	assert.Equal(t, int64(http.StatusNotFound), span.attrs["http.status_code"].AsInt64())
+
+	assert.Equal(t, int64(1), meter.counters["cloudflare.client.request.errors"])
+	errorType, ok := meter.attrs["cloudflare.client.request.errors"][0].Value("error.type")
+	assert.True(t, ok)
+	assert.Equal(t, "not_found", errorType.AsString())
+}
+
+func TestTelemetry_DryRun(t *testing.T) {
+	tracer := &recordingTracer{}
+	meter := newRecordingMeter()
+	telemetry, err := New(tracer, recordingMeterProvider{meter: meter})
+	require.NoError(t, err)
+
+	api := newClient(t, telemetry, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	}, cloudflare.UsingDryRun(&cloudflare.DryRunPlan{}))
+
+	err = api.DeleteDNSRecord(context.Background(), testZoneID, testRecordID)
+	require.ErrorIs(t, err, cloudflare.ErrDryRun)
+
+	require.Len(t, tracer.spans, 1)
+	span := tracer.spans[0]
+	assert.Equal(t, codes.Unset, span.status)
+	assert.Empty(t, span.errs)
+	assert.True(t, span.attrs["cloudflare.dry_run"].AsBool())
+	assert.Zero(t, meter.counters["cloudflare.client.request.errors"])
+}
+
+func TestTelemetry_MetricsOnly(t *testing.T) {
+	meter := newRecordingMeter()
+	telemetry, err := New(nil, recordingMeterProvider{meter: meter})
+	require.NoError(t, err)
+
+	// the caller's span must be left alone when tracing is disabled.
+	parent := &recordingSpan{Span: trace.SpanFromContext(context.Background()), attrs: map[attribute.Key]attribute.Value{}}
+	ctx := trace.ContextWithSpan(context.Background(), parent)
+
+	req := cloudflare.TelemetryRequest{Operation: "zones.list", Method: http.MethodGet}
+	assert.Equal(t, ctx, telemetry.StartRequest(ctx, req))
+	telemetry.EndRequest(ctx, req, cloudflare.TelemetryResult{StatusCode: http.StatusOK})
+
+	assert.False(t, parent.ended)
+	assert.Empty(t, parent.attrs)
+	assert.Len(t, meter.histograms["cloudflare.client.request.duration"], 1)
+}
@@ -0,0 +1,29 @@
+module github.com/cloudflare/cloudflare-go/cfotel
+
+go 1.19
+
+require (
+	github.com/cloudflare/cloudflare-go v0.50.1-0.20261016115127-b9b3d954fe55
+	github.com/stretchr/testify v1.8.3
+	go.opentelemetry.io/otel v1.16.0
+	go.opentelemetry.io/otel/metric v1.16.0
+	go.opentelemetry.io/otel/trace v1.16.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/google/go-querystring v1.1.0 // indirect
+	github.com/hashicorp/go-cleanhttp v0.5.2 // indirect
+	github.com/hashicorp/go-retryablehttp v0.7.1 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.0.0-20220722155237-a158d28d115b // indirect
+	golang.org/x/text v0.3.7 // indirect
+	golang.org/x/time v0.0.0-20220224211638-0e9765cccd65 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// The replace builds cfotel against the cloudflare package in this repository
+// during development. It is ignored by modules depending on cfotel, which use
+// the required version above instead; see docs/release-process.md.
+replace github.com/cloudflare/cloudflare-go => ../
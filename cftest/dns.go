@@ -0,0 +1,368 @@
+package cftest
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// Error codes returned by the DNS record endpoints.
+const (
+	CodeDNSValidationError     = 1004
+	CodeDNSRecordNotProxiable  = 9004
+	CodeDNSInvalidTTL          = 9021
+	CodeDNSRecordNotFound      = 81044
+	CodeDNSRecordCNAMEConflict = 81053
+	CodeDNSRecordAlreadyExists = 81057
+)
+
+// proxiableTypes are the record types that can be proxied through Cloudflare.
+var proxiableTypes = map[string]bool{"A": true, "AAAA": true, "CNAME": true}
+
+func (s *Server) registerDNSRoutes() {
+	s.handle(http.MethodGet, "/zones/{zone_id}/dns_records", s.listDNSRecords)
+	s.handle(http.MethodPost, "/zones/{zone_id}/dns_records", s.createDNSRecord)
+	s.handle(http.MethodGet, "/zones/{zone_id}/dns_records/{record_id}", s.getDNSRecord)
+	s.handle(http.MethodPatch, "/zones/{zone_id}/dns_records/{record_id}", s.updateDNSRecord)
+	s.handle(http.MethodPut, "/zones/{zone_id}/dns_records/{record_id}", s.updateDNSRecord)
+	s.handle(http.MethodDelete, "/zones/{zone_id}/dns_records/{record_id}", s.deleteDNSRecord)
+}
+
+// DNSRecords returns the DNS records in a zone, bypassing the API. It is
+// useful for asserting on state after a test.
+func (s *Server) DNSRecords(zoneID string) []cloudflare.DNSRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return sortedDNSRecords(s.dnsRecords[zoneID])
+}
+
+func sortedDNSRecords(records map[string]*cloudflare.DNSRecord) []cloudflare.DNSRecord {
+	out := make([]cloudflare.DNSRecord, 0, len(records))
+	for _, rr := range records {
+		out = append(out, *rr)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Type != out[j].Type {
+			return out[i].Type < out[j].Type
+		}
+		if out[i].Name != out[j].Name {
+			return out[i].Name < out[j].Name
+		}
+		return out[i].Content < out[j].Content
+	})
+
+	return out
+}
+
+func (s *Server) listDNSRecords(w http.ResponseWriter, r *http.Request, p params) {
+	zone, ok := s.zone(w, r, p)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	filters := dnsRecordFilters(q)
+	matchAny := q.Get("match") == "any"
+
+	records := make([]cloudflare.DNSRecord, 0)
+	for _, rr := range sortedDNSRecords(s.dnsRecords[zone.ID]) {
+		matched := len(filters) == 0 || !matchAny
+		for _, f := range filters {
+			if f(rr) == matchAny {
+				matched = matchAny
+				break
+			}
+		}
+		if matched {
+			records = append(records, rr)
+		}
+	}
+
+	if order := q.Get("order"); order != "" {
+		desc := q.Get("direction") == "desc"
+		sort.SliceStable(records, func(i, j int) bool {
+			if desc {
+				i, j = j, i
+			}
+			return dnsRecordLess(order, records[i], records[j])
+		})
+	}
+
+	page, info := paginate(r, records, 100, 5000)
+	writePage(w, page, info)
+}
+
+// dnsRecordFilters returns a predicate for each of the filters of a list
+// request. Tags are combined according to `tag_match` into a single filter.
+func dnsRecordFilters(q url.Values) []func(cloudflare.DNSRecord) bool {
+	var filters []func(cloudflare.DNSRecord) bool
+	field := func(key string, match func(rr cloudflare.DNSRecord, v string) bool) {
+		if v := q.Get(key); v != "" {
+			filters = append(filters, func(rr cloudflare.DNSRecord) bool { return match(rr, v) })
+		}
+	}
+
+	field("type", func(rr cloudflare.DNSRecord, v string) bool { return rr.Type == v })
+	field("name", func(rr cloudflare.DNSRecord, v string) bool { return rr.Name == strings.ToLower(v) })
+	field("name.startswith", func(rr cloudflare.DNSRecord, v string) bool {
+		return strings.HasPrefix(rr.Name, strings.ToLower(v))
+	})
+	field("name.endswith", func(rr cloudflare.DNSRecord, v string) bool {
+		return strings.HasSuffix(rr.Name, strings.ToLower(v))
+	})
+	field("name.contains", func(rr cloudflare.DNSRecord, v string) bool {
+		return strings.Contains(rr.Name, strings.ToLower(v))
+	})
+	field("content", func(rr cloudflare.DNSRecord, v string) bool { return rr.Content == v })
+	field("comment", func(rr cloudflare.DNSRecord, v string) bool { return rr.Comment == v })
+	field("proxied", func(rr cloudflare.DNSRecord, v string) bool {
+		proxied, _ := strconv.ParseBool(v)
+		return (rr.Proxied != nil && *rr.Proxied) == proxied
+	})
+
+	if tags := q["tag"]; len(tags) > 0 {
+		matchAny := q.Get("tag_match") == "any"
+		filters = append(filters, func(rr cloudflare.DNSRecord) bool {
+			for _, tag := range tags {
+				if hasDNSRecordTag(rr, tag) == matchAny {
+					return matchAny
+				}
+			}
+			return !matchAny
+		})
+	}
+
+	return filters
+}
+
+// hasDNSRecordTag reports whether `rr` has the tag `tag`, either given as
+// "name:value" or as just the name of the tag.
+func hasDNSRecordTag(rr cloudflare.DNSRecord, tag string) bool {
+	for _, t := range rr.Tags {
+		if t == tag || strings.HasPrefix(t, tag+":") {
+			return true
+		}
+	}
+	return false
+}
+
+// dnsRecordLess orders records by the field of the `order` list parameter.
+func dnsRecordLess(order string, a, b cloudflare.DNSRecord) bool {
+	switch order {
+	case "type":
+		return a.Type < b.Type
+	case "name":
+		return a.Name < b.Name
+	case "content":
+		return a.Content < b.Content
+	case "ttl":
+		return a.TTL < b.TTL
+	case "proxied":
+		return (a.Proxied == nil || !*a.Proxied) && b.Proxied != nil && *b.Proxied
+	}
+	return false
+}
+
+func (s *Server) createDNSRecord(w http.ResponseWriter, r *http.Request, p params) {
+	zone, ok := s.zone(w, r, p)
+	if !ok {
+		return
+	}
+
+	var rr cloudflare.DNSRecord
+	if !decode(w, r, &rr) {
+		return
+	}
+
+	ts := now()
+	rr.ID = newID()
+	rr.ZoneID = zone.ID
+	rr.ZoneName = zone.Name
+	rr.CreatedOn = ts
+	rr.ModifiedOn = ts
+	if !s.validateDNSRecord(w, zone, &rr) {
+		return
+	}
+
+	s.dnsRecords[zone.ID][rr.ID] = &rr
+	writeResult(w, rr)
+}
+
+func (s *Server) getDNSRecord(w http.ResponseWriter, r *http.Request, p params) {
+	zone, ok := s.zone(w, r, p)
+	if !ok {
+		return
+	}
+
+	rr, ok := s.dnsRecord(w, zone, p)
+	if !ok {
+		return
+	}
+
+	writeResult(w, rr)
+}
+
+// updateDNSRecord handles both PATCH, which only changes the provided
+// fields, and PUT, which replaces the record.
+func (s *Server) updateDNSRecord(w http.ResponseWriter, r *http.Request, p params) {
+	zone, ok := s.zone(w, r, p)
+	if !ok {
+		return
+	}
+
+	existing, ok := s.dnsRecord(w, zone, p)
+	if !ok {
+		return
+	}
+
+	var body cloudflare.DNSRecord
+	if !decode(w, r, &body) {
+		return
+	}
+
+	updated := *existing
+	if r.Method == http.MethodPut {
+		updated = body
+	} else {
+		if body.Type != "" {
+			updated.Type = body.Type
+		}
+		if body.Name != "" {
+			updated.Name = body.Name
+		}
+		if body.Content != "" {
+			updated.Content = body.Content
+		}
+		if body.Data != nil {
+			updated.Data = body.Data
+		}
+		if body.Priority != nil {
+			updated.Priority = body.Priority
+		}
+		if body.TTL != 0 {
+			updated.TTL = body.TTL
+		}
+		if body.Proxied != nil {
+			updated.Proxied = body.Proxied
+		}
+	}
+
+	updated.ID = existing.ID
+	updated.ZoneID = zone.ID
+	updated.ZoneName = zone.Name
+	updated.CreatedOn = existing.CreatedOn
+	updated.ModifiedOn = now()
+	if !s.validateDNSRecord(w, zone, &updated) {
+		return
+	}
+
+	s.dnsRecords[zone.ID][updated.ID] = &updated
+	writeResult(w, updated)
+}
+
+func (s *Server) deleteDNSRecord(w http.ResponseWriter, r *http.Request, p params) {
+	zone, ok := s.zone(w, r, p)
+	if !ok {
+		return
+	}
+
+	rr, ok := s.dnsRecord(w, zone, p)
+	if !ok {
+		return
+	}
+
+	delete(s.dnsRecords[zone.ID], rr.ID)
+	writeResult(w, map[string]string{"id": rr.ID})
+}
+
+func (s *Server) dnsRecord(w http.ResponseWriter, zone *cloudflare.Zone, p params) (*cloudflare.DNSRecord, bool) {
+	rr, ok := s.dnsRecords[zone.ID][p["record_id"]]
+	if !ok {
+		writeError(w, http.StatusNotFound, CodeDNSRecordNotFound, "Record does not exist.")
+		return nil, false
+	}
+
+	return rr, true
+}
+
+// validateDNSRecord normalises `rr` and checks it the way the API does,
+// including conflicts with the other records in the zone.
+func (s *Server) validateDNSRecord(w http.ResponseWriter, zone *cloudflare.Zone, rr *cloudflare.DNSRecord) bool {
+	rr.Type = strings.ToUpper(rr.Type)
+	rr.Name = qualifyName(rr.Name, zone.Name)
+	rr.Proxiable = proxiableTypes[rr.Type]
+	if rr.TTL == 0 {
+		rr.TTL = 1
+	}
+	if rr.Proxied == nil {
+		proxied := false
+		rr.Proxied = &proxied
+	}
+
+	switch {
+	case rr.Type == "":
+		writeError(w, http.StatusBadRequest, CodeDNSValidationError, "DNS Validation Error: type is required")
+		return false
+	case rr.Name == "":
+		writeError(w, http.StatusBadRequest, CodeDNSValidationError, "DNS Validation Error: name is required")
+		return false
+	case rr.Content == "" && rr.Data == nil:
+		writeError(w, http.StatusBadRequest, CodeDNSValidationError, "DNS Validation Error: content is required")
+		return false
+	case rr.Type == "A" && (net.ParseIP(rr.Content) == nil || net.ParseIP(rr.Content).To4() == nil):
+		writeError(w, http.StatusBadRequest, CodeDNSValidationError, "Content for A record is invalid. Must be a valid IPv4 address")
+		return false
+	case rr.Type == "AAAA" && (net.ParseIP(rr.Content) == nil || net.ParseIP(rr.Content).To4() != nil):
+		writeError(w, http.StatusBadRequest, CodeDNSValidationError, "Content for AAAA record is invalid. Must be a valid IPv6 address")
+		return false
+	case *rr.Proxied && !rr.Proxiable:
+		writeError(w, http.StatusBadRequest, CodeDNSRecordNotProxiable, fmt.Sprintf("%s records cannot be proxied.", rr.Type))
+		return false
+	case rr.TTL != 1 && (rr.TTL < 60 || rr.TTL > 86400):
+		writeError(w, http.StatusBadRequest, CodeDNSInvalidTTL, "Invalid TTL. Must be between 60 and 86400 seconds, or 1 for Automatic.")
+		return false
+	}
+
+	for _, other := range s.dnsRecords[zone.ID] {
+		if other.ID == rr.ID || other.Name != rr.Name {
+			continue
+		}
+
+		if other.Type == "CNAME" || rr.Type == "CNAME" {
+			writeError(w, http.StatusBadRequest, CodeDNSRecordCNAMEConflict, "An A, AAAA, or CNAME record with that host already exists.")
+			return false
+		}
+
+		if other.Type == rr.Type && other.Content == rr.Content && rr.Content != "" {
+			writeError(w, http.StatusBadRequest, CodeDNSRecordAlreadyExists, "Record already exists.")
+			return false
+		}
+	}
+
+	return true
+}
+
+// qualifyName turns `name` into a lower case fully qualified name within
+// `zone`. Like the API, "@" and names without the zone suffix are treated as
+// relative to the zone.
+func qualifyName(name, zone string) string {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	switch {
+	case name == "" || name == zone:
+		return name
+	case name == "@":
+		return zone
+	case strings.HasSuffix(name, "."+zone):
+		return name
+	default:
+		return name + "." + zone
+	}
+}
@@ -0,0 +1,143 @@
+package cftest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNSRecords(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	zone := srv.AddZone("example.com")
+
+	api, err := srv.Client()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	proxied := true
+	created, err := api.CreateDNSRecord(ctx, zone.ID, cloudflare.DNSRecord{Type: "A", Name: "www", Content: "198.51.100.4", Proxied: &proxied})
+	require.NoError(t, err)
+	assert.Equal(t, "www.example.com", created.Result.Name)
+	assert.True(t, created.Result.Proxiable)
+	assert.Equal(t, 1, created.Result.TTL)
+
+	// more records than fit on a single page of results.
+	for i := 0; i < 120; i++ {
+		_, err := api.CreateDNSRecord(ctx, zone.ID, cloudflare.DNSRecord{Type: "TXT", Name: "@", Content: fmt.Sprintf("record %d", i)})
+		require.NoError(t, err)
+	}
+
+	records, err := api.DNSRecords(ctx, zone.ID, cloudflare.DNSRecord{})
+	require.NoError(t, err)
+	assert.Len(t, records, 121)
+
+	records, err = api.DNSRecords(ctx, zone.ID, cloudflare.DNSRecord{Type: "A"})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, created.Result.ID, records[0].ID)
+
+	err = api.UpdateDNSRecord(ctx, zone.ID, created.Result.ID, cloudflare.DNSRecord{Content: "198.51.100.5"})
+	require.NoError(t, err)
+
+	record, err := api.DNSRecord(ctx, zone.ID, created.Result.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "198.51.100.5", record.Content)
+	assert.Equal(t, "www.example.com", record.Name)
+
+	require.NoError(t, api.DeleteDNSRecord(ctx, zone.ID, created.Result.ID))
+	assert.Len(t, srv.DNSRecords(zone.ID), 120)
+
+	_, err = api.DNSRecord(ctx, zone.ID, created.Result.ID)
+	var notFoundErr *cloudflare.NotFoundError
+	require.True(t, errors.As(err, &notFoundErr), "expected a not found error, got %v", err)
+	assert.True(t, notFoundErr.InternalErrorCodeIs(CodeDNSRecordNotFound))
+}
+
+func TestListDNSRecords(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	zone := srv.AddZone("example.com")
+
+	api, err := srv.Client()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	proxied := true
+	for _, rr := range []cloudflare.DNSRecord{
+		{Type: "A", Name: "api", Content: "198.51.100.4", Proxied: &proxied, Tags: []string{"env:prod", "team:dns"}},
+		{Type: "A", Name: "www", Content: "198.51.100.5", Comment: "managed", Tags: []string{"env:dev"}},
+		{Type: "TXT", Name: "api-docs", Content: "hello", TTL: 300},
+	} {
+		_, err := api.CreateDNSRecord(ctx, zone.ID, rr)
+		require.NoError(t, err)
+	}
+
+	names := func(params cloudflare.DNSListParams) []string {
+		records, _, err := api.ListDNSRecords(ctx, cloudflare.ZoneIdentifier(zone.ID), params)
+		require.NoError(t, err)
+
+		var names []string
+		for _, rr := range records {
+			names = append(names, rr.Name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"api.example.com"}, names(cloudflare.DNSListParams{Proxied: &proxied}))
+	assert.Equal(t, []string{"www.example.com"}, names(cloudflare.DNSListParams{Comment: "managed"}))
+	assert.Equal(t, []string{"api.example.com", "api-docs.example.com"}, names(cloudflare.DNSListParams{NameStartsWith: "api"}))
+	assert.Equal(t, []string{"api-docs.example.com"}, names(cloudflare.DNSListParams{NameContains: "docs"}))
+	assert.Equal(t, []string{"www.example.com"}, names(cloudflare.DNSListParams{NameEndsWith: "www.example.com"}))
+	assert.Equal(t, []string{"api.example.com", "www.example.com"}, names(cloudflare.DNSListParams{Tags: []string{"env"}}))
+	assert.Equal(t, []string{"api.example.com"}, names(cloudflare.DNSListParams{Tags: []string{"env", "team:dns"}}))
+	assert.Equal(t, []string{"api.example.com", "www.example.com"}, names(cloudflare.DNSListParams{Tags: []string{"env:dev", "team"}, TagMatch: "any"}))
+	assert.Empty(t, names(cloudflare.DNSListParams{Type: "TXT", Comment: "managed"}))
+	assert.Equal(t, []string{"www.example.com", "api-docs.example.com"}, names(cloudflare.DNSListParams{Type: "TXT", Comment: "managed", Match: "any"}))
+	assert.Equal(t, []string{"www.example.com", "api.example.com", "api-docs.example.com"}, names(cloudflare.DNSListParams{Order: "name", Direction: "desc"}))
+}
+
+func TestDNSRecords_Validation(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	zone := srv.AddZone("example.com")
+
+	api, err := srv.Client()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = api.CreateDNSRecord(ctx, zone.ID, cloudflare.DNSRecord{Type: "CNAME", Name: "www", Content: "example.net"})
+	require.NoError(t, err)
+
+	proxied := true
+	tests := map[string]struct {
+		record cloudflare.DNSRecord
+		code   int
+	}{
+		"CNAME conflict":   {cloudflare.DNSRecord{Type: "A", Name: "www.example.com", Content: "198.51.100.4"}, CodeDNSRecordCNAMEConflict},
+		"invalid IPv4":     {cloudflare.DNSRecord{Type: "A", Name: "api", Content: "2001:db8::1"}, CodeDNSValidationError},
+		"not proxiable":    {cloudflare.DNSRecord{Type: "TXT", Name: "api", Content: "hello", Proxied: &proxied}, CodeDNSRecordNotProxiable},
+		"TTL out of range": {cloudflare.DNSRecord{Type: "A", Name: "api", Content: "198.51.100.4", TTL: 30}, CodeDNSInvalidTTL},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := api.CreateDNSRecord(ctx, zone.ID, tc.record)
+			var requestErr *cloudflare.RequestError
+			require.True(t, errors.As(err, &requestErr), "expected a request error, got %v", err)
+			assert.True(t, requestErr.InternalErrorCodeIs(tc.code), "expected error code %d, got %v", tc.code, requestErr.ErrorCodes())
+		})
+	}
+}
+
+func TestQualifyName(t *testing.T) {
+	assert.Equal(t, "example.com", qualifyName("@", "example.com"))
+	assert.Equal(t, "example.com", qualifyName("Example.com.", "example.com"))
+	assert.Equal(t, "www.example.com", qualifyName("www", "example.com"))
+	assert.Equal(t, "www.example.com", qualifyName("www.example.com", "example.com"))
+}
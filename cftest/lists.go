@@ -0,0 +1,284 @@
+package cftest
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// Error codes returned by the list endpoints.
+const (
+	CodeListNotFound          = 10000
+	CodeListInvalidKind       = 10001
+	CodeListNameAlreadyExists = 10015
+	CodeListItemNotFound      = 10018
+	CodeListOperationNotFound = 10019
+)
+
+type list struct {
+	cloudflare.List
+	items []cloudflare.ListItem
+}
+
+func (s *Server) registerListRoutes() {
+	const lists = "/accounts/{account_id}/rules/lists"
+
+	s.handle(http.MethodGet, lists, s.listLists)
+	s.handle(http.MethodPost, lists, s.createList)
+	s.handle(http.MethodGet, lists+"/bulk_operations/{operation_id}", s.getListBulkOperation)
+	s.handle(http.MethodGet, lists+"/{list_id}", s.getList)
+	s.handle(http.MethodPut, lists+"/{list_id}", s.updateList)
+	s.handle(http.MethodDelete, lists+"/{list_id}", s.deleteList)
+	s.handle(http.MethodGet, lists+"/{list_id}/items", s.listListItems)
+	s.handle(http.MethodPost, lists+"/{list_id}/items", s.changeListItems)
+	s.handle(http.MethodPut, lists+"/{list_id}/items", s.changeListItems)
+	s.handle(http.MethodDelete, lists+"/{list_id}/items", s.changeListItems)
+	s.handle(http.MethodGet, lists+"/{list_id}/items/{item_id}", s.getListItem)
+}
+
+func (s *Server) listLists(w http.ResponseWriter, r *http.Request, p params) {
+	lists := make([]cloudflare.List, 0)
+	for _, l := range s.lists[p["account_id"]] {
+		lists = append(lists, l.List)
+	}
+	sort.Slice(lists, func(i, j int) bool { return lists[i].Name < lists[j].Name })
+
+	writeResult(w, lists)
+}
+
+func (s *Server) createList(w http.ResponseWriter, r *http.Request, p params) {
+	var body cloudflare.ListCreateRequest
+	if !decode(w, r, &body) {
+		return
+	}
+
+	if body.Kind != cloudflare.ListTypeIP && body.Kind != cloudflare.ListTypeRedirect {
+		writeError(w, http.StatusBadRequest, CodeListInvalidKind, fmt.Sprintf("invalid list kind %q", body.Kind))
+		return
+	}
+
+	accountID := p["account_id"]
+	for _, l := range s.lists[accountID] {
+		if l.Name == body.Name {
+			writeError(w, http.StatusBadRequest, CodeListNameAlreadyExists, "a list with this name already exists")
+			return
+		}
+	}
+
+	ts := now()
+	l := &list{List: cloudflare.List{
+		ID:          newID(),
+		Name:        body.Name,
+		Description: body.Description,
+		Kind:        body.Kind,
+		CreatedOn:   &ts,
+		ModifiedOn:  &ts,
+	}}
+	if s.lists[accountID] == nil {
+		s.lists[accountID] = make(map[string]*list)
+	}
+	s.lists[accountID][l.ID] = l
+
+	writeResult(w, l.List)
+}
+
+func (s *Server) getList(w http.ResponseWriter, r *http.Request, p params) {
+	l, ok := s.list(w, p)
+	if !ok {
+		return
+	}
+
+	writeResult(w, l.List)
+}
+
+func (s *Server) updateList(w http.ResponseWriter, r *http.Request, p params) {
+	l, ok := s.list(w, p)
+	if !ok {
+		return
+	}
+
+	var body cloudflare.ListUpdateRequest
+	if !decode(w, r, &body) {
+		return
+	}
+
+	ts := now()
+	l.Description = body.Description
+	l.ModifiedOn = &ts
+
+	writeResult(w, l.List)
+}
+
+func (s *Server) deleteList(w http.ResponseWriter, r *http.Request, p params) {
+	l, ok := s.list(w, p)
+	if !ok {
+		return
+	}
+
+	delete(s.lists[p["account_id"]], l.ID)
+	writeResult(w, map[string]string{"id": l.ID})
+}
+
+func (s *Server) listListItems(w http.ResponseWriter, r *http.Request, p params) {
+	l, ok := s.list(w, p)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	perPage, _ := strconv.Atoi(q.Get("per_page"))
+	if perPage <= 0 || perPage > 500 {
+		perPage = 500
+	}
+
+	items, cursor := paginateCursor(q.Get("cursor"), l.items, perPage)
+	writePage(w, items, cloudflare.ResultInfo{Cursors: cloudflare.ResultInfoCursors{After: cursor}})
+}
+
+func (s *Server) getListItem(w http.ResponseWriter, r *http.Request, p params) {
+	l, ok := s.list(w, p)
+	if !ok {
+		return
+	}
+
+	for _, item := range l.items {
+		if item.ID == p["item_id"] {
+			writeResult(w, item)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, CodeListItemNotFound, "list item not found")
+}
+
+// changeListItems handles the asynchronous item endpoints: POST appends
+// items, PUT replaces them and DELETE removes them by ID. Changes are applied
+// straight away and the bulk operation is recorded as either completed or
+// failed.
+func (s *Server) changeListItems(w http.ResponseWriter, r *http.Request, p params) {
+	l, ok := s.list(w, p)
+	if !ok {
+		return
+	}
+
+	var err error
+	switch r.Method {
+	case http.MethodDelete:
+		var body cloudflare.ListItemDeleteRequest
+		if !decode(w, r, &body) {
+			return
+		}
+		l.deleteItems(body.Items)
+	default:
+		var body []cloudflare.ListItemCreateRequest
+		if !decode(w, r, &body) {
+			return
+		}
+		err = l.addItems(body, r.Method == http.MethodPut)
+	}
+
+	ts := now()
+	op := &cloudflare.ListBulkOperation{ID: newID(), Status: "completed", Completed: &ts}
+	if err != nil {
+		op.Status = "failed"
+		op.Error = err.Error()
+	}
+
+	accountID := p["account_id"]
+	if s.operations[accountID] == nil {
+		s.operations[accountID] = make(map[string]*cloudflare.ListBulkOperation)
+	}
+	s.operations[accountID][op.ID] = op
+
+	writeResult(w, map[string]string{"operation_id": op.ID})
+}
+
+func (s *Server) getListBulkOperation(w http.ResponseWriter, r *http.Request, p params) {
+	op, ok := s.operations[p["account_id"]][p["operation_id"]]
+	if !ok {
+		writeError(w, http.StatusNotFound, CodeListOperationNotFound, "bulk operation not found")
+		return
+	}
+
+	writeResult(w, op)
+}
+
+func (s *Server) list(w http.ResponseWriter, p params) (*list, bool) {
+	l, ok := s.lists[p["account_id"]][p["list_id"]]
+	if !ok {
+		writeError(w, http.StatusNotFound, CodeListNotFound, "list not found")
+		return nil, false
+	}
+
+	return l, true
+}
+
+// addItems validates `items` against the kind of the list and adds them,
+// replacing the existing items when `replace` is set. Nothing is changed if
+// any item is invalid.
+func (l *list) addItems(items []cloudflare.ListItemCreateRequest, replace bool) error {
+	ts := now()
+	added := make([]cloudflare.ListItem, 0, len(items))
+	for _, item := range items {
+		switch l.Kind {
+		case cloudflare.ListTypeIP:
+			if item.IP == nil || !validListIP(*item.IP) {
+				return fmt.Errorf("invalid IP address or CIDR in list item")
+			}
+		case cloudflare.ListTypeRedirect:
+			if item.Redirect == nil || item.Redirect.SourceUrl == "" || item.Redirect.TargetUrl == "" {
+				return fmt.Errorf("redirect list items require a source_url and target_url")
+			}
+		}
+
+		added = append(added, cloudflare.ListItem{
+			ID:         newID(),
+			IP:         item.IP,
+			Redirect:   item.Redirect,
+			Comment:    item.Comment,
+			CreatedOn:  &ts,
+			ModifiedOn: &ts,
+		})
+	}
+
+	if replace {
+		l.items = nil
+	}
+	l.items = append(l.items, added...)
+	l.NumItems = len(l.items)
+	l.ModifiedOn = &ts
+
+	return nil
+}
+
+func (l *list) deleteItems(items []cloudflare.ListItemDeleteItemRequest) {
+	remove := make(map[string]bool, len(items))
+	for _, item := range items {
+		remove[item.ID] = true
+	}
+
+	kept := l.items[:0]
+	for _, item := range l.items {
+		if !remove[item.ID] {
+			kept = append(kept, item)
+		}
+	}
+
+	ts := now()
+	l.items = kept
+	l.NumItems = len(l.items)
+	l.ModifiedOn = &ts
+}
+
+func validListIP(ip string) bool {
+	if net.ParseIP(ip) != nil {
+		return true
+	}
+
+	_, _, err := net.ParseCIDR(ip)
+	return err == nil
+}
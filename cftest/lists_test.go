@@ -0,0 +1,75 @@
+package cftest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLists(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	api, err := srv.Client()
+	require.NoError(t, err)
+	ctx := context.Background()
+	rc := cloudflare.AccountIdentifier(srv.AccountID)
+
+	l, err := api.CreateList(ctx, rc, cloudflare.ListCreateParams{Name: "blocked", Kind: cloudflare.ListTypeIP})
+	require.NoError(t, err)
+
+	ip, cidr := "198.51.100.4", "2001:db8::/32"
+	op, err := api.CreateListItemsAsync(ctx, rc, cloudflare.ListCreateItemsParams{
+		ID:    l.ID,
+		Items: []cloudflare.ListItemCreateRequest{{IP: &ip}, {IP: &cidr, Comment: "documentation"}},
+	})
+	require.NoError(t, err)
+
+	status, err := api.GetListBulkOperation(ctx, rc, op.Result.OperationID)
+	require.NoError(t, err)
+	assert.Equal(t, "completed", status.Status)
+
+	items, err := api.ListListItems(ctx, rc, cloudflare.ListListItemsParams{ID: l.ID})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "documentation", items[1].Comment)
+
+	item, err := api.GetListItem(ctx, rc, l.ID, items[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, ip, *item.IP)
+
+	invalid := "not an ip"
+	op, err = api.ReplaceListItemsAsync(ctx, rc, cloudflare.ListReplaceItemsParams{
+		ID:    l.ID,
+		Items: []cloudflare.ListItemCreateRequest{{IP: &invalid}},
+	})
+	require.NoError(t, err)
+
+	status, err = api.GetListBulkOperation(ctx, rc, op.Result.OperationID)
+	require.NoError(t, err)
+	assert.Equal(t, "failed", status.Status)
+	assert.NotEmpty(t, status.Error)
+
+	_, err = api.DeleteListItemsAsync(ctx, rc, cloudflare.ListDeleteItemsParams{
+		ID:    l.ID,
+		Items: cloudflare.ListItemDeleteRequest{Items: []cloudflare.ListItemDeleteItemRequest{{ID: items[0].ID}}},
+	})
+	require.NoError(t, err)
+
+	l, err = api.GetList(ctx, rc, l.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, l.NumItems)
+
+	lists, err := api.ListLists(ctx, rc, cloudflare.ListListsParams{})
+	require.NoError(t, err)
+	assert.Len(t, lists, 1)
+
+	_, err = api.DeleteList(ctx, rc, l.ID)
+	require.NoError(t, err)
+
+	_, err = api.GetList(ctx, rc, l.ID)
+	assert.Error(t, err)
+}
@@ -0,0 +1,211 @@
+package cftest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// Error codes returned by the ruleset endpoints.
+const (
+	CodeRulesetValidationError = 20217
+)
+
+func (s *Server) registerRulesetRoutes() {
+	for _, level := range []string{"zones", "accounts"} {
+		level := level
+		rulesets := "/" + level + "/{container_id}/rulesets"
+
+		s.handle(http.MethodGet, rulesets, s.withContainer(level, s.listRulesets))
+		s.handle(http.MethodPost, rulesets, s.withContainer(level, s.createRuleset))
+		s.handle(http.MethodGet, rulesets+"/{ruleset_id}", s.withContainer(level, s.getRuleset))
+		s.handle(http.MethodPut, rulesets+"/{ruleset_id}", s.withContainer(level, s.updateRuleset))
+		s.handle(http.MethodDelete, rulesets+"/{ruleset_id}", s.withContainer(level, s.deleteRuleset))
+		s.handle(http.MethodGet, rulesets+"/phases/{phase}/entrypoint", s.withContainer(level, s.getRulesetPhase))
+		s.handle(http.MethodPut, rulesets+"/phases/{phase}/entrypoint", s.withContainer(level, s.updateRulesetPhase))
+	}
+}
+
+// containerKey returns the key rulesets are stored under for a zone or
+// account.
+func containerKey(level, id string) string {
+	return level + "/" + id
+}
+
+// withContainer resolves the zone or account a ruleset route applies to and
+// passes its key to `h` as the "container" parameter. Zone routes fail when
+// the zone doesn't exist.
+func (s *Server) withContainer(level string, h handlerFunc) handlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, p params) {
+		if level == "zones" {
+			p["zone_id"] = p["container_id"]
+			if _, ok := s.zone(w, r, p); !ok {
+				return
+			}
+		}
+
+		p["level"] = level
+		p["container"] = containerKey(level, p["container_id"])
+		h(w, r, p)
+	}
+}
+
+func (s *Server) listRulesets(w http.ResponseWriter, r *http.Request, p params) {
+	rulesets := make([]cloudflare.Ruleset, 0)
+	for _, rs := range s.rulesets[p["container"]] {
+		summary := *rs
+		summary.Rules = nil
+		rulesets = append(rulesets, summary)
+	}
+	sort.Slice(rulesets, func(i, j int) bool { return rulesets[i].Name < rulesets[j].Name })
+
+	writeResult(w, rulesets)
+}
+
+func (s *Server) createRuleset(w http.ResponseWriter, r *http.Request, p params) {
+	var rs cloudflare.Ruleset
+	if !decode(w, r, &rs) {
+		return
+	}
+
+	if rs.Name == "" || rs.Kind == "" || rs.Phase == "" {
+		writeError(w, http.StatusBadRequest, CodeRulesetValidationError, "name, kind and phase are required")
+		return
+	}
+
+	s.storeRuleset(p["container"], &rs, "1")
+	writeResult(w, rs)
+}
+
+func (s *Server) getRuleset(w http.ResponseWriter, r *http.Request, p params) {
+	rs, ok := s.ruleset(w, r, p)
+	if !ok {
+		return
+	}
+
+	writeResult(w, rs)
+}
+
+func (s *Server) updateRuleset(w http.ResponseWriter, r *http.Request, p params) {
+	rs, ok := s.ruleset(w, r, p)
+	if !ok {
+		return
+	}
+
+	var body cloudflare.UpdateRulesetRequest
+	if !decode(w, r, &body) {
+		return
+	}
+
+	updated := *rs
+	updated.Description = body.Description
+	updated.Rules = body.Rules
+	s.storeRuleset(p["container"], &updated, nextVersion(rs.Version))
+
+	writeResult(w, updated)
+}
+
+func (s *Server) deleteRuleset(w http.ResponseWriter, r *http.Request, p params) {
+	rs, ok := s.ruleset(w, r, p)
+	if !ok {
+		return
+	}
+
+	delete(s.rulesets[p["container"]], rs.ID)
+
+	// like the API, a successful delete has no response body.
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getRulesetPhase(w http.ResponseWriter, r *http.Request, p params) {
+	rs := s.entrypoint(p["container"], p["phase"])
+	if rs == nil {
+		writeError(w, http.StatusNotFound, CodeRouteNotFound, fmt.Sprintf("Could not find entrypoint ruleset in the %s phase", p["phase"]))
+		return
+	}
+
+	writeResult(w, rs)
+}
+
+func (s *Server) updateRulesetPhase(w http.ResponseWriter, r *http.Request, p params) {
+	var body cloudflare.Ruleset
+	if !decode(w, r, &body) {
+		return
+	}
+
+	updated := cloudflare.Ruleset{Kind: "zone", Phase: p["phase"], Name: "default"}
+	if p["level"] == "accounts" {
+		updated.Kind = "root"
+	}
+
+	version := "1"
+	if rs := s.entrypoint(p["container"], p["phase"]); rs != nil {
+		updated = *rs
+		version = nextVersion(rs.Version)
+	}
+
+	if body.Name != "" {
+		updated.Name = body.Name
+	}
+	updated.Description = body.Description
+	updated.Rules = body.Rules
+	s.storeRuleset(p["container"], &updated, version)
+
+	writeResult(w, updated)
+}
+
+func (s *Server) ruleset(w http.ResponseWriter, r *http.Request, p params) (*cloudflare.Ruleset, bool) {
+	rs, ok := s.rulesets[p["container"]][p["ruleset_id"]]
+	if !ok {
+		writeError(w, http.StatusNotFound, CodeRouteNotFound, fmt.Sprintf("Could not route to %s, perhaps your object identifier is invalid?", r.URL.Path))
+		return nil, false
+	}
+
+	return rs, true
+}
+
+// entrypoint returns the entry point ruleset of a phase, if there is one.
+func (s *Server) entrypoint(container, phase string) *cloudflare.Ruleset {
+	for _, rs := range s.rulesets[container] {
+		if rs.Phase == phase && (rs.Kind == "zone" || rs.Kind == "root") {
+			return rs
+		}
+	}
+
+	return nil
+}
+
+// storeRuleset saves `rs` at `version`, assigning IDs to the ruleset and any
+// new rules.
+func (s *Server) storeRuleset(container string, rs *cloudflare.Ruleset, version string) {
+	ts := now()
+	if rs.ID == "" {
+		rs.ID = newID()
+	}
+	rs.Version = version
+	rs.LastUpdated = &ts
+
+	rules := make([]cloudflare.RulesetRule, len(rs.Rules))
+	for i, rule := range rs.Rules {
+		if rule.ID == "" {
+			rule.ID = newID()
+		}
+		rule.Version = version
+		rule.LastUpdated = &ts
+		rules[i] = rule
+	}
+	rs.Rules = rules
+
+	if s.rulesets[container] == nil {
+		s.rulesets[container] = make(map[string]*cloudflare.Ruleset)
+	}
+	s.rulesets[container][rs.ID] = rs
+}
+
+func nextVersion(version string) string {
+	v, _ := strconv.Atoi(version)
+	return strconv.Itoa(v + 1)
+}
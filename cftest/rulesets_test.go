@@ -0,0 +1,79 @@
+package cftest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRulesets(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	zone := srv.AddZone("example.com")
+
+	api, err := srv.Client()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	rs, err := api.CreateZoneRuleset(ctx, zone.ID, cloudflare.Ruleset{
+		Name:  "custom",
+		Kind:  string(cloudflare.RulesetKindCustom),
+		Phase: string(cloudflare.RulesetPhaseHTTPRequestFirewallCustom),
+		Rules: []cloudflare.RulesetRule{{Action: "block", Expression: "ip.src eq 198.51.100.4"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1", rs.Version)
+	require.Len(t, rs.Rules, 1)
+	assert.NotEmpty(t, rs.Rules[0].ID)
+
+	updated, err := api.UpdateZoneRuleset(ctx, zone.ID, rs.ID, "updated", []cloudflare.RulesetRule{rs.Rules[0], {Action: "log", Expression: "true"}})
+	require.NoError(t, err)
+	assert.Equal(t, "2", updated.Version)
+	assert.Len(t, updated.Rules, 2)
+
+	rulesets, err := api.ListZoneRulesets(ctx, zone.ID)
+	require.NoError(t, err)
+	require.Len(t, rulesets, 1)
+	assert.Empty(t, rulesets[0].Rules)
+
+	got, err := api.GetZoneRuleset(ctx, zone.ID, rs.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "updated", got.Description)
+
+	require.NoError(t, api.DeleteZoneRuleset(ctx, zone.ID, rs.ID))
+
+	_, err = api.GetZoneRuleset(ctx, zone.ID, rs.ID)
+	assert.Error(t, err)
+}
+
+func TestRulesets_Entrypoint(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	api, err := srv.Client()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	phase := string(cloudflare.RulesetPhaseHTTPRequestFirewallManaged)
+	_, err = api.GetAccountRulesetPhase(ctx, srv.AccountID, phase)
+	assert.Error(t, err)
+
+	rs, err := api.UpdateAccountRulesetPhase(ctx, srv.AccountID, phase, cloudflare.Ruleset{
+		Rules: []cloudflare.RulesetRule{{Action: "execute", Expression: "true"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "root", rs.Kind)
+	assert.Equal(t, "1", rs.Version)
+
+	rs, err = api.UpdateAccountRulesetPhase(ctx, srv.AccountID, phase, cloudflare.Ruleset{Description: "managed"})
+	require.NoError(t, err)
+	assert.Equal(t, "2", rs.Version)
+
+	got, err := api.GetAccountRulesetPhase(ctx, srv.AccountID, phase)
+	require.NoError(t, err)
+	assert.Equal(t, rs.ID, got.ID)
+	assert.Equal(t, "managed", got.Description)
+}
@@ -0,0 +1,370 @@
+// Package cftest provides an in-memory fake of the Cloudflare v4 API for use
+// in tests.
+//
+// A Server keeps zones, DNS records, Workers KV namespaces, lists, rulesets
+// and tunnels in memory so that tests can exercise create, list, update and
+// delete flows without a network:
+//
+//	srv := cftest.NewServer()
+//	defer srv.Close()
+//
+//	api, _ := srv.Client()
+//	zone, _ := api.CreateZone(ctx, "example.com", false, cloudflare.Account{}, "full")
+//	_, _ = api.CreateDNSRecord(ctx, zone.ID, cloudflare.DNSRecord{Type: "A", Name: "www", Content: "198.51.100.4"})
+//
+// Responses use the same envelope, pagination and error codes as the real
+// API. Rate limiting and arbitrary failures can be simulated with
+// SimulateRateLimit and FailNext.
+package cftest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// Error codes returned by the fake for requests that fail regardless of the
+// resource.
+const (
+	CodeMissingCredentials = 9106
+	CodeRouteNotFound      = 7003
+	CodeMethodNotAllowed   = 7001
+	CodeInvalidRequest     = 6003
+	CodeRateLimited        = 971
+)
+
+// Server is a stateful in-process fake of the Cloudflare v4 API. It is safe
+// for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	// AccountID is the account zones are created in when the request doesn't
+	// specify one.
+	AccountID string
+
+	mu           sync.Mutex
+	routes       []route
+	requests     int
+	rateLimited  int
+	retryAfter   time.Duration
+	failures     []failure
+	zones        map[string]*cloudflare.Zone
+	dnsRecords   map[string]map[string]*cloudflare.DNSRecord
+	kvNamespaces map[string]map[string]*kvNamespace
+	lists        map[string]map[string]*list
+	operations   map[string]map[string]*cloudflare.ListBulkOperation
+	rulesets     map[string]map[string]*cloudflare.Ruleset
+	tunnels      map[string]map[string]*tunnel
+}
+
+type failure struct {
+	status int
+	errors []cloudflare.ResponseInfo
+}
+
+// NewServer starts a new fake API server. The caller should call Close when
+// finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		AccountID:    newID(),
+		zones:        make(map[string]*cloudflare.Zone),
+		dnsRecords:   make(map[string]map[string]*cloudflare.DNSRecord),
+		kvNamespaces: make(map[string]map[string]*kvNamespace),
+		lists:        make(map[string]map[string]*list),
+		operations:   make(map[string]map[string]*cloudflare.ListBulkOperation),
+		rulesets:     make(map[string]map[string]*cloudflare.Ruleset),
+		tunnels:      make(map[string]map[string]*tunnel),
+	}
+
+	s.registerZoneRoutes()
+	s.registerDNSRoutes()
+	s.registerWorkersKVRoutes()
+	s.registerListRoutes()
+	s.registerRulesetRoutes()
+	s.registerTunnelRoutes()
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Client returns an API client configured to talk to the fake. Client side
+// rate limiting and retries are disabled by default; `opts` are applied
+// afterwards so they can be overridden.
+func (s *Server) Client(opts ...cloudflare.Option) (*cloudflare.API, error) {
+	opts = append([]cloudflare.Option{
+		cloudflare.BaseURL(s.URL),
+		cloudflare.UsingRateLimit(100000),
+		cloudflare.UsingRetryPolicy(0, 0, 0),
+	}, opts...)
+
+	api, err := cloudflare.NewWithAPIToken("cftest-token", opts...)
+	if err != nil {
+		return nil, err
+	}
+	api.AccountID = s.AccountID
+
+	return api, nil
+}
+
+// SimulateRateLimit makes the next `requests` requests fail with a HTTP 429
+// and a `Retry-After` header of `retryAfter`.
+func (s *Server) SimulateRateLimit(requests int, retryAfter time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rateLimited = requests
+	s.retryAfter = retryAfter
+}
+
+// FailNext makes the next request fail with `status` and the provided errors.
+// Calls are queued so that several failures can be set up at once.
+func (s *Server) FailNext(status int, errs ...cloudflare.ResponseInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(errs) == 0 {
+		errs = []cloudflare.ResponseInfo{{Code: status, Message: http.StatusText(status)}}
+	}
+	s.failures = append(s.failures, failure{status: status, errors: errs})
+}
+
+// Requests returns the number of requests the server has received.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+// params are the named path segments of a matched route.
+type params map[string]string
+
+type handlerFunc func(w http.ResponseWriter, r *http.Request, p params)
+
+type route struct {
+	method   string
+	segments []string
+	handler  handlerFunc
+}
+
+// handle registers a handler for `method` and `pattern`. Pattern segments
+// wrapped in braces (e.g. "{zone_id}") match any single path segment.
+func (s *Server) handle(method, pattern string, h handlerFunc) {
+	s.routes = append(s.routes, route{
+		method:   method,
+		segments: strings.Split(strings.Trim(pattern, "/"), "/"),
+		handler:  h,
+	})
+}
+
+func (r route) match(segments []string) (params, bool) {
+	if len(r.segments) != len(segments) {
+		return nil, false
+	}
+
+	p := params{}
+	for i, segment := range r.segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			p[segment[1:len(segment)-1]] = segments[i]
+			continue
+		}
+
+		if segment != segments[i] {
+			return nil, false
+		}
+	}
+
+	return p, true
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+
+	if s.rateLimited > 0 {
+		s.rateLimited--
+		w.Header().Set("Retry-After", strconv.Itoa(int(s.retryAfter.Seconds())))
+		writeError(w, http.StatusTooManyRequests, CodeRateLimited, "Please wait and consider throttling your request speed")
+		return
+	}
+
+	if len(s.failures) > 0 {
+		f := s.failures[0]
+		s.failures = s.failures[1:]
+		writeJSON(w, f.status, envelope{Errors: f.errors})
+		return
+	}
+
+	if r.Header.Get("Authorization") == "" && (r.Header.Get("X-Auth-Key") == "" || r.Header.Get("X-Auth-Email") == "") && r.Header.Get("X-Auth-User-Service-Key") == "" {
+		writeError(w, http.StatusBadRequest, CodeMissingCredentials, "Missing X-Auth-Key, X-Auth-Email or Authorization headers")
+		return
+	}
+
+	// path segments are unescaped individually so that escaped slashes (e.g.
+	// in Workers KV key names) stay within their segment.
+	path := strings.TrimPrefix(r.URL.EscapedPath(), "/client/v4")
+	var segments []string
+	for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			unescaped = segment
+		}
+		segments = append(segments, unescaped)
+	}
+
+	methodMismatch := false
+	for _, rt := range s.routes {
+		p, ok := rt.match(segments)
+		if !ok {
+			continue
+		}
+
+		if rt.method != r.Method {
+			methodMismatch = true
+			continue
+		}
+
+		rt.handler(w, r, p)
+		return
+	}
+
+	if methodMismatch {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, fmt.Sprintf("Method %s not available for that URI.", r.Method))
+		return
+	}
+
+	writeError(w, http.StatusNotFound, CodeRouteNotFound, fmt.Sprintf("Could not route to %s, perhaps your object identifier is invalid?", r.URL.Path))
+}
+
+// envelope is the standard v4 API response body.
+type envelope struct {
+	Success    bool                      `json:"success"`
+	Errors     []cloudflare.ResponseInfo `json:"errors"`
+	Messages   []cloudflare.ResponseInfo `json:"messages"`
+	Result     interface{}               `json:"result"`
+	ResultInfo *cloudflare.ResultInfo    `json:"result_info,omitempty"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, body envelope) {
+	if body.Errors == nil {
+		body.Errors = []cloudflare.ResponseInfo{}
+	}
+	if body.Messages == nil {
+		body.Messages = []cloudflare.ResponseInfo{}
+	}
+	body.Success = status < http.StatusBadRequest
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("cf-ray", newID()[:16]+"-SJC")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeResult(w http.ResponseWriter, result interface{}) {
+	writeJSON(w, http.StatusOK, envelope{Result: result})
+}
+
+func writePage(w http.ResponseWriter, result interface{}, info cloudflare.ResultInfo) {
+	writeJSON(w, http.StatusOK, envelope{Result: result, ResultInfo: &info})
+}
+
+func writeError(w http.ResponseWriter, status, code int, message string) {
+	writeJSON(w, status, envelope{Errors: []cloudflare.ResponseInfo{{Code: code, Message: message}}})
+}
+
+// decode reads the JSON request body into `v`, writing an error response
+// and returning false when it can't.
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, fmt.Sprintf("Invalid request: %s", err))
+		return false
+	}
+
+	return true
+}
+
+// paginate returns the requested page of `items` using the `page` and
+// `per_page` query parameters.
+func paginate[T any](r *http.Request, items []T, defaultPerPage, maxPerPage int) ([]T, cloudflare.ResultInfo) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if perPage < 1 {
+		perPage = defaultPerPage
+	}
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+
+	totalPages := (len(items) + perPage - 1) / perPage
+	start := (page - 1) * perPage
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + perPage
+	if end > len(items) {
+		end = len(items)
+	}
+
+	result := items[start:end]
+	return result, cloudflare.ResultInfo{
+		Page:       page,
+		PerPage:    perPage,
+		TotalPages: totalPages,
+		Count:      len(result),
+		Total:      len(items),
+	}
+}
+
+// paginateCursor returns the page of `items` after the opaque `cursor`
+// along with the cursor for the next page, which is empty on the last page.
+func paginateCursor[T any](cursor string, items []T, limit int) ([]T, string) {
+	start, _ := strconv.Atoi(cursor)
+	if start < 0 || start > len(items) {
+		start = len(items)
+	}
+
+	end := start + limit
+	if end >= len(items) {
+		return items[start:], ""
+	}
+
+	return items[start:end], strconv.Itoa(end)
+}
+
+// newID returns a random 32 character hex identifier like the ones used by
+// the API.
+func newID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// newUUID returns a random version 4 UUID.
+func newUUID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
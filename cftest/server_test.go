@@ -0,0 +1,96 @@
+package cftest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_SimulateRateLimit(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	zone := srv.AddZone("example.com")
+
+	api, err := srv.Client()
+	require.NoError(t, err)
+
+	srv.SimulateRateLimit(1, 0)
+	_, err = api.ZoneDetails(context.Background(), zone.ID)
+	var rateLimitErr *cloudflare.RatelimitError
+	require.True(t, errors.As(err, &rateLimitErr), "expected a rate limit error, got %v", err)
+	assert.True(t, rateLimitErr.InternalErrorCodeIs(CodeRateLimited))
+
+	srv.SimulateRateLimit(2, 0)
+	retrying, err := srv.Client(cloudflare.UsingRetryPolicy(2, 0, 0))
+	require.NoError(t, err)
+
+	got, err := retrying.ZoneDetails(context.Background(), zone.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", got.Name)
+	assert.Equal(t, 4, srv.Requests())
+}
+
+func TestServer_FailNext(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	zone := srv.AddZone("example.com")
+
+	api, err := srv.Client()
+	require.NoError(t, err)
+
+	srv.FailNext(http.StatusForbidden, cloudflare.ResponseInfo{Code: 10000, Message: "Authentication error"})
+	_, err = api.ZoneDetails(context.Background(), zone.ID)
+	var authErr *cloudflare.AuthenticationError
+	require.True(t, errors.As(err, &authErr), "expected an authentication error, got %v", err)
+	assert.True(t, authErr.InternalErrorCodeIs(10000))
+
+	_, err = api.ZoneDetails(context.Background(), zone.ID)
+	assert.NoError(t, err)
+}
+
+func TestServer_MissingCredentials(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/zones")
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func TestServer_UnknownRoute(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	api, err := srv.Client()
+	require.NoError(t, err)
+
+	_, err = api.ZoneDetails(context.Background(), "023e105f4ecef8ad9ca31a8372d0c353")
+	var notFoundErr *cloudflare.NotFoundError
+	require.True(t, errors.As(err, &notFoundErr), "expected a not found error, got %v", err)
+	assert.True(t, notFoundErr.InternalErrorCodeIs(CodeRouteNotFound))
+
+	_, err = api.ZoneActivationCheck(context.Background(), "023e105f4ecef8ad9ca31a8372d0c353")
+	assert.Error(t, err)
+}
+
+func TestPaginateCursor(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	page, cursor := paginateCursor("", items, 2)
+	assert.Equal(t, []int{1, 2}, page)
+	assert.Equal(t, "2", cursor)
+
+	page, cursor = paginateCursor(cursor, items, 2)
+	assert.Equal(t, []int{3, 4}, page)
+
+	page, cursor = paginateCursor(cursor, items, 2)
+	assert.Equal(t, []int{5}, page)
+	assert.Empty(t, cursor)
+}
@@ -0,0 +1,207 @@
+package cftest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// Error codes returned by the tunnel endpoints.
+const (
+	CodeTunnelValidationError = 1001
+	CodeTunnelNameConflict    = 1013
+	CodeTunnelNotFound        = 1003
+)
+
+type tunnel struct {
+	cloudflare.Tunnel
+	secret  string
+	config  cloudflare.TunnelConfiguration
+	version int
+}
+
+func (s *Server) registerTunnelRoutes() {
+	const tunnels = "/accounts/{account_id}/cfd_tunnel"
+
+	s.handle(http.MethodGet, tunnels, s.listTunnels)
+	s.handle(http.MethodPost, tunnels, s.createTunnel)
+	s.handle(http.MethodGet, tunnels+"/{tunnel_id}", s.getTunnel)
+	s.handle(http.MethodPatch, tunnels+"/{tunnel_id}", s.updateTunnel)
+	s.handle(http.MethodDelete, tunnels+"/{tunnel_id}", s.deleteTunnel)
+	s.handle(http.MethodGet, tunnels+"/{tunnel_id}/configurations", s.getTunnelConfiguration)
+	s.handle(http.MethodPut, tunnels+"/{tunnel_id}/configurations", s.updateTunnelConfiguration)
+	s.handle(http.MethodGet, tunnels+"/{tunnel_id}/connections", s.listTunnelConnections)
+	s.handle(http.MethodDelete, tunnels+"/{tunnel_id}/connections", s.cleanupTunnelConnections)
+	s.handle(http.MethodGet, tunnels+"/{tunnel_id}/token", s.getTunnelToken)
+}
+
+func (s *Server) listTunnels(w http.ResponseWriter, r *http.Request, p params) {
+	q := r.URL.Query()
+
+	tunnels := make([]cloudflare.Tunnel, 0)
+	for _, t := range s.tunnels[p["account_id"]] {
+		if v := q.Get("name"); v != "" && t.Name != v {
+			continue
+		}
+		if v := q.Get("uuid"); v != "" && t.ID != v {
+			continue
+		}
+		if v := q.Get("is_deleted"); v != "" {
+			deleted, _ := strconv.ParseBool(v)
+			if (t.DeletedAt != nil) != deleted {
+				continue
+			}
+		}
+		tunnels = append(tunnels, t.Tunnel)
+	}
+	sort.Slice(tunnels, func(i, j int) bool { return tunnels[i].Name < tunnels[j].Name })
+
+	page, info := paginate(r, tunnels, 20, 1000)
+	writePage(w, page, info)
+}
+
+func (s *Server) createTunnel(w http.ResponseWriter, r *http.Request, p params) {
+	var body cloudflare.TunnelCreateParams
+	if !decode(w, r, &body) {
+		return
+	}
+
+	if body.Name == "" || body.Secret == "" {
+		writeError(w, http.StatusBadRequest, CodeTunnelValidationError, "name and tunnel_secret are required")
+		return
+	}
+
+	accountID := p["account_id"]
+	for _, t := range s.tunnels[accountID] {
+		if t.Name == body.Name && t.DeletedAt == nil {
+			writeError(w, http.StatusConflict, CodeTunnelNameConflict, "You already have a tunnel with this name.")
+			return
+		}
+	}
+
+	ts := now()
+	t := &tunnel{
+		Tunnel: cloudflare.Tunnel{ID: newUUID(), Name: body.Name, CreatedAt: &ts},
+		secret: body.Secret,
+	}
+	if s.tunnels[accountID] == nil {
+		s.tunnels[accountID] = make(map[string]*tunnel)
+	}
+	s.tunnels[accountID][t.ID] = t
+
+	writeResult(w, t.Tunnel)
+}
+
+func (s *Server) getTunnel(w http.ResponseWriter, r *http.Request, p params) {
+	t, ok := s.tunnel(w, p)
+	if !ok {
+		return
+	}
+
+	writeResult(w, t.Tunnel)
+}
+
+func (s *Server) updateTunnel(w http.ResponseWriter, r *http.Request, p params) {
+	t, ok := s.tunnel(w, p)
+	if !ok {
+		return
+	}
+
+	var body cloudflare.TunnelUpdateParams
+	if !decode(w, r, &body) {
+		return
+	}
+
+	if body.Name != "" {
+		t.Name = body.Name
+	}
+	if body.Secret != "" {
+		t.secret = body.Secret
+	}
+
+	writeResult(w, t.Tunnel)
+}
+
+func (s *Server) deleteTunnel(w http.ResponseWriter, r *http.Request, p params) {
+	t, ok := s.tunnel(w, p)
+	if !ok {
+		return
+	}
+
+	// deleted tunnels are kept so that they can still be listed with
+	// `is_deleted=true`.
+	if t.DeletedAt == nil {
+		ts := now()
+		t.DeletedAt = &ts
+	}
+
+	writeResult(w, t.Tunnel)
+}
+
+func (s *Server) getTunnelConfiguration(w http.ResponseWriter, r *http.Request, p params) {
+	t, ok := s.tunnel(w, p)
+	if !ok {
+		return
+	}
+
+	writeResult(w, cloudflare.TunnelConfigurationResult{TunnelID: t.ID, Config: t.config, Version: t.version})
+}
+
+func (s *Server) updateTunnelConfiguration(w http.ResponseWriter, r *http.Request, p params) {
+	t, ok := s.tunnel(w, p)
+	if !ok {
+		return
+	}
+
+	var body cloudflare.TunnelConfigurationParams
+	if !decode(w, r, &body) {
+		return
+	}
+
+	t.config = body.Config
+	t.version++
+
+	writeResult(w, cloudflare.TunnelConfigurationResult{TunnelID: t.ID, Config: t.config, Version: t.version})
+}
+
+func (s *Server) listTunnelConnections(w http.ResponseWriter, r *http.Request, p params) {
+	if _, ok := s.tunnel(w, p); !ok {
+		return
+	}
+
+	// the fake never has any cloudflared instances connected.
+	writeResult(w, []cloudflare.Connection{})
+}
+
+func (s *Server) cleanupTunnelConnections(w http.ResponseWriter, r *http.Request, p params) {
+	t, ok := s.tunnel(w, p)
+	if !ok {
+		return
+	}
+
+	writeResult(w, t.Tunnel)
+}
+
+func (s *Server) getTunnelToken(w http.ResponseWriter, r *http.Request, p params) {
+	t, ok := s.tunnel(w, p)
+	if !ok {
+		return
+	}
+
+	token, _ := json.Marshal(map[string]string{"a": p["account_id"], "t": t.ID, "s": t.secret})
+	writeResult(w, base64.StdEncoding.EncodeToString(token))
+}
+
+func (s *Server) tunnel(w http.ResponseWriter, p params) (*tunnel, bool) {
+	t, ok := s.tunnels[p["account_id"]][p["tunnel_id"]]
+	if !ok {
+		writeError(w, http.StatusNotFound, CodeTunnelNotFound, "Tunnel not found")
+		return nil, false
+	}
+
+	return t, true
+}
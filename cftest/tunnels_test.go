@@ -0,0 +1,61 @@
+package cftest
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTunnels(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	api, err := srv.Client()
+	require.NoError(t, err)
+	ctx := context.Background()
+	rc := cloudflare.AccountIdentifier(srv.AccountID)
+
+	tunnel, err := api.CreateTunnel(ctx, rc, cloudflare.TunnelCreateParams{Name: "blog", Secret: "AQIDBAUGBwgBAgMEBQYHCAECAwQFBgcIAQIDBAUGBwg="})
+	require.NoError(t, err)
+	assert.Equal(t, "blog", tunnel.Name)
+	assert.Empty(t, tunnel.Secret)
+
+	config, err := api.UpdateTunnelConfiguration(ctx, rc, cloudflare.TunnelConfigurationParams{
+		TunnelID: tunnel.ID,
+		Config: cloudflare.TunnelConfiguration{
+			Ingress: []cloudflare.UnvalidatedIngressRule{{Hostname: "blog.example.com", Service: "http://localhost:8080"}, {Service: "http_status:404"}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, config.Version)
+
+	config, err = api.GetTunnelConfiguration(ctx, rc, tunnel.ID)
+	require.NoError(t, err)
+	require.Len(t, config.Config.Ingress, 2)
+	assert.Equal(t, "blog.example.com", config.Config.Ingress[0].Hostname)
+
+	token, err := api.TunnelToken(ctx, rc, tunnel.ID)
+	require.NoError(t, err)
+	raw, err := base64.StdEncoding.DecodeString(token)
+	require.NoError(t, err)
+	var claims map[string]string
+	require.NoError(t, json.Unmarshal(raw, &claims))
+	assert.Equal(t, tunnel.ID, claims["t"])
+
+	connections, err := api.TunnelConnections(ctx, rc, tunnel.ID)
+	require.NoError(t, err)
+	assert.Empty(t, connections)
+
+	require.NoError(t, api.DeleteTunnel(ctx, rc, tunnel.ID))
+
+	deleted := true
+	tunnels, err := api.Tunnels(ctx, rc, cloudflare.TunnelListParams{IsDeleted: &deleted})
+	require.NoError(t, err)
+	require.Len(t, tunnels, 1)
+	assert.NotNil(t, tunnels[0].DeletedAt)
+}
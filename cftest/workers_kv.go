@@ -0,0 +1,276 @@
+package cftest
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// Error codes returned by the Workers KV endpoints.
+const (
+	CodeKVKeyNotFound             = 10009
+	CodeKVNamespaceNotFound       = 10013
+	CodeKVNamespaceTitleDuplicate = 10014
+)
+
+type kvNamespace struct {
+	namespace cloudflare.WorkersKVNamespace
+	values    map[string]*kvValue
+}
+
+type kvValue struct {
+	value      []byte
+	expiration int64
+	metadata   interface{}
+}
+
+func (v *kvValue) expired() bool {
+	return v.expiration != 0 && v.expiration <= time.Now().Unix()
+}
+
+func (s *Server) registerWorkersKVRoutes() {
+	const namespaces = "/accounts/{account_id}/storage/kv/namespaces"
+
+	s.handle(http.MethodGet, namespaces, s.listKVNamespaces)
+	s.handle(http.MethodPost, namespaces, s.createKVNamespace)
+	s.handle(http.MethodPut, namespaces+"/{namespace_id}", s.updateKVNamespace)
+	s.handle(http.MethodDelete, namespaces+"/{namespace_id}", s.deleteKVNamespace)
+	s.handle(http.MethodGet, namespaces+"/{namespace_id}/keys", s.listKVKeys)
+	s.handle(http.MethodGet, namespaces+"/{namespace_id}/values/{key}", s.readKV)
+	s.handle(http.MethodPut, namespaces+"/{namespace_id}/values/{key}", s.writeKV)
+	s.handle(http.MethodDelete, namespaces+"/{namespace_id}/values/{key}", s.deleteKV)
+	s.handle(http.MethodGet, namespaces+"/{namespace_id}/metadata/{key}", s.readKVMetadata)
+	s.handle(http.MethodPut, namespaces+"/{namespace_id}/bulk", s.writeKVBulk)
+	s.handle(http.MethodDelete, namespaces+"/{namespace_id}/bulk", s.deleteKVBulk)
+}
+
+func (s *Server) listKVNamespaces(w http.ResponseWriter, r *http.Request, p params) {
+	namespaces := make([]cloudflare.WorkersKVNamespace, 0)
+	for _, ns := range s.kvNamespaces[p["account_id"]] {
+		namespaces = append(namespaces, ns.namespace)
+	}
+	sort.Slice(namespaces, func(i, j int) bool { return namespaces[i].Title < namespaces[j].Title })
+
+	page, info := paginate(r, namespaces, 20, 100)
+	writePage(w, page, info)
+}
+
+func (s *Server) createKVNamespace(w http.ResponseWriter, r *http.Request, p params) {
+	var body cloudflare.WorkersKVNamespaceRequest
+	if !decode(w, r, &body) {
+		return
+	}
+
+	accountID := p["account_id"]
+	for _, ns := range s.kvNamespaces[accountID] {
+		if ns.namespace.Title == body.Title {
+			writeError(w, http.StatusBadRequest, CodeKVNamespaceTitleDuplicate, "a namespace with this account ID and title already exists")
+			return
+		}
+	}
+
+	ns := &kvNamespace{
+		namespace: cloudflare.WorkersKVNamespace{ID: newID(), Title: body.Title},
+		values:    make(map[string]*kvValue),
+	}
+	if s.kvNamespaces[accountID] == nil {
+		s.kvNamespaces[accountID] = make(map[string]*kvNamespace)
+	}
+	s.kvNamespaces[accountID][ns.namespace.ID] = ns
+
+	writeResult(w, ns.namespace)
+}
+
+func (s *Server) updateKVNamespace(w http.ResponseWriter, r *http.Request, p params) {
+	ns, ok := s.kvNamespace(w, p)
+	if !ok {
+		return
+	}
+
+	var body cloudflare.WorkersKVNamespaceRequest
+	if !decode(w, r, &body) {
+		return
+	}
+	ns.namespace.Title = body.Title
+
+	writeResult(w, nil)
+}
+
+func (s *Server) deleteKVNamespace(w http.ResponseWriter, r *http.Request, p params) {
+	ns, ok := s.kvNamespace(w, p)
+	if !ok {
+		return
+	}
+
+	delete(s.kvNamespaces[p["account_id"]], ns.namespace.ID)
+	writeResult(w, nil)
+}
+
+func (s *Server) listKVKeys(w http.ResponseWriter, r *http.Request, p params) {
+	ns, ok := s.kvNamespace(w, p)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	if limit <= 0 || limit > 1000 {
+		limit = 1000
+	}
+
+	keys := make([]cloudflare.StorageKey, 0)
+	for name, v := range ns.values {
+		if v.expired() || !strings.HasPrefix(name, q.Get("prefix")) {
+			continue
+		}
+		keys = append(keys, cloudflare.StorageKey{Name: name, Expiration: int(v.expiration), Metadata: v.metadata})
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+
+	page, cursor := paginateCursor(q.Get("cursor"), keys, limit)
+	writePage(w, page, cloudflare.ResultInfo{Count: len(page), Cursor: cursor})
+}
+
+func (s *Server) readKV(w http.ResponseWriter, r *http.Request, p params) {
+	v, ok := s.kvValue(w, p)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	_, _ = w.Write(v.value)
+}
+
+func (s *Server) readKVMetadata(w http.ResponseWriter, r *http.Request, p params) {
+	v, ok := s.kvValue(w, p)
+	if !ok {
+		return
+	}
+
+	writeResult(w, v.metadata)
+}
+
+func (s *Server) writeKV(w http.ResponseWriter, r *http.Request, p params) {
+	ns, ok := s.kvNamespace(w, p)
+	if !ok {
+		return
+	}
+
+	value, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "could not read value")
+		return
+	}
+
+	ns.values[p["key"]] = &kvValue{
+		value:      value,
+		expiration: kvExpiration(r.URL.Query().Get("expiration"), r.URL.Query().Get("expiration_ttl")),
+	}
+
+	writeResult(w, nil)
+}
+
+func (s *Server) deleteKV(w http.ResponseWriter, r *http.Request, p params) {
+	ns, ok := s.kvNamespace(w, p)
+	if !ok {
+		return
+	}
+
+	delete(ns.values, p["key"])
+	writeResult(w, nil)
+}
+
+func (s *Server) writeKVBulk(w http.ResponseWriter, r *http.Request, p params) {
+	ns, ok := s.kvNamespace(w, p)
+	if !ok {
+		return
+	}
+
+	var pairs cloudflare.WorkersKVBulkWriteRequest
+	if !decode(w, r, &pairs) {
+		return
+	}
+
+	for _, pair := range pairs {
+		value := []byte(pair.Value)
+		if pair.Base64 {
+			decoded, err := base64.StdEncoding.DecodeString(pair.Value)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, CodeInvalidRequest, "invalid base64 value for key "+pair.Key)
+				return
+			}
+			value = decoded
+		}
+
+		ns.values[pair.Key] = &kvValue{
+			value:      value,
+			expiration: kvExpiration(strconv.Itoa(pair.Expiration), strconv.Itoa(pair.ExpirationTTL)),
+			metadata:   pair.Metadata,
+		}
+	}
+
+	writeResult(w, nil)
+}
+
+func (s *Server) deleteKVBulk(w http.ResponseWriter, r *http.Request, p params) {
+	ns, ok := s.kvNamespace(w, p)
+	if !ok {
+		return
+	}
+
+	var keys []string
+	if !decode(w, r, &keys) {
+		return
+	}
+
+	for _, key := range keys {
+		delete(ns.values, key)
+	}
+
+	writeResult(w, nil)
+}
+
+func (s *Server) kvNamespace(w http.ResponseWriter, p params) (*kvNamespace, bool) {
+	ns, ok := s.kvNamespaces[p["account_id"]][p["namespace_id"]]
+	if !ok {
+		writeError(w, http.StatusNotFound, CodeKVNamespaceNotFound, "namespace not found")
+		return nil, false
+	}
+
+	return ns, true
+}
+
+func (s *Server) kvValue(w http.ResponseWriter, p params) (*kvValue, bool) {
+	ns, ok := s.kvNamespace(w, p)
+	if !ok {
+		return nil, false
+	}
+
+	v, ok := ns.values[p["key"]]
+	if !ok || v.expired() {
+		writeError(w, http.StatusNotFound, CodeKVKeyNotFound, "get: 'key not found'")
+		return nil, false
+	}
+
+	return v, true
+}
+
+// kvExpiration works out the absolute expiry (in seconds since the epoch)
+// from the `expiration` and `expiration_ttl` parameters.
+func kvExpiration(expiration, ttl string) int64 {
+	if v, _ := strconv.ParseInt(expiration, 10, 64); v > 0 {
+		return v
+	}
+
+	if v, _ := strconv.ParseInt(ttl, 10, 64); v > 0 {
+		return time.Now().Unix() + v
+	}
+
+	return 0
+}
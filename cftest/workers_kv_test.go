@@ -0,0 +1,95 @@
+package cftest
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkersKV(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	api, err := srv.Client()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	ns, err := api.CreateWorkersKVNamespace(ctx, &cloudflare.WorkersKVNamespaceRequest{Title: "sessions"})
+	require.NoError(t, err)
+
+	namespaces, err := api.ListWorkersKVNamespaces(ctx)
+	require.NoError(t, err)
+	require.Len(t, namespaces, 1)
+	assert.Equal(t, "sessions", namespaces[0].Title)
+
+	_, err = api.WriteWorkersKV(ctx, ns.Result.ID, "path/to/key", []byte("hello"))
+	require.NoError(t, err)
+
+	value, err := api.ReadWorkersKV(ctx, ns.Result.ID, "path/to/key")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(value))
+
+	_, err = api.DeleteWorkersKV(ctx, ns.Result.ID, "path/to/key")
+	require.NoError(t, err)
+
+	_, err = api.ReadWorkersKV(ctx, ns.Result.ID, "path/to/key")
+	var notFoundErr *cloudflare.NotFoundError
+	require.True(t, errors.As(err, &notFoundErr), "expected a not found error, got %v", err)
+	assert.True(t, notFoundErr.InternalErrorCodeIs(CodeKVKeyNotFound))
+
+	_, err = api.DeleteWorkersKVNamespace(ctx, ns.Result.ID)
+	require.NoError(t, err)
+
+	_, err = api.ReadWorkersKV(ctx, ns.Result.ID, "path/to/key")
+	require.True(t, errors.As(err, &notFoundErr), "expected a not found error, got %v", err)
+	assert.True(t, notFoundErr.InternalErrorCodeIs(CodeKVNamespaceNotFound))
+}
+
+func TestWorkersKV_Bulk(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	api, err := srv.Client()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	ns, err := api.CreateWorkersKVNamespace(ctx, &cloudflare.WorkersKVNamespaceRequest{Title: "sessions"})
+	require.NoError(t, err)
+
+	pairs := cloudflare.WorkersKVBulkWriteRequest{
+		{Key: "binary", Value: base64.StdEncoding.EncodeToString([]byte{0xde, 0xad}), Base64: true},
+	}
+	for i := 0; i < 25; i++ {
+		pairs = append(pairs, &cloudflare.WorkersKVPair{Key: fmt.Sprintf("user:%02d", i), Value: "v", Metadata: map[string]interface{}{"n": i}})
+	}
+	_, err = api.WriteWorkersKVBulk(ctx, ns.Result.ID, pairs)
+	require.NoError(t, err)
+
+	value, err := api.ReadWorkersKV(ctx, ns.Result.ID, "binary")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xde, 0xad}, value)
+
+	limit, prefix := 10, "user:"
+	first, err := api.ListWorkersKVsWithOptions(ctx, ns.Result.ID, cloudflare.ListWorkersKVsOptions{Limit: &limit, Prefix: &prefix})
+	require.NoError(t, err)
+	require.Len(t, first.Result, 10)
+	assert.Equal(t, "user:00", first.Result[0].Name)
+	assert.NotEmpty(t, first.Cursor)
+
+	keys, err := api.ListWorkersKVsIterator(ctx, ns.Result.ID, cloudflare.ListWorkersKVsOptions{Limit: &limit, Prefix: &prefix}).All()
+	require.NoError(t, err)
+	assert.Len(t, keys, 25)
+
+	_, err = api.DeleteWorkersKVBulk(ctx, ns.Result.ID, []string{"binary", "user:00"})
+	require.NoError(t, err)
+
+	all, err := api.ListWorkersKVs(ctx, ns.Result.ID)
+	require.NoError(t, err)
+	assert.Len(t, all.Result, 24)
+}
@@ -0,0 +1,176 @@
+package cftest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// Error codes returned by the zone endpoints.
+const (
+	CodeZoneAlreadyExists = 1061
+	CodeInvalidZoneName   = 1099
+)
+
+func (s *Server) registerZoneRoutes() {
+	s.handle(http.MethodGet, "/zones", s.listZones)
+	s.handle(http.MethodPost, "/zones", s.createZone)
+	s.handle(http.MethodGet, "/zones/{zone_id}", s.getZone)
+	s.handle(http.MethodPatch, "/zones/{zone_id}", s.editZone)
+	s.handle(http.MethodDelete, "/zones/{zone_id}", s.deleteZone)
+}
+
+// AddZone adds a zone to the server's default account, bypassing the API.
+// It is useful for seeding state before a test.
+func (s *Server) AddZone(name string) cloudflare.Zone {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return *s.addZone(name, s.AccountID, "full")
+}
+
+func (s *Server) addZone(name, accountID, zoneType string) *cloudflare.Zone {
+	ts := now()
+	zone := &cloudflare.Zone{
+		ID:          newID(),
+		Name:        name,
+		Status:      "pending",
+		Type:        zoneType,
+		NameServers: []string{"ada.ns.cloudflare.com", "bob.ns.cloudflare.com"},
+		CreatedOn:   ts,
+		ModifiedOn:  ts,
+		Account:     cloudflare.Account{ID: accountID},
+	}
+	zone.Plan.ID = "0feeeeeeeeeeeeeeeeeeeeeeeeeeeeee"
+	zone.Plan.Name = "Free Website"
+
+	s.zones[zone.ID] = zone
+	s.dnsRecords[zone.ID] = make(map[string]*cloudflare.DNSRecord)
+
+	return zone
+}
+
+// zone returns the zone for the `zone_id` parameter, writing an error
+// response when it doesn't exist.
+func (s *Server) zone(w http.ResponseWriter, r *http.Request, p params) (*cloudflare.Zone, bool) {
+	zone, ok := s.zones[p["zone_id"]]
+	if !ok {
+		writeError(w, http.StatusNotFound, CodeRouteNotFound, fmt.Sprintf("Could not route to %s, perhaps your object identifier is invalid?", r.URL.Path))
+		return nil, false
+	}
+
+	return zone, true
+}
+
+func (s *Server) listZones(w http.ResponseWriter, r *http.Request, p params) {
+	q := r.URL.Query()
+
+	zones := make([]cloudflare.Zone, 0, len(s.zones))
+	for _, zone := range s.zones {
+		if name := q.Get("name"); name != "" && zone.Name != name {
+			continue
+		}
+		if status := q.Get("status"); status != "" && zone.Status != status {
+			continue
+		}
+		if accountID := q.Get("account.id"); accountID != "" && zone.Account.ID != accountID {
+			continue
+		}
+		zones = append(zones, *zone)
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].Name < zones[j].Name })
+
+	page, info := paginate(r, zones, 20, 50)
+	writePage(w, page, info)
+}
+
+func (s *Server) createZone(w http.ResponseWriter, r *http.Request, p params) {
+	var body struct {
+		Name    string `json:"name"`
+		Type    string `json:"type"`
+		Account *struct {
+			ID string `json:"id"`
+		} `json:"account"`
+	}
+	if !decode(w, r, &body) {
+		return
+	}
+
+	name := strings.ToLower(strings.TrimSuffix(body.Name, "."))
+	if !strings.Contains(name, ".") {
+		writeError(w, http.StatusBadRequest, CodeInvalidZoneName, fmt.Sprintf("Invalid zone name %q", body.Name))
+		return
+	}
+
+	for _, zone := range s.zones {
+		if zone.Name == name {
+			writeError(w, http.StatusBadRequest, CodeZoneAlreadyExists, fmt.Sprintf("%s already exists", name))
+			return
+		}
+	}
+
+	accountID := s.AccountID
+	if body.Account != nil && body.Account.ID != "" {
+		accountID = body.Account.ID
+	}
+
+	zoneType := body.Type
+	if zoneType == "" {
+		zoneType = "full"
+	}
+
+	writeResult(w, s.addZone(name, accountID, zoneType))
+}
+
+func (s *Server) getZone(w http.ResponseWriter, r *http.Request, p params) {
+	zone, ok := s.zone(w, r, p)
+	if !ok {
+		return
+	}
+
+	writeResult(w, zone)
+}
+
+func (s *Server) editZone(w http.ResponseWriter, r *http.Request, p params) {
+	zone, ok := s.zone(w, r, p)
+	if !ok {
+		return
+	}
+
+	var body cloudflare.ZoneOptions
+	if !decode(w, r, &body) {
+		return
+	}
+
+	if body.Paused != nil {
+		zone.Paused = *body.Paused
+	}
+	if body.VanityNS != nil {
+		zone.VanityNS = body.VanityNS
+	}
+	if body.Plan != nil {
+		zone.Plan = *body.Plan
+	}
+	if body.Type != "" {
+		zone.Type = body.Type
+	}
+	zone.ModifiedOn = now()
+
+	writeResult(w, zone)
+}
+
+func (s *Server) deleteZone(w http.ResponseWriter, r *http.Request, p params) {
+	zone, ok := s.zone(w, r, p)
+	if !ok {
+		return
+	}
+
+	delete(s.zones, zone.ID)
+	delete(s.dnsRecords, zone.ID)
+	delete(s.rulesets, containerKey("zones", zone.ID))
+
+	writeResult(w, map[string]string{"id": zone.ID})
+}
@@ -0,0 +1,58 @@
+package cftest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZones(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	api, err := srv.Client()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	zone, err := api.CreateZone(ctx, "example.com", false, cloudflare.Account{}, "full")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", zone.Name)
+	assert.Equal(t, srv.AccountID, zone.Account.ID)
+
+	_, err = api.CreateZone(ctx, "example.org", false, cloudflare.Account{}, "partial")
+	require.NoError(t, err)
+
+	_, err = api.CreateZone(ctx, "example.com", false, cloudflare.Account{}, "full")
+	var requestErr *cloudflare.RequestError
+	require.True(t, errors.As(err, &requestErr), "expected a request error, got %v", err)
+	assert.True(t, requestErr.InternalErrorCodeIs(CodeZoneAlreadyExists))
+
+	zones, err := api.ListZones(ctx)
+	require.NoError(t, err)
+	require.Len(t, zones, 2)
+	assert.Equal(t, "example.com", zones[0].Name)
+	assert.Equal(t, "partial", zones[1].Type)
+
+	zones, err = api.ListZones(ctx, "example.org")
+	require.NoError(t, err)
+	require.Len(t, zones, 1)
+
+	paused, err := api.ZoneSetPaused(ctx, zone.ID, true)
+	require.NoError(t, err)
+	assert.True(t, paused.Paused)
+
+	got, err := api.ZoneDetails(ctx, zone.ID)
+	require.NoError(t, err)
+	assert.True(t, got.Paused)
+
+	_, err = api.DeleteZone(ctx, zone.ID)
+	require.NoError(t, err)
+
+	_, err = api.ZoneDetails(ctx, zone.ID)
+	var notFoundErr *cloudflare.NotFoundError
+	assert.True(t, errors.As(err, &notFoundErr), "expected a not found error, got %v", err)
+}
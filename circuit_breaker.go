@@ -0,0 +1,281 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without sending the request when the circuit
+// breaker of the endpoint group is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of the circuit of an endpoint group.
+type CircuitState int
+
+const (
+	// CircuitClosed lets every request through.
+	CircuitClosed CircuitState = iota
+
+	// CircuitOpen fails every request with ErrCircuitOpen.
+	CircuitOpen
+
+	// CircuitHalfOpen lets a limited number of probe requests through to
+	// decide whether to close or reopen the circuit.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+// CircuitBreaker stops sending requests to an endpoint group during a
+// sustained outage instead of letting every caller retry against it.
+//
+// Each group's circuit opens after Threshold consecutive failures, where a
+// failure is a 5xx response or a request that timed out. While open,
+// requests fail immediately with ErrCircuitOpen and aren't retried. Once
+// Cooldown has passed the circuit is half-open and up to HalfOpenRequests
+// probe requests are let through; the circuit closes when a probe succeeds
+// and opens again when one fails.
+//
+// A CircuitBreaker is safe for concurrent use and may be shared by several
+// API instances using UsingCircuitBreaker. State changes are written to the
+// client's logger.
+type CircuitBreaker struct {
+	// Threshold is the number of consecutive failures that opens a
+	// circuit. Defaults to 5.
+	Threshold int
+
+	// Cooldown is how long a circuit stays open before it lets probe
+	// requests through. Defaults to 30 seconds.
+	Cooldown time.Duration
+
+	// HalfOpenRequests is the number of concurrent probe requests allowed
+	// while a circuit is half-open. Defaults to 1.
+	HalfOpenRequests int
+
+	// GroupFunc returns the endpoint group of a request URI. Defaults to the
+	// first two segments of the path that aren't account or zone
+	// identifiers, e.g. "zones/dns_records".
+	GroupFunc func(uri string) string
+
+	// OnStateChange, if set, is called whenever the circuit of a group
+	// changes state.
+	OnStateChange func(group string, from, to CircuitState)
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+	now      func() time.Time
+}
+
+type circuit struct {
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probes   int
+}
+
+// State returns the current state of the circuit of `group`.
+func (b *CircuitBreaker) State(group string) CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[group]
+	if !ok {
+		return CircuitClosed
+	}
+
+	if c.state == CircuitOpen && !b.clock().Before(c.openedAt.Add(b.cooldown())) {
+		return CircuitHalfOpen
+	}
+
+	return c.state
+}
+
+// allow reports whether a request to `uri` may be sent and returns its
+// endpoint group. Every nil error must be followed by a call to record with
+// the outcome of the request.
+func (b *CircuitBreaker) allow(uri string, logf func(format string, v ...interface{})) (string, error) {
+	group := b.group(uri)
+
+	b.mu.Lock()
+	c := b.circuit(group)
+
+	var from CircuitState
+	changed := false
+	if c.state == CircuitOpen && !b.clock().Before(c.openedAt.Add(b.cooldown())) {
+		from, changed = c.state, true
+		c.state = CircuitHalfOpen
+		c.probes = 0
+	}
+
+	var err error
+	switch c.state {
+	case CircuitOpen:
+		err = fmt.Errorf("%s: %w", group, ErrCircuitOpen)
+	case CircuitHalfOpen:
+		if c.probes >= b.halfOpenRequests() {
+			err = fmt.Errorf("%s: %w", group, ErrCircuitOpen)
+		} else {
+			c.probes++
+		}
+	}
+	b.mu.Unlock()
+
+	if changed {
+		b.changed(logf, group, from, CircuitHalfOpen)
+	}
+
+	return group, err
+}
+
+// record updates the circuit of `group` with the outcome of a request.
+func (b *CircuitBreaker) record(group string, resp *http.Response, err error, logf func(format string, v ...interface{})) {
+	failed := isCircuitFailure(resp, err)
+
+	b.mu.Lock()
+	c := b.circuit(group)
+	from := c.state
+
+	switch {
+	case c.state == CircuitHalfOpen:
+		c.probes--
+		// requests that were cancelled or never sent because of a dry run
+		// don't say anything about the health of the API.
+		neutral := errors.Is(err, context.Canceled) || errors.Is(err, ErrDryRun)
+		if failed || (err != nil && !neutral) {
+			c.state = CircuitOpen
+			c.openedAt = b.clock()
+		} else if err == nil {
+			c.state = CircuitClosed
+			c.failures = 0
+		}
+	case failed:
+		c.failures++
+		if c.state == CircuitClosed && c.failures >= b.threshold() {
+			c.state = CircuitOpen
+			c.openedAt = b.clock()
+		}
+	case err == nil:
+		c.failures = 0
+	}
+
+	to := c.state
+	b.mu.Unlock()
+
+	if from != to {
+		b.changed(logf, group, from, to)
+	}
+}
+
+func (b *CircuitBreaker) changed(logf func(format string, v ...interface{}), group string, from, to CircuitState) {
+	logf("Circuit breaker for %s changed from %s to %s\n", group, from, to)
+
+	if b.OnStateChange != nil {
+		b.OnStateChange(group, from, to)
+	}
+}
+
+// circuit returns the circuit of `group`. b.mu must be held.
+func (b *CircuitBreaker) circuit(group string) *circuit {
+	if b.circuits == nil {
+		b.circuits = make(map[string]*circuit)
+	}
+
+	c, ok := b.circuits[group]
+	if !ok {
+		c = &circuit{}
+		b.circuits[group] = c
+	}
+
+	return c
+}
+
+func (b *CircuitBreaker) group(uri string) string {
+	if b.GroupFunc != nil {
+		return b.GroupFunc(uri)
+	}
+
+	return defaultCircuitGroup(uri)
+}
+
+func (b *CircuitBreaker) clock() time.Time {
+	if b.now != nil {
+		return b.now()
+	}
+
+	return time.Now()
+}
+
+func (b *CircuitBreaker) threshold() int {
+	if b.Threshold > 0 {
+		return b.Threshold
+	}
+
+	return 5
+}
+
+func (b *CircuitBreaker) cooldown() time.Duration {
+	if b.Cooldown > 0 {
+		return b.Cooldown
+	}
+
+	return 30 * time.Second
+}
+
+func (b *CircuitBreaker) halfOpenRequests() int {
+	if b.HalfOpenRequests > 0 {
+		return b.HalfOpenRequests
+	}
+
+	return 1
+}
+
+// defaultCircuitGroup groups requests by the first two path segments of
+// `uri` that aren't the identifier following "accounts" or "zones".
+func defaultCircuitGroup(uri string) string {
+	segments := strings.Split(strings.Trim(uriPath(uri), "/"), "/")
+
+	var group []string
+	for i := 0; i < len(segments) && len(group) < 2; i++ {
+		group = append(group, segments[i])
+		switch segments[i] {
+		case string(AccountRouteLevel), string(ZoneRouteLevel):
+			if len(group) == 1 {
+				i++
+			}
+		}
+	}
+
+	return strings.Join(group, "/")
+}
+
+// isCircuitFailure reports whether the outcome of a request counts towards
+// opening a circuit.
+func isCircuitFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return true
+		}
+
+		var netErr net.Error
+		return errors.As(err, &netErr) && netErr.Timeout()
+	}
+
+	return resp != nil && resp.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,118 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingLogger keeps the circuit breaker state changes it is given.
+type recordingLogger struct {
+	lines []string
+}
+
+func (l *recordingLogger) Printf(format string, v ...interface{}) {
+	if line := fmt.Sprintf(format, v...); strings.HasPrefix(line, "Circuit breaker") {
+		l.lines = append(l.lines, line)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	setup()
+	defer teardown()
+
+	now := time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+	breaker := &CircuitBreaker{Threshold: 3, Cooldown: time.Minute, now: func() time.Time { return now }}
+	logger := &recordingLogger{}
+	require.NoError(t, UsingCircuitBreaker(breaker)(client))
+	require.NoError(t, UsingRetryPolicy(5, 0, 0)(client))
+	client.logger = logger
+
+	var requests int
+	healthy := false
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records/372e67954025e0ba6aaa6d586b9e0b59", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("content-type", "application/json")
+		if !healthy {
+			w.WriteHeader(http.StatusBadGateway)
+			fmt.Fprint(w, `{"success": false, "errors": [], "messages": [], "result": null}`)
+			return
+		}
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "372e67954025e0ba6aaa6d586b9e0b59"}}`)
+	})
+
+	// the circuit opens part way through the retries.
+	_, err := client.DNSRecord(context.Background(), testZoneID, "372e67954025e0ba6aaa6d586b9e0b59")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 3, requests)
+	assert.Equal(t, CircuitOpen, breaker.State("zones/dns_records"))
+
+	// other endpoint groups aren't affected.
+	assert.Equal(t, CircuitClosed, breaker.State("zones/settings"))
+
+	_, err = client.DNSRecord(context.Background(), testZoneID, "372e67954025e0ba6aaa6d586b9e0b59")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 3, requests, "request was sent while the circuit was open")
+
+	// a failed probe reopens the circuit.
+	now = now.Add(time.Minute)
+	assert.Equal(t, CircuitHalfOpen, breaker.State("zones/dns_records"))
+	_, err = client.DNSRecord(context.Background(), testZoneID, "372e67954025e0ba6aaa6d586b9e0b59")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 4, requests)
+
+	// and a successful one closes it.
+	now = now.Add(time.Minute)
+	healthy = true
+	_, err = client.DNSRecord(context.Background(), testZoneID, "372e67954025e0ba6aaa6d586b9e0b59")
+	require.NoError(t, err)
+	assert.Equal(t, CircuitClosed, breaker.State("zones/dns_records"))
+
+	assert.Equal(t, []string{
+		"Circuit breaker for zones/dns_records changed from closed to open\n",
+		"Circuit breaker for zones/dns_records changed from open to half-open\n",
+		"Circuit breaker for zones/dns_records changed from half-open to open\n",
+		"Circuit breaker for zones/dns_records changed from open to half-open\n",
+		"Circuit breaker for zones/dns_records changed from half-open to closed\n",
+	}, logger.lines)
+}
+
+func TestCircuitBreaker_DryRun(t *testing.T) {
+	now := time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+	breaker := &CircuitBreaker{Threshold: 1, Cooldown: time.Minute, now: func() time.Time { return now }}
+	logf := func(format string, v ...interface{}) {}
+
+	group, err := breaker.allow("/zones/"+testZoneID+"/dns_records", logf)
+	require.NoError(t, err)
+	breaker.record(group, &http.Response{StatusCode: http.StatusBadGateway}, nil, logf)
+	require.Equal(t, CircuitOpen, breaker.State(group))
+
+	// a probe captured by a dry run neither reopens nor closes the circuit.
+	now = now.Add(time.Minute)
+	_, err = breaker.allow("/zones/"+testZoneID+"/dns_records", logf)
+	require.NoError(t, err)
+	breaker.record(group, nil, fmt.Errorf("DELETE /zones: %w", ErrDryRun), logf)
+	assert.Equal(t, CircuitHalfOpen, breaker.State(group))
+}
+
+func TestDefaultCircuitGroup(t *testing.T) {
+	tests := map[string]string{
+		"/zones":                  "zones",
+		"/zones?name=example.com": "zones",
+		"/zones/" + testZoneID:    "zones",
+		"/zones/" + testZoneID + "/dns_records?page=2":                     "zones/dns_records",
+		"/accounts/" + testAccountID + "/storage/kv/namespaces":            "accounts/storage",
+		"/user/tokens/permission_groups":                                   "user/tokens",
+		"/accounts/" + testAccountID + "/workers/scripts/hello/content/v2": "accounts/workers",
+	}
+
+	for uri, want := range tests {
+		assert.Equal(t, want, defaultCircuitGroup(uri), uri)
+	}
+}
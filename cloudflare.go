@@ -9,7 +9,6 @@ import (
 	"io"
 	"io/ioutil"
 	"log"
-	"math"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -17,8 +16,6 @@ import (
 	"time"
 
 	"errors"
-
-	"golang.org/x/time/rate"
 )
 
 var (
@@ -50,9 +47,17 @@ type API struct {
 	headers           http.Header
 	httpClient        *http.Client
 	authType          int
-	rateLimiter       *rate.Limiter
-	retryPolicy       RetryPolicy
+	credentials       CredentialsProvider
+	rateLimiter       RateLimiter
+	retryPolicy       Retryer
+	circuitBreaker    *CircuitBreaker
+	validateParams    bool
+	dnsPreflight      bool
 	logger            Logger
+	leveledLogger     LeveledLoggerInterface
+	middleware        []Middleware
+	dryRun            *DryRunPlan
+	telemetry         Telemetry
 	Debug             bool
 }
 
@@ -64,7 +69,7 @@ func newClient(opts ...Option) (*API, error) {
 		BaseURL:     fmt.Sprintf("%s://%s%s", defaultScheme, defaultHostname, defaultBasePath),
 		UserAgent:   userAgent + "/" + Version,
 		headers:     make(http.Header),
-		rateLimiter: rate.NewLimiter(rate.Limit(4), 1), // 4rps equates to default api limit (1200 req/5 min)
+		rateLimiter: newStaticRateLimiter(4), // 4rps equates to default api limit (1200 req/5 min)
 		retryPolicy: RetryPolicy{
 			MaxRetries:    3,
 			MinRetryDelay: time.Duration(1) * time.Second,
@@ -139,6 +144,23 @@ func NewWithUserServiceKey(key string, opts ...Option) (*API, error) {
 	return api, nil
 }
 
+// NewWithCredentialsProvider creates a new Cloudflare v4 API client that
+// authenticates each request with the credentials supplied by `provider`.
+func NewWithCredentialsProvider(provider CredentialsProvider, opts ...Option) (*API, error) {
+	if provider == nil {
+		return nil, ErrMissingCredentials
+	}
+
+	api, err := newClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	api.credentials = provider
+
+	return api, nil
+}
+
 // SetAuthType sets the authentication method (AuthKeyEmail, AuthToken, or AuthUserService).
 func (api *API) SetAuthType(authType int) {
 	api.authType = authType
@@ -190,6 +212,9 @@ type APIResponse struct {
 	Status     string
 	StatusCode int
 	Headers    http.Header
+
+	// stream is the unread response body of a streamed request.
+	stream io.ReadCloser
 }
 
 func (api *API) makeRequestWithAuthTypeAndHeaders(ctx context.Context, method, uri string, params interface{}, authType int, headers http.Header) ([]byte, error) {
@@ -206,15 +231,56 @@ func (api *API) makeRequestContextWithHeadersComplete(ctx context.Context, metho
 }
 
 func (api *API) makeRequestWithAuthTypeAndHeadersComplete(ctx context.Context, method, uri string, params interface{}, authType int, headers http.Header) (*APIResponse, error) {
+	return api.sendRequest(ctx, method, uri, params, authType, headers, false)
+}
+
+// sendRequest sends the request, reauthenticating once if the credentials
+// have been revoked. When `stream` is true the body of a successful response
+// is returned unread in APIResponse.stream.
+func (api *API) sendRequest(ctx context.Context, method, uri string, params interface{}, authType int, headers http.Header, stream bool) (*APIResponse, error) {
+	if api.validateParams {
+		if err := validateParams(method, params); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, rt := startTelemetry(ctx, api.telemetry, method, uri)
+	res, err := api.sendRequestWithRetries(ctx, method, uri, params, authType, headers, stream, rt)
+
+	// credentials from a provider may have been revoked or rotated since
+	// they were retrieved so give the provider a single chance to replace
+	// them. Streamed bodies have already been consumed and can't be resent.
+	var authErr *AuthorizationError
+	if _, streamed := params.(io.Reader); api.credentials != nil && !streamed && errors.As(err, &authErr) {
+		api.credentials.Invalidate()
+		rt.retried()
+		res, err = api.sendRequestWithRetries(ctx, method, uri, params, authType, headers, stream, rt)
+	}
+
+	rt.end(ctx, err)
+
+	return res, err
+}
+
+// sendRequestWithRetries sends the request, retrying and rate limiting it as
+// configured, and converts error responses into the matching error type.
+func (api *API) sendRequestWithRetries(ctx context.Context, method, uri string, params interface{}, authType int, headers http.Header, stream bool, rt *requestTelemetry) (*APIResponse, error) {
 	var err error
 	var resp *http.Response
 	var respErr error
 	var respBody []byte
 
-	for i := 0; i <= api.retryPolicy.MaxRetries; i++ {
+	rc := resourceContainerFromURI(uri)
+
+	for i := 0; ; i++ {
 		var reqBody io.Reader
 		if params != nil {
-			if r, ok := params.(io.Reader); ok {
+			if b, ok := params.(*requestBody); ok {
+				reqBody, err = b.reader()
+				if err != nil {
+					return nil, err
+				}
+			} else if r, ok := params.(io.Reader); ok {
 				reqBody = r
 			} else if paramBytes, ok := params.([]byte); ok {
 				reqBody = bytes.NewReader(paramBytes)
@@ -229,14 +295,12 @@ func (api *API) makeRequestWithAuthTypeAndHeadersComplete(ctx context.Context, m
 		}
 
 		if i > 0 {
-			// expect the backoff introduced here on errored requests to dominate the effect of rate limiting
-			// don't need a random component here as the rate limiter should do something similar
-			// nb time duration could truncate an arbitrary float. Since our inputs are all ints, we should be ok
-			sleepDuration := time.Duration(math.Pow(2, float64(i-1)) * float64(api.retryPolicy.MinRetryDelay))
+			rt.retried()
+
+			// `resp` is still the previous (failed) response here so the
+			// retryer can take the server's guidance into account.
+			sleepDuration := api.retryPolicy.RetryDelay(i, resp)
 
-			if sleepDuration > api.retryPolicy.MaxRetryDelay {
-				sleepDuration = api.retryPolicy.MaxRetryDelay
-			}
 			// useful to do some simple logging here, maybe introduce levels later
 			api.logger.Printf("Sleeping %s before retry attempt number %d for request %s %s", sleepDuration.String(), i, method, uri)
 
@@ -247,54 +311,51 @@ func (api *API) makeRequestWithAuthTypeAndHeadersComplete(ctx context.Context, m
 			}
 		}
 
-		err = api.rateLimiter.Wait(ctx)
+		waitStart := time.Now()
+		err = api.rateLimiter.Wait(ctx, rc)
+		rt.waited(ctx, time.Since(waitStart))
 		if err != nil {
 			return nil, fmt.Errorf("error caused by request rate limiting: %w", err)
 		}
 
-		if api.Debug {
-			if method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch {
-				buf := &bytes.Buffer{}
-				tee := io.TeeReader(reqBody, buf)
-				debugBody, _ := ioutil.ReadAll(tee)
-				payloadBody, _ := ioutil.ReadAll(buf)
-				fmt.Printf("cloudflare-go [DEBUG] REQUEST Method:%v URI:%s Headers:%#v Body:%v\n", method, api.BaseURL+uri, headers, string(debugBody))
-				// ensure we recreate the io.Reader for use
-				reqBody = bytes.NewReader(payloadBody)
-			} else {
-				fmt.Printf("cloudflare-go [DEBUG] REQUEST Method:%v URI:%s Headers:%#v Body:%v\n", method, api.BaseURL+uri, headers, nil)
+		var credentials Credentials
+		var credentialsAuthType int
+		credentials, credentialsAuthType, err = api.credentialsFor(ctx, authType)
+		if err != nil {
+			return nil, err
+		}
+
+		var group string
+		if api.circuitBreaker != nil {
+			group, err = api.circuitBreaker.allow(uri, api.logger.Printf)
+			if err != nil {
+				return nil, err
 			}
 		}
 
-		resp, respErr = api.request(ctx, method, uri, reqBody, authType, headers)
+		var transportErr error
+		resp, transportErr = api.request(ctx, method, uri, reqBody, credentials, credentialsAuthType, headers, stream)
+		respErr = transportErr
+
+		if api.circuitBreaker != nil {
+			api.circuitBreaker.record(group, resp, transportErr, api.logger.Printf)
+		}
 
 		// short circuit processing on context timeouts
 		if respErr != nil && errors.Is(respErr, context.DeadlineExceeded) {
 			return nil, respErr
 		}
 
-		// retry if the server is rate limiting us or if it failed
-		// assumes server operations are rolled back on failure
-		if respErr != nil || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
-			if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
-				respErr = errors.New("exceeded available rate limit retries")
-			}
-
-			// if we got a valid http response, try to read body so we can reuse the connection
-			// see https://golang.org/pkg/net/http/#Client.Do
-			if respErr == nil {
-				respBody, err = ioutil.ReadAll(resp.Body)
-				resp.Body.Close()
-
-				respErr = fmt.Errorf("could not read response body: %w", err)
+		if resp != nil {
+			api.rateLimiter.Observe(rc, resp)
+			rt.observe(resp)
+		}
 
-				api.logger.Printf("Request: %s %s got an error response %d: %s\n", method, uri, resp.StatusCode,
-					strings.Replace(strings.Replace(string(respBody), "\n", "", -1), "\t", "", -1))
-			} else {
-				api.logger.Printf("Error performing request: %s %s : %s \n", method, uri, respErr.Error())
+		if respErr == nil && resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < http.StatusInternalServerError {
+			if stream && resp.StatusCode < http.StatusBadRequest {
+				break
 			}
-			continue
-		} else {
+
 			respBody, err = ioutil.ReadAll(resp.Body)
 			defer resp.Body.Close()
 			if err != nil {
@@ -302,15 +363,47 @@ func (api *API) makeRequestWithAuthTypeAndHeadersComplete(ctx context.Context, m
 			}
 			break
 		}
+
+		switch {
+		case respErr != nil:
+			api.logger.Printf("Error performing request: %s %s : %s \n", method, uri, respErr.Error())
+
+		case resp.StatusCode == http.StatusTooManyRequests:
+			respBody, _ = ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+
+			quota := ratelimitQuotaFromHeaders(resp.Header)
+			respErr = newRatelimitErrorFromResponse(resp, respBody, quota)
+
+			api.logger.Printf("Request: %s %s was rate limited (remaining: %d, retry after: %s)\n", method, uri, quota.Remaining, quota.RetryAfter)
+
+		default:
+			// if we got a valid http response, try to read body so we can reuse the connection
+			// see https://golang.org/pkg/net/http/#Client.Do
+			respBody, err = ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				respErr = fmt.Errorf("could not read response body: %w", err)
+			}
+
+			api.logger.Printf("Request: %s %s got an error response %d: %s\n", method, uri, resp.StatusCode,
+				strings.Replace(strings.Replace(redactBody(respBody), "\n", "", -1), "\t", "", -1))
+		}
+
+		if !api.retryPolicy.ShouldRetry(i+1, method, resp, transportErr) {
+			break
+		}
 	}
 
+	collectResponse(ctx, method, uri, resp, respBody)
+
 	// still had an error after all retries
 	if respErr != nil {
 		return nil, respErr
 	}
 
 	if api.Debug {
-		fmt.Printf("cloudflare-go [DEBUG] RESPONSE StatusCode:%d RayID:%s ContentType:%s Body:%#v\n", resp.StatusCode, resp.Header.Get("cf-ray"), resp.Header.Get("content-type"), string(respBody))
+		logResponse(api.debugLogger(), resp, respBody)
 	}
 
 	if resp.StatusCode >= http.StatusBadRequest {
@@ -347,6 +440,7 @@ func (api *API) makeRequestWithAuthTypeAndHeadersComplete(ctx context.Context, m
 			Errors:        errBody.Errors,
 			ErrorCodes:    errCodes,
 			ErrorMessages: errMsgs,
+			Messages:      errBody.Messages,
 		}
 
 		switch resp.StatusCode {
@@ -361,45 +455,85 @@ func (api *API) makeRequestWithAuthTypeAndHeadersComplete(ctx context.Context, m
 			return nil, &NotFoundError{cloudflareError: err}
 		case http.StatusTooManyRequests:
 			err.Type = ErrorTypeRateLimit
-			return nil, &RatelimitError{cloudflareError: err}
+			return nil, &RatelimitError{cloudflareError: err, Quota: ratelimitQuotaFromHeaders(resp.Header)}
 		default:
 			err.Type = ErrorTypeRequest
 			return nil, &RequestError{cloudflareError: err}
 		}
 	}
 
-	return &APIResponse{
+	res := &APIResponse{
 		Body:       respBody,
 		StatusCode: resp.StatusCode,
 		Status:     resp.Status,
 		Headers:    resp.Header,
-	}, nil
+	}
+	if stream {
+		res.stream = resp.Body
+	}
+
+	return res, nil
+}
+
+// debugLogger returns the logger debug output is written to.
+func (api *API) debugLogger() LeveledLoggerInterface {
+	if api.leveledLogger != nil {
+		return api.leveledLogger
+	}
+
+	return &LeveledLogger{Level: LevelDebug}
+}
+
+// credentialsFor returns the credentials to authenticate the next request
+// with and the authentication methods to use them for. Without a
+// CredentialsProvider these are the client's own fields and `authType`.
+func (api *API) credentialsFor(ctx context.Context, authType int) (Credentials, int, error) {
+	if api.credentials == nil {
+		return Credentials{
+			APIKey:            api.APIKey,
+			APIEmail:          api.APIEmail,
+			APIUserServiceKey: api.APIUserServiceKey,
+			APIToken:          api.APIToken,
+		}, authType, nil
+	}
+
+	credentials, err := api.credentials.Retrieve(ctx)
+	if err != nil {
+		return Credentials{}, 0, fmt.Errorf("failed to retrieve credentials: %w", err)
+	}
+
+	// prefer the authentication method the endpoint asked for but fall back
+	// to whatever the provider supplied.
+	available := credentials.authType()
+	if authType&available != 0 {
+		available &= authType
+	}
+
+	return credentials, available, nil
 }
 
 // request makes a HTTP request to the given API endpoint, returning the raw
 // *http.Response, or an error if one occurred. The caller is responsible for
-// closing the response body.
-func (api *API) request(ctx context.Context, method, uri string, reqBody io.Reader, authType int, headers http.Header) (*http.Response, error) {
+// closing the response body, which isn't buffered when `stream` is set.
+func (api *API) request(ctx context.Context, method, uri string, reqBody io.Reader, credentials Credentials, authType int, headers http.Header, stream bool) (*http.Response, error) {
 	req, err := http.NewRequestWithContext(ctx, method, api.BaseURL+uri, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("HTTP request creation failed: %w", err)
 	}
 
+	if r, ok := reqBody.(*sizedReader); ok {
+		req.ContentLength = r.length
+		if r.length == 0 {
+			req.Body = http.NoBody
+		}
+	}
+
 	combinedHeaders := make(http.Header)
 	copyHeader(combinedHeaders, api.headers)
 	copyHeader(combinedHeaders, headers)
 	req.Header = combinedHeaders
 
-	if authType&AuthKeyEmail != 0 {
-		req.Header.Set("X-Auth-Key", api.APIKey)
-		req.Header.Set("X-Auth-Email", api.APIEmail)
-	}
-	if authType&AuthUserService != 0 {
-		req.Header.Set("X-Auth-User-Service-Key", api.APIUserServiceKey)
-	}
-	if authType&AuthToken != 0 {
-		req.Header.Set("Authorization", "Bearer "+api.APIToken)
-	}
+	credentials.setHeaders(req.Header, authType)
 
 	if api.UserAgent != "" {
 		req.Header.Set("User-Agent", api.UserAgent)
@@ -409,7 +543,17 @@ func (api *API) request(ctx context.Context, method, uri string, reqBody io.Read
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := api.httpClient.Do(req)
+	if api.Debug {
+		logRequest(api.debugLogger(), req)
+	}
+
+	resp, err := doWithMiddleware(api.httpClient, api.requestMiddleware(), &MiddlewareRequest{
+		Method:   method,
+		URI:      uri,
+		AuthType: authType,
+		Request:  req,
+		Stream:   stream,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("HTTP request failed: %w", err)
 	}
@@ -498,14 +642,6 @@ type PaginationOptions struct {
 	PerPage int `json:"per_page,omitempty" url:"per_page,omitempty"`
 }
 
-// RetryPolicy specifies number of retries and min/max retry delays
-// This config is used when the client exponentially backs off after errored requests.
-type RetryPolicy struct {
-	MaxRetries    int
-	MinRetryDelay time.Duration
-	MaxRetryDelay time.Duration
-}
-
 // Logger defines the interface this library needs to use logging
 // This is a subset of the methods implemented in the log package.
 type Logger interface {
@@ -549,9 +685,9 @@ func WithPagination(opts PaginationOptions) ReqOption {
 	}
 }
 
-// checkResultInfo checks whether ResultInfo is reasonable except that it currently
-// ignores the cursor information. perPage, page, and count are the requested #items
-// per page, the requested page number, and the actual length of the Result array.
+// checkResultInfo checks whether ResultInfo is reasonable. perPage, page, and count
+// are the requested #items per page, the requested page number, and the actual
+// length of the Result array.
 //
 // Responses from the actual Cloudflare servers should pass all these checks (or we
 // discover a serious bug in the Cloudflare servers). However, the unit tests can
@@ -559,10 +695,20 @@ func WithPagination(opts PaginationOptions) ReqOption {
 // Correct pagination information is crucial for more advanced List* functions that
 // handle pagination automatically and fetch different pages in parallel.
 //
-// TODO: check cursors as well.
+// Cursor based responses don't carry page numbers or totals so only the page
+// size and the number of items are checked for them.
 func checkResultInfo(perPage, page, count int, info *ResultInfo) bool {
 	if info.Cursor != "" || info.Cursors.Before != "" || info.Cursors.After != "" {
-		panic("checkResultInfo could not handle cursors yet.")
+		switch {
+		case info.Count != count:
+			return false
+		case info.PerPage != 0 && info.PerPage != perPage:
+			return false
+		case perPage > 0 && count > perPage:
+			return false
+		default:
+			return true
+		}
 	}
 
 	switch {
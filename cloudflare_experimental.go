@@ -7,11 +7,11 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
 	"net/http"
 	"net/url"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 )
@@ -26,16 +26,24 @@ type ClientParams struct {
 	UserServiceKey string
 	Token          string
 	STS            *SecurityTokenConfiguration
+	Credentials    CredentialsProvider
 	BaseURL        *url.URL
 	UserAgent      string
 	Headers        http.Header
 	HTTPClient     *http.Client
-	RetryPolicy    RetryPolicy
+	RetryPolicy    Retryer
 	Logger         LeveledLoggerInterface
+	Middleware     []Middleware
+	DryRun         *DryRunPlan
+	CircuitBreaker *CircuitBreaker
 	Debug          bool
 }
 
 // A Client manages communication with the Cloudflare API.
+//
+// Services return typed results along with the pagination metadata of list
+// endpoints. The rest of the response (messages, `cf-ray` and rate limit
+// headers) can be collected with WithResponseCollector.
 type Client struct {
 	clientMu sync.Mutex
 
@@ -43,7 +51,13 @@ type Client struct {
 
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 
-	Zones *ZonesService
+	Zones     *ZonesService
+	DNS       *DNSService
+	Workers   *WorkersService
+	WorkersKV *WorkersKVService
+	Rulesets  *RulesetsService
+	Lists     *ListsService
+	Tunnels   *TunnelsService
 }
 
 // Client returns the http.Client used by this Cloudflare client.
@@ -86,29 +100,29 @@ func NewExperimental(config *ClientParams) (*Client, error) {
 	}
 
 	if config.HTTPClient != nil {
-		c.ClientParams.HTTPClient = config.HTTPClient
+		c.ClientParams.HTTPClient = withMiddlewareTransport(config.HTTPClient, c.ClientParams)
 	} else {
 		retryClient := retryablehttp.NewClient()
 
-		if c.RetryPolicy.MaxRetries > 0 {
-			retryClient.RetryMax = c.RetryPolicy.MaxRetries
-		} else {
-			retryClient.RetryMax = 4
+		switch policy := config.RetryPolicy.(type) {
+		case nil:
+			c.ClientParams.RetryPolicy = RetryPolicy{}.withDefaults()
+		case RetryPolicy:
+			// unset fields of a RetryPolicy have always meant "use the
+			// default" rather than "don't retry".
+			c.ClientParams.RetryPolicy = policy.withDefaults()
+		default:
+			c.ClientParams.RetryPolicy = policy
 		}
 
-		if c.RetryPolicy.MinRetryDelay > 0 {
-			retryClient.RetryWaitMin = c.RetryPolicy.MinRetryDelay
-		} else {
-			retryClient.RetryWaitMin = time.Duration(1) * time.Second
-		}
-
-		if c.RetryPolicy.MaxRetryDelay > 0 {
-			retryClient.RetryWaitMax = c.RetryPolicy.MaxRetryDelay
-		} else {
-			retryClient.RetryWaitMax = time.Duration(30) * time.Second
-		}
+		// the Retryer is responsible for giving up so only use `RetryMax` as
+		// a backstop.
+		retryClient.RetryMax = math.MaxInt32
+		retryClient.CheckRetry = retryableHTTPCheckRetry(c.ClientParams.RetryPolicy)
+		retryClient.Backoff = retryableHTTPBackoff(c.ClientParams.RetryPolicy)
 
 		retryClient.Logger = silentRetryLogger
+		retryClient.HTTPClient = withMiddlewareTransport(retryClient.HTTPClient, c.ClientParams)
 		c.ClientParams.HTTPClient = retryClient.StandardClient()
 	}
 
@@ -135,22 +149,47 @@ func NewExperimental(config *ClientParams) (*Client, error) {
 		c.ClientParams.UserServiceKey = config.UserServiceKey
 	}
 
+	c.ClientParams.Middleware = config.Middleware
+
+	// the dry run middleware is innermost so the rest of the chain sees the
+	// requests it captures.
+	if config.DryRun != nil {
+		c.ClientParams.DryRun = config.DryRun
+		c.ClientParams.Middleware = append(append([]Middleware(nil), config.Middleware...), config.DryRun.Middleware())
+	}
+
+	c.ClientParams.CircuitBreaker = config.CircuitBreaker
+
 	c.ClientParams.Debug = config.Debug
-	if c.ClientParams.Debug {
-		c.ClientParams.Logger = &LeveledLogger{Level: 4}
-	} else {
+	switch {
+	case config.Logger != nil:
+		c.ClientParams.Logger = config.Logger
+	case c.ClientParams.Debug:
+		c.ClientParams.Logger = &LeveledLogger{Level: LevelDebug}
+	default:
 		c.ClientParams.Logger = SilentLeveledLogger
 	}
 
+	c.ClientParams.Credentials = config.Credentials
+
+	// security tokens expire so rather than fetching one up front they are
+	// cached and refreshed by a provider. The first token is still fetched
+	// here so that misconfiguration is reported straight away.
 	if config.STS != nil {
-		stsToken, err := fetchSTSCredentials(config.STS)
-		if err != nil {
+		c.ClientParams.STS = config.STS
+		c.ClientParams.Credentials = NewSTSCredentials(config.STS)
+		if _, err := c.ClientParams.Credentials.Retrieve(context.Background()); err != nil {
 			return nil, ErrSTSFailure
 		}
-		c.ClientParams.Token = stsToken
 	}
 
 	c.Zones = (*ZonesService)(&c.common)
+	c.DNS = (*DNSService)(&c.common)
+	c.Workers = (*WorkersService)(&c.common)
+	c.WorkersKV = (*WorkersKVService)(&c.common)
+	c.Rulesets = (*RulesetsService)(&c.common)
+	c.Lists = (*ListsService)(&c.common)
+	c.Tunnels = (*TunnelsService)(&c.common)
 
 	return c, nil
 }
@@ -159,6 +198,8 @@ func NewExperimental(config *ClientParams) (*Client, error) {
 // *http.Response, or an error if one occurred. The caller is responsible for
 // closing the response body.
 func (c *Client) request(ctx context.Context, method, uri string, reqBody io.Reader, headers http.Header) (*http.Response, error) {
+	ctx = contextWithRetryState(ctx, method)
+
 	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL.String()+uri, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("HTTP request creation failed: %w", err)
@@ -169,22 +210,11 @@ func (c *Client) request(ctx context.Context, method, uri string, reqBody io.Rea
 	copyHeader(combinedHeaders, headers)
 	req.Header = combinedHeaders
 
-	if c.Key == "" && c.Email == "" && c.Token == "" && c.UserServiceKey == "" {
-		return nil, ErrMissingCredentials
-	}
-
-	if c.Key != "" {
-		req.Header.Set("X-Auth-Key", c.ClientParams.Key)
-		req.Header.Set("X-Auth-Email", c.ClientParams.Email)
-	}
-
-	if c.UserServiceKey != "" {
-		req.Header.Set("X-Auth-User-Service-Key", c.ClientParams.UserServiceKey)
-	}
-
-	if c.Token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.ClientParams.Token)
+	credentials, err := c.credentials(ctx)
+	if err != nil {
+		return nil, err
 	}
+	credentials.setHeaders(req.Header, credentials.authType())
 
 	if c.UserAgent != "" {
 		req.Header.Set("User-Agent", c.ClientParams.UserAgent)
@@ -194,6 +224,18 @@ func (c *Client) request(ctx context.Context, method, uri string, reqBody io.Rea
 		req.Header.Set("Content-Type", "application/json")
 	}
 
+	if c.Debug {
+		logRequest(c.Logger, req)
+	}
+
+	// the middleware runs in the HTTP client's transport so that it sees
+	// every attempt made by the retrying transport.
+	req = req.WithContext(contextWithMiddlewareRequest(ctx, MiddlewareRequest{
+		Method:   method,
+		URI:      uri,
+		AuthType: credentials.authType(),
+	}))
+
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("HTTP request failed: %w", err)
@@ -202,53 +244,93 @@ func (c *Client) request(ctx context.Context, method, uri string, reqBody io.Rea
 	return resp, nil
 }
 
+// credentials returns the credentials to authenticate the next request with,
+// preferring the CredentialsProvider over the static fields.
+func (c *Client) credentials(ctx context.Context) (Credentials, error) {
+	if c.Credentials != nil {
+		credentials, err := c.Credentials.Retrieve(ctx)
+		if err != nil {
+			return Credentials{}, fmt.Errorf("failed to retrieve credentials: %w", err)
+		}
+		return credentials, nil
+	}
+
+	credentials := Credentials{
+		APIKey:            c.Key,
+		APIEmail:          c.Email,
+		APIUserServiceKey: c.UserServiceKey,
+		APIToken:          c.Token,
+	}
+	if credentials.authType() == 0 {
+		return Credentials{}, ErrMissingCredentials
+	}
+
+	return credentials, nil
+}
+
 func (c *Client) makeRequest(ctx context.Context, method, uri string, params interface{}, headers http.Header) ([]byte, error) {
-	var reqBody io.Reader
 	var err error
+	var resp *http.Response
+	var respErr error
+	var respBody []byte
 
-	if params != nil {
-		if r, ok := params.(io.Reader); ok {
-			reqBody = r
-		} else if paramBytes, ok := params.([]byte); ok {
-			reqBody = bytes.NewReader(paramBytes)
-		} else {
-			var jsonBody []byte
-			jsonBody, err = json.Marshal(params)
+	for attempt := 0; ; attempt++ {
+		var reqBody io.Reader
+		if params != nil {
+			if r, ok := params.(io.Reader); ok {
+				reqBody = r
+			} else if paramBytes, ok := params.([]byte); ok {
+				reqBody = bytes.NewReader(paramBytes)
+			} else {
+				var jsonBody []byte
+				jsonBody, err = json.Marshal(params)
+				if err != nil {
+					return nil, fmt.Errorf("error marshalling params to JSON: %w", err)
+				}
+				reqBody = bytes.NewReader(jsonBody)
+			}
+		}
+
+		var group string
+		if c.CircuitBreaker != nil {
+			group, err = c.CircuitBreaker.allow(uri, c.Logger.Warnf)
 			if err != nil {
-				return nil, fmt.Errorf("error marshalling params to JSON: %w", err)
+				return nil, err
 			}
-			reqBody = bytes.NewReader(jsonBody)
 		}
-	}
 
-	var resp *http.Response
-	var respErr error
-	var respBody []byte
+		resp, respErr = c.request(ctx, method, uri, reqBody, headers)
+		if c.CircuitBreaker != nil {
+			c.CircuitBreaker.record(group, resp, respErr, c.Logger.Warnf)
+		}
+		if respErr != nil {
+			return nil, respErr
+		}
 
-	if method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch {
-		buf := &bytes.Buffer{}
-		tee := io.TeeReader(reqBody, buf)
-		debugBody, _ := ioutil.ReadAll(tee)
-		payloadBody, _ := ioutil.ReadAll(buf)
-		c.Logger.Debugf("REQUEST Method:%v URI:%s Headers:%#v Body:%v\n", method, c.BaseURL.String()+uri, headers, string(debugBody))
-		// ensure we recreate the io.Reader for use
-		reqBody = bytes.NewReader(payloadBody)
-	} else {
-		c.Logger.Debugf("REQUEST Method:%v URI:%s Headers:%#v Body:%v\n", method, c.BaseURL.String()+uri, headers, nil) //)
-	}
+		respBody, err = ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("could not read response body: %w", err)
+		}
 
-	resp, respErr = c.request(ctx, method, uri, reqBody, headers)
-	if respErr != nil {
-		return nil, respErr
-	}
+		// give the provider a single chance to replace credentials that
+		// have been revoked or rotated. Streamed bodies have already been
+		// consumed and can't be resent.
+		_, streamed := params.(io.Reader)
+		if resp.StatusCode != http.StatusUnauthorized || c.Credentials == nil || streamed || attempt > 0 {
+			break
+		}
 
-	c.Logger.Debugf("RESPONSE URI:%s StatusCode:%d Body:%#v RayID:%s\n", c.BaseURL.String()+uri, resp.StatusCode, string(respBody), resp.Header.Get("cf-ray"))
+		if c.Debug {
+			logResponse(c.Logger, resp, respBody)
+		}
+		c.Credentials.Invalidate()
+	}
 
-	respBody, err = ioutil.ReadAll(resp.Body)
-	resp.Body.Close()
-	if err != nil {
-		return nil, fmt.Errorf("could not read response body: %w", err)
+	if c.Debug {
+		logResponse(c.Logger, resp, respBody)
 	}
+	collectResponse(ctx, method, uri, resp, respBody)
 
 	if resp.StatusCode >= http.StatusBadRequest {
 		if strings.HasSuffix(resp.Request.URL.Path, "/filters/validate-expr") {
@@ -284,6 +366,7 @@ func (c *Client) makeRequest(ctx context.Context, method, uri string, params int
 			Errors:        errBody.Errors,
 			ErrorCodes:    errCodes,
 			ErrorMessages: errMsgs,
+			Messages:      errBody.Messages,
 		}
 
 		switch resp.StatusCode {
@@ -298,7 +381,7 @@ func (c *Client) makeRequest(ctx context.Context, method, uri string, params int
 			return nil, &NotFoundError{cloudflareError: err}
 		case http.StatusTooManyRequests:
 			err.Type = ErrorTypeRateLimit
-			return nil, &RatelimitError{cloudflareError: err}
+			return nil, &RatelimitError{cloudflareError: err, Quota: ratelimitQuotaFromHeaders(resp.Header)}
 		default:
 			err.Type = ErrorTypeRequest
 			return nil, &RequestError{cloudflareError: err}
@@ -327,3 +410,62 @@ func (c *Client) put(ctx context.Context, path string, payload interface{}) ([]b
 func (c *Client) delete(ctx context.Context, path string, payload interface{}) ([]byte, error) {
 	return c.makeRequest(ctx, http.MethodDelete, path, payload, nil)
 }
+
+// listResponse is the envelope returned by list endpoints.
+type listResponse[T any] struct {
+	Response
+	Result     []T        `json:"result"`
+	ResultInfo ResultInfo `json:"result_info"`
+}
+
+// getPage fetches a single page of results from a list endpoint.
+func getPage[T any](ctx context.Context, c *Client, uri string) ([]T, ResultInfo, error) {
+	res, err := c.get(ctx, uri, nil)
+	if err != nil {
+		return nil, ResultInfo{}, err
+	}
+
+	var r listResponse[T]
+	if err := json.Unmarshal(res, &r); err != nil {
+		return nil, ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+
+	return r.Result, r.ResultInfo, nil
+}
+
+// listAll returns the single page described by `start` when the caller asked
+// for one and every page otherwise. The ResultInfo is that of the last page
+// fetched.
+func listAll[T any](ctx context.Context, start ResultInfo, fetch PageFetcher[T]) ([]T, *ResultInfo, error) {
+	if start.Page > 0 || start.Cursor != "" || start.Cursors.After != "" {
+		items, info, err := fetch(ctx, start)
+		if err != nil {
+			return nil, nil, err
+		}
+		return items, &info, nil
+	}
+
+	it := newIterator(ctx, start, fetch)
+	items, err := it.All()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info := it.ResultInfo()
+	return items, &info, nil
+}
+
+// requireLevel checks that `rc` identifies a resource at one of `levels`.
+func requireLevel(rc *ResourceContainer, levels ...RouteLevel) error {
+	if rc == nil || rc.Identifier == "" {
+		return ErrMissingResourceIdentifier
+	}
+
+	for _, level := range levels {
+		if rc.Level == level {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(errInvalidResourceContainerAccess, rc.Level)
+}
@@ -0,0 +1,90 @@
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newExperimentalTestClient returns an experimental client for the test
+// server started by setup.
+func newExperimentalTestClient(t *testing.T) *Client {
+	baseURL, _ := url.Parse(server.URL)
+	c, err := NewExperimental(&ClientParams{BaseURL: baseURL, Token: "deadbeef", RetryPolicy: noRetries{}})
+	require.NoError(t, err)
+	return c
+}
+
+func TestRequireLevel(t *testing.T) {
+	assert.NoError(t, requireLevel(ZoneIdentifier(testZoneID), ZoneRouteLevel))
+	assert.NoError(t, requireLevel(AccountIdentifier(testAccountID), ZoneRouteLevel, AccountRouteLevel))
+	assert.ErrorIs(t, requireLevel(ZoneIdentifier(""), ZoneRouteLevel), ErrMissingResourceIdentifier)
+	assert.ErrorIs(t, requireLevel(nil, ZoneRouteLevel), ErrMissingResourceIdentifier)
+	assert.EqualError(t, requireLevel(AccountIdentifier(testAccountID), ZoneRouteLevel), `requested resource container ("accounts") is not supported for this endpoint`)
+}
+
+func TestListAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/items", func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": ["item-%s"], "result_info": {"page": %s, "per_page": 1, "total_pages": 3}}`, page, page)
+	})
+
+	c := newExperimentalTestClient(t)
+	fetch := func(ctx context.Context, info ResultInfo) ([]string, ResultInfo, error) {
+		return getPage[string](ctx, c, buildURI("/items", info))
+	}
+
+	items, info, err := listAll(context.Background(), ResultInfo{}, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"item-1", "item-2", "item-3"}, items)
+	assert.Equal(t, 3, info.Page)
+
+	items, info, err = listAll(context.Background(), ResultInfo{Page: 2}, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"item-2"}, items)
+	assert.Equal(t, 2, info.Page)
+}
+
+func TestExperimentalClient_DebugLogging(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/items", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": ["item-1"]}`)
+	})
+
+	baseURL, _ := url.Parse(server.URL)
+	for _, debug := range []bool{false, true} {
+		out := &bytes.Buffer{}
+		c, err := NewExperimental(&ClientParams{
+			BaseURL: baseURL,
+			Token:   "deadbeef",
+			Debug:   debug,
+			Logger:  &LeveledLogger{Level: LevelDebug, stdoutOverride: out},
+		})
+		require.NoError(t, err)
+
+		_, _, err = getPage[string](context.Background(), c, "/items")
+		require.NoError(t, err)
+		if debug {
+			assert.Contains(t, out.String(), "GET")
+			assert.Contains(t, out.String(), "RESPONSE")
+		} else {
+			assert.Empty(t, out.String(), "nothing is logged without Debug")
+		}
+	}
+}
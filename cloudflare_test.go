@@ -116,7 +116,7 @@ func TestClient_Headers(t *testing.T) {
 }
 
 func TestClient_RetryCanSucceedAfterErrors(t *testing.T) {
-	setup(UsingRetryPolicy(2, 0, 1))
+	setup(UsingRetryer(RetryPolicy{MaxRetries: 2, MaxRetryDelay: time.Second, RetryNonIdempotent: true}))
 	defer teardown()
 
 	requestsReceived := 0
@@ -188,6 +188,67 @@ func TestClient_RetryCanSucceedAfterErrors(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestClient_RetryDoesNotReplayNonIdempotentRequests(t *testing.T) {
+	setup(UsingRetryPolicy(2, 0, 1))
+	defer teardown()
+
+	requestsReceived := 0
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+		requestsReceived++
+
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, `{}`)
+	}
+
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records", handler)
+
+	_, err := client.CreateDNSRecord(context.Background(), testZoneID, DNSRecord{Type: "A", Name: "example.com", Content: "198.51.100.4"})
+
+	var serviceErr *ServiceError
+	assert.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, 1, requestsReceived)
+}
+
+func TestClient_RetryReplaysRateLimitedNonIdempotentRequests(t *testing.T) {
+	setup(UsingRetryPolicy(2, 0, 1))
+	defer teardown()
+
+	requestsReceived := 0
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+		requestsReceived++
+
+		w.Header().Set("content-type", "application/json")
+		if requestsReceived == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{}`)
+			return
+		}
+
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": {
+				"id": "372e67954025e0ba6aaa6d586b9e0b59",
+				"type": "A",
+				"name": "example.com",
+				"content": "198.51.100.4"
+			}
+		}`)
+	}
+
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records", handler)
+
+	res, err := client.CreateDNSRecord(context.Background(), testZoneID, DNSRecord{Type: "A", Name: "example.com", Content: "198.51.100.4"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "372e67954025e0ba6aaa6d586b9e0b59", res.Result.ID)
+	}
+	assert.Equal(t, 2, requestsReceived)
+}
+
 func TestClient_RetryReturnsPersistentErrorResponse(t *testing.T) {
 	setup(UsingRetryPolicy(2, 0, 1))
 	defer teardown()
@@ -213,6 +274,37 @@ func TestClient_RetryReturnsPersistentErrorResponse(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestClient_RatelimitErrorIncludesQuota(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		w.Header().Set("cf-ray", "7a1b2c3d4e5f6a7b-LHR")
+		w.Header().Set("Retry-After", "42")
+		w.Header().Set("Ratelimit", `"default";r=0;t=42`)
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{
+			"success": false,
+			"errors": [{"code": 971, "message": "Please wait and consider throttling your request speed"}],
+			"messages": [],
+			"result": null
+		}`)
+	}
+
+	mux.HandleFunc("/zones/"+testZoneID, handler)
+
+	_, err := client.ZoneDetails(context.Background(), testZoneID)
+
+	var ratelimitErr *RatelimitError
+	if assert.ErrorAs(t, err, &ratelimitErr) {
+		assert.Equal(t, 0, ratelimitErr.Quota.Remaining)
+		assert.Equal(t, 42*time.Second, ratelimitErr.Quota.RetryAfter)
+		assert.Equal(t, "7a1b2c3d4e5f6a7b-LHR", ratelimitErr.RayID())
+		assert.True(t, ratelimitErr.InternalErrorCodeIs(971))
+	}
+}
+
 func TestZoneIDByNameWithNonUniqueZonesWithoutOrgID(t *testing.T) {
 	setup()
 	defer teardown()
@@ -535,6 +627,11 @@ func TestCheckResultInfo(t *testing.T) {
 		{"we are not on the last page so it should be full of results", 20, 1, 19, ResultInfo{Page: 1, PerPage: 20, TotalPages: 2, Count: 19, Total: 39}, false},
 		{"last page only has 19 items not 20", 20, 2, 20, ResultInfo{Page: 2, PerPage: 20, TotalPages: 2, Count: 20, Total: 39}, false},
 		{"fully working result info", 20, 2, 19, ResultInfo{Page: 2, PerPage: 20, TotalPages: 2, Count: 19, Total: 39}, true},
+		{"cursor with matching count", 20, 0, 20, ResultInfo{PerPage: 20, Count: 20, Cursor: "abc"}, true},
+		{"cursor without per_page", 20, 0, 5, ResultInfo{Count: 5, Cursors: ResultInfoCursors{After: "abc"}}, true},
+		{"cursor counts do not match", 20, 0, 19, ResultInfo{PerPage: 20, Count: 20, Cursor: "abc"}, false},
+		{"cursor per_page do not match", 20, 0, 10, ResultInfo{PerPage: 10, Count: 10, Cursors: ResultInfoCursors{Before: "abc"}}, false},
+		{"cursor page larger than requested", 5, 0, 10, ResultInfo{Count: 10, Cursor: "abc"}, false},
 	} {
 		t.Run(c.TestName, func(t *testing.T) {
 			assert.Equal(t, c.Verdict, checkResultInfo(c.PerPage, c.Page, c.Count, &c.ResultInfo))
@@ -28,6 +28,12 @@ func main() {
 			Value:   "",
 			EnvVars: []string{"CF_ACCOUNT_ID"},
 		},
+		&cli.StringFlag{
+			Name:    "profile",
+			Usage:   "Optional credentials profile from ~/.cloudflare/credentials",
+			Value:   "",
+			EnvVars: []string{"CF_PROFILE"},
+		},
 		&cli.BoolFlag{
 			Name:  "json",
 			Usage: "show output as JSON instead of as a table",
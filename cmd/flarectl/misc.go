@@ -22,15 +22,12 @@ func initializeAPI(c *cli.Context) error {
 	// Be aware the following code sets the global package `api` variable
 	var err error
 
-	if apiToken != "" {
+	switch {
+	case c.IsSet("profile"):
+		api, err = cloudflare.NewFromProfile(c.String("profile"))
+	case apiToken != "":
 		api, err = cloudflare.NewWithAPIToken(apiToken)
-	} else {
-		if apiKey == "" {
-			err := errors.New("No CF_API_KEY or CF_API_TOKEN environment set")
-			fmt.Fprintln(os.Stderr, err)
-			return err
-		}
-
+	case apiKey != "":
 		if apiEmail == "" {
 			err := errors.New("No CF_API_EMAIL environment set")
 			fmt.Fprintln(os.Stderr, err)
@@ -38,6 +35,15 @@ func initializeAPI(c *cli.Context) error {
 		}
 
 		api, err = cloudflare.New(apiKey, apiEmail)
+	default:
+		// without credentials in the environment fall back to the default
+		// profile, if there is one.
+		api, err = cloudflare.NewFromProfile("")
+		if errors.Is(err, os.ErrNotExist) || errors.Is(err, cloudflare.ErrProfileNotFound) {
+			err := errors.New("No CF_API_KEY or CF_API_TOKEN environment set and no credentials profile found")
+			fmt.Fprintln(os.Stderr, err)
+			return err
+		}
 	}
 
 	if err != nil {
@@ -32,6 +32,10 @@ func zoneCreate(c *cli.Context) error {
 	accountID := c.String("account-id")
 	zoneType := c.String("type")
 	var account cloudflare.Account
+	// fall back to the account of the credentials profile, if any.
+	if accountID == "" {
+		accountID = api.AccountID
+	}
 	if accountID != "" {
 		account.ID = accountID
 	}
@@ -0,0 +1,275 @@
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// stsRefreshWindow is how long before expiry a security token is
+	// replaced.
+	stsRefreshWindow = time.Minute
+
+	// stsDefaultTTL is how long a security token is cached for when its
+	// expiry can't be determined.
+	stsDefaultTTL = 5 * time.Minute
+)
+
+// Credentials are the authentication details a request is sent with. Only
+// the fields for the authentication methods in use need to be populated.
+type Credentials struct {
+	APIKey            string `json:"api_key,omitempty"`
+	APIEmail          string `json:"api_email,omitempty"`
+	APIUserServiceKey string `json:"api_user_service_key,omitempty"`
+	APIToken          string `json:"api_token,omitempty"`
+}
+
+// authType returns the authentication methods the credentials can be used
+// with.
+func (c Credentials) authType() int {
+	var authType int
+	if c.APIKey != "" {
+		authType |= AuthKeyEmail
+	}
+	if c.APIUserServiceKey != "" {
+		authType |= AuthUserService
+	}
+	if c.APIToken != "" {
+		authType |= AuthToken
+	}
+	return authType
+}
+
+// setHeaders sets the authentication headers for `authType` on `h`.
+func (c Credentials) setHeaders(h http.Header, authType int) {
+	if authType&AuthKeyEmail != 0 {
+		h.Set("X-Auth-Key", c.APIKey)
+		h.Set("X-Auth-Email", c.APIEmail)
+	}
+	if authType&AuthUserService != 0 {
+		h.Set("X-Auth-User-Service-Key", c.APIUserServiceKey)
+	}
+	if authType&AuthToken != 0 {
+		h.Set("Authorization", "Bearer "+c.APIToken)
+	}
+}
+
+// CredentialsProvider supplies the credentials each request is authenticated
+// with, allowing them to change over the lifetime of a client.
+type CredentialsProvider interface {
+	// Retrieve returns the credentials to use for the next request.
+	Retrieve(ctx context.Context) (Credentials, error)
+
+	// Invalidate is called when the API rejects the credentials (HTTP 401),
+	// before the request is retried once. Providers that cache credentials
+	// should fetch fresh ones on the next call to Retrieve.
+	Invalidate()
+}
+
+type staticCredentials struct {
+	credentials Credentials
+}
+
+// NewStaticCredentials returns a CredentialsProvider that always supplies
+// `credentials`.
+func NewStaticCredentials(credentials Credentials) CredentialsProvider {
+	return &staticCredentials{credentials: credentials}
+}
+
+// NewStaticTokenCredentials returns a CredentialsProvider for a fixed API
+// Token.
+func NewStaticTokenCredentials(token string) CredentialsProvider {
+	return NewStaticCredentials(Credentials{APIToken: token})
+}
+
+// NewKeyEmailCredentials returns a CredentialsProvider for a fixed API key and
+// email address.
+func NewKeyEmailCredentials(key, email string) CredentialsProvider {
+	return NewStaticCredentials(Credentials{APIKey: key, APIEmail: email})
+}
+
+func (p *staticCredentials) Retrieve(ctx context.Context) (Credentials, error) {
+	if p.credentials.authType() == 0 {
+		return Credentials{}, ErrMissingCredentials
+	}
+
+	return p.credentials, nil
+}
+
+func (p *staticCredentials) Invalidate() {}
+
+type envCredentials struct{}
+
+// NewEnvCredentials returns a CredentialsProvider that reads the credentials
+// from the environment on every request. An API Token is read from
+// `CLOUDFLARE_API_TOKEN`, an API key and email address from
+// `CLOUDFLARE_API_KEY` and `CLOUDFLARE_EMAIL` and a User Service key from
+// `CLOUDFLARE_API_USER_SERVICE_KEY`. The `CF_API_TOKEN`, `CF_API_KEY` and
+// `CF_API_EMAIL` variables used by flarectl are also accepted.
+func NewEnvCredentials() CredentialsProvider {
+	return envCredentials{}
+}
+
+func (envCredentials) Retrieve(ctx context.Context) (Credentials, error) {
+	credentials := Credentials{
+		APIToken:          getenv("CLOUDFLARE_API_TOKEN", "CF_API_TOKEN"),
+		APIKey:            getenv("CLOUDFLARE_API_KEY", "CF_API_KEY"),
+		APIEmail:          getenv("CLOUDFLARE_EMAIL", "CF_API_EMAIL"),
+		APIUserServiceKey: getenv("CLOUDFLARE_API_USER_SERVICE_KEY"),
+	}
+	if credentials.authType() == 0 {
+		return Credentials{}, ErrMissingCredentials
+	}
+
+	return credentials, nil
+}
+
+func (envCredentials) Invalidate() {}
+
+// getenv returns the value of the first of `keys` that is set.
+func getenv(keys ...string) string {
+	for _, key := range keys {
+		if v := os.Getenv(key); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+type fileCredentials struct {
+	path string
+
+	mu          sync.Mutex
+	modTime     time.Time
+	size        int64
+	credentials Credentials
+}
+
+// NewFileCredentials returns a CredentialsProvider that reads the
+// credentials from the file at `path`, reloading it whenever it changes. This
+// suits credentials that are rotated on disk, such as mounted secrets.
+//
+// The file either contains a JSON encoded Credentials object or just an API
+// Token.
+func NewFileCredentials(path string) CredentialsProvider {
+	return &fileCredentials{path: path}
+}
+
+func (p *fileCredentials) Retrieve(ctx context.Context) (Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+
+	if info.ModTime().Equal(p.modTime) && info.Size() == p.size {
+		return p.credentials, nil
+	}
+
+	raw, err := os.ReadFile(p.path)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+
+	var credentials Credentials
+	raw = bytes.TrimSpace(raw)
+	if bytes.HasPrefix(raw, []byte("{")) {
+		if err := json.Unmarshal(raw, &credentials); err != nil {
+			return Credentials{}, fmt.Errorf("failed to parse credentials file: %w", err)
+		}
+	} else {
+		credentials.APIToken = string(raw)
+	}
+
+	if credentials.authType() == 0 {
+		return Credentials{}, ErrMissingCredentials
+	}
+
+	p.modTime = info.ModTime()
+	p.size = info.Size()
+	p.credentials = credentials
+
+	return credentials, nil
+}
+
+func (p *fileCredentials) Invalidate() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.modTime = time.Time{}
+}
+
+type stsCredentials struct {
+	config *SecurityTokenConfiguration
+
+	// httpClient is used to call the issuer. Tests replace it to trust
+	// their TLS server.
+	httpClient *http.Client
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// NewSTSCredentials returns a CredentialsProvider that issues API Tokens
+// from the security token service. Tokens are cached and replaced shortly
+// before they expire.
+func NewSTSCredentials(config *SecurityTokenConfiguration) CredentialsProvider {
+	return &stsCredentials{config: config}
+}
+
+func (p *stsCredentials) Retrieve(ctx context.Context) (Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token != "" && time.Now().Add(stsRefreshWindow).Before(p.expires) {
+		return Credentials{APIToken: p.token}, nil
+	}
+
+	token, err := fetchSTSCredentials(ctx, p.httpClient, p.config)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	p.token = token
+	p.expires = securityTokenExpiry(token)
+
+	return Credentials{APIToken: token}, nil
+}
+
+func (p *stsCredentials) Invalidate() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.token = ""
+}
+
+// securityTokenExpiry returns when the JSON Web Token `token` expires, based
+// on its `exp` claim. If the claim can't be read the token is assumed to be
+// valid for stsDefaultTTL.
+func securityTokenExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) == 3 {
+		payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+		if err == nil {
+			var claims struct {
+				Expires int64 `json:"exp"`
+			}
+			if json.Unmarshal(payload, &claims) == nil && claims.Expires > 0 {
+				return time.Unix(claims.Expires, 0)
+			}
+		}
+	}
+
+	return time.Now().Add(stsDefaultTTL)
+}
@@ -0,0 +1,191 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rotatingCredentials hands out a new token each time it is invalidated.
+type rotatingCredentials struct {
+	generation int32
+}
+
+func (p *rotatingCredentials) Retrieve(ctx context.Context) (Credentials, error) {
+	return Credentials{APIToken: fmt.Sprintf("token-%d", atomic.LoadInt32(&p.generation))}, nil
+}
+
+func (p *rotatingCredentials) Invalidate() {
+	atomic.AddInt32(&p.generation, 1)
+}
+
+func TestCredentials_SetHeaders(t *testing.T) {
+	creds := Credentials{APIKey: "deadbeef", APIEmail: "cloudflare@example.org", APIToken: "token"}
+	assert.Equal(t, AuthKeyEmail|AuthToken, creds.authType())
+
+	h := make(http.Header)
+	creds.setHeaders(h, AuthToken)
+	assert.Equal(t, "Bearer token", h.Get("Authorization"))
+	assert.Empty(t, h.Get("X-Auth-Key"))
+}
+
+func TestEnvCredentials(t *testing.T) {
+	t.Setenv("CLOUDFLARE_API_TOKEN", "")
+	t.Setenv("CF_API_TOKEN", "")
+	t.Setenv("CLOUDFLARE_API_KEY", "")
+	t.Setenv("CF_API_KEY", "")
+	t.Setenv("CLOUDFLARE_EMAIL", "")
+	t.Setenv("CF_API_EMAIL", "")
+	t.Setenv("CLOUDFLARE_API_USER_SERVICE_KEY", "")
+
+	provider := NewEnvCredentials()
+	_, err := provider.Retrieve(context.Background())
+	assert.ErrorIs(t, err, ErrMissingCredentials)
+
+	t.Setenv("CF_API_KEY", "deadbeef")
+	t.Setenv("CLOUDFLARE_EMAIL", "cloudflare@example.org")
+	creds, err := provider.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{APIKey: "deadbeef", APIEmail: "cloudflare@example.org"}, creds)
+}
+
+func TestFileCredentials(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+	require.NoError(t, os.WriteFile(path, []byte("first-token\n"), 0600))
+
+	provider := NewFileCredentials(path)
+	creds, err := provider.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{APIToken: "first-token"}, creds)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"api_key": "deadbeef", "api_email": "cloudflare@example.org"}`), 0600))
+	creds, err = provider.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{APIKey: "deadbeef", APIEmail: "cloudflare@example.org"}, creds)
+
+	require.NoError(t, os.Remove(path))
+	_, err = provider.Retrieve(context.Background())
+	assert.Error(t, err)
+}
+
+func TestSTSCredentials_RefreshesBeforeExpiry(t *testing.T) {
+	var issued int32
+	var expiresIn time.Duration
+	sts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer tagsecret", r.Header.Get("Authorization"))
+
+		n := atomic.AddInt32(&issued, 1)
+		claims := fmt.Sprintf(`{"exp": %d}`, time.Now().Add(expiresIn).Unix())
+		token := "e30." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + fmt.Sprintf(".sig%d", n)
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"json_web_token": %q}}`, token)
+	}))
+	defer sts.Close()
+
+	issuer, _ := url.Parse(sts.URL)
+	provider := NewSTSCredentials(&SecurityTokenConfiguration{
+		Issuer:     &IssuerConfiguration{Hostname: issuer.Host, Path: "/token"},
+		ServiceTag: "tag",
+		Secret:     "secret",
+	}).(*stsCredentials)
+	provider.httpClient = sts.Client()
+
+	// a token that expires within the refresh window is replaced every time.
+	expiresIn = 30 * time.Second
+	first, err := provider.Retrieve(context.Background())
+	require.NoError(t, err)
+	second, err := provider.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.NotEqual(t, first.APIToken, second.APIToken)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&issued))
+
+	expiresIn = time.Hour
+	provider.Invalidate()
+	third, err := provider.Retrieve(context.Background())
+	require.NoError(t, err)
+	cached, err := provider.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, third, cached)
+	assert.True(t, strings.HasSuffix(cached.APIToken, ".sig3"))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&issued))
+}
+
+func TestSecurityTokenExpiry(t *testing.T) {
+	claims := base64.RawURLEncoding.EncodeToString([]byte(`{"exp": 1700000000}`))
+	assert.Equal(t, time.Unix(1700000000, 0), securityTokenExpiry("e30."+claims+".sig"))
+
+	assert.WithinDuration(t, time.Now().Add(stsDefaultTTL), securityTokenExpiry("opaque"), time.Second)
+}
+
+func TestCredentialsProvider_ReauthenticatesOnUnauthorized(t *testing.T) {
+	setup()
+	defer teardown()
+
+	provider := &rotatingCredentials{}
+	client.credentials = provider
+
+	var requests int32
+	mux.HandleFunc("/user/tokens/verify", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("content-type", "application/json")
+		if r.Header.Get("Authorization") != "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"success": false, "errors": [{"code": 10000, "message": "Authentication error"}], "messages": [], "result": null}`)
+			return
+		}
+		assert.Empty(t, r.Header.Get("X-Auth-Key"))
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "ed17574386854bf78a67040be0a770b0", "status": "active"}}`)
+	})
+
+	res, err := client.VerifyAPIToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "active", res.Status)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+
+	// only a single attempt is made with fresh credentials.
+	atomic.StoreInt32(&requests, 0)
+	provider.Invalidate()
+	_, err = client.VerifyAPIToken(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestCredentialsProvider_ExperimentalClient(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/zones/"+testZoneID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		if r.Header.Get("Authorization") != "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"success": false, "errors": [{"code": 10000, "message": "Authentication error"}], "messages": [], "result": null}`)
+			return
+		}
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"id": %q, "name": "example.com"}}`, testZoneID)
+	})
+
+	baseURL, _ := url.Parse(server.URL)
+	c, err := NewExperimental(&ClientParams{
+		BaseURL:     baseURL,
+		Credentials: &rotatingCredentials{},
+		RetryPolicy: noRetries{},
+	})
+	require.NoError(t, err)
+
+	zone, err := c.Zones.Get(context.Background(), ZoneIdentifier(testZoneID))
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", zone.Name)
+}
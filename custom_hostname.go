@@ -240,6 +240,16 @@ func (api *API) CustomHostnames(ctx context.Context, zoneID string, page int, fi
 	return customHostnameListResponse.Result, customHostnameListResponse.ResultInfo, nil
 }
 
+// CustomHostnamesIterator returns an Iterator over all custom hostnames for
+// the given zone, by applying filter.Hostname if not empty.
+//
+// API reference: https://api.cloudflare.com/#custom-hostname-for-a-zone-list-custom-hostnames
+func (api *API) CustomHostnamesIterator(ctx context.Context, zoneID string, filter CustomHostname) *Iterator[CustomHostname] {
+	return newIterator(ctx, ResultInfo{Page: 1}, func(ctx context.Context, info ResultInfo) ([]CustomHostname, ResultInfo, error) {
+		return api.CustomHostnames(ctx, zoneID, info.Page, filter)
+	})
+}
+
 // CustomHostname inspects the given custom hostname in the given zone.
 //
 // API reference: https://api.cloudflare.com/#custom-hostname-for-a-zone-custom-hostname-configuration-details
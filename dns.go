@@ -3,10 +3,14 @@ package cloudflare
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/net/idna"
@@ -20,7 +24,7 @@ type DNSRecord struct {
 	Name       string      `json:"name,omitempty"`
 	Content    string      `json:"content,omitempty"`
 	Meta       interface{} `json:"meta,omitempty"`
-	Data       interface{} `json:"data,omitempty"` // data returned by: SRV, LOC
+	Data       interface{} `json:"data,omitempty"` // data of SRV, LOC, CAA and similar records, see DNSRecordData
 	ID         string      `json:"id,omitempty"`
 	ZoneID     string      `json:"zone_id,omitempty"`
 	ZoneName   string      `json:"zone_name,omitempty"`
@@ -29,6 +33,70 @@ type DNSRecord struct {
 	Proxied    *bool       `json:"proxied,omitempty"`
 	Proxiable  bool        `json:"proxiable,omitempty"`
 	Locked     bool        `json:"locked,omitempty"`
+	Comment    string      `json:"comment,omitempty"`
+	Tags       []string    `json:"tags,omitempty"`
+}
+
+// Validate checks the record on its own for mistakes the API would reject:
+//
+//   - fields every record requires and TTLs out of range
+//   - proxied records of a type that can't be proxied or with a TTL other
+//     than 1 (automatic)
+//   - A and AAAA records whose content isn't an IPv4 or IPv6 address
+//   - MX records without a priority
+//
+// ValidateDNSRecords also checks the record against its zone and other
+// records.
+func (rr DNSRecord) Validate() error {
+	var errs fieldErrors
+
+	// The type of typed data is filled in when the record is marshalled.
+	typ := strings.ToUpper(rr.Type)
+	if d, ok := typedDNSRecordData(rr.Data); ok && typ == "" {
+		typ = d.DNSRecordType()
+	}
+	if typ == "" {
+		errs.add("Type", "is required")
+	}
+
+	if rr.Name == "" {
+		errs.add("Name", "is required")
+	}
+
+	// SRV, LOC, CAA and similar records are created from Data instead.
+	if rr.Content == "" && rr.Data == nil {
+		errs.add("Content", "is required unless Data is set")
+	}
+
+	if rr.TTL != 0 && rr.TTL != 1 && (rr.TTL < 30 || rr.TTL > 86400) {
+		errs.add("TTL", "must be 1 (automatic) or between 30 and 86400, got %d", rr.TTL)
+	}
+
+	if rr.Proxied != nil && *rr.Proxied {
+		if !proxiableDNSRecordTypes[typ] {
+			errs.add("Proxied", "%s records can't be proxied", typ)
+		}
+		if rr.TTL != 0 && rr.TTL != 1 {
+			errs.add("TTL", "must be 1 (automatic) for proxied records, got %d", rr.TTL)
+		}
+	}
+
+	switch typ {
+	case "A":
+		if ip := net.ParseIP(rr.Content); ip == nil || ip.To4() == nil {
+			errs.add("Content", "%q isn't an IPv4 address", rr.Content)
+		}
+	case "AAAA":
+		if ip := net.ParseIP(rr.Content); ip == nil || ip.To4() != nil {
+			errs.add("Content", "%q isn't an IPv6 address", rr.Content)
+		}
+	case "MX":
+		if rr.Priority == nil {
+			errs.add("Priority", "is required for MX records")
+		}
+	}
+
+	return errs.err("DNSRecord")
 }
 
 // DNSRecordResponse represents the response from the DNS endpoint.
@@ -64,12 +132,24 @@ func toUTS46ASCII(name string) string {
 	return name
 }
 
+// normalizeDNSName returns the ASCII form of a domain name in lower case and
+// without a trailing dot, so that names can be compared.
+func normalizeDNSName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(toUTS46ASCII(name), "."))
+}
+
 // CreateDNSRecord creates a DNS record for the zone identifier.
 //
 // API reference: https://api.cloudflare.com/#dns-records-for-a-zone-create-dns-record
 func (api *API) CreateDNSRecord(ctx context.Context, zoneID string, rr DNSRecord) (*DNSRecordResponse, error) {
 	rr.Name = toUTS46ASCII(rr.Name)
 
+	if api.dnsPreflight {
+		if err := api.preflightDNSRecord(ctx, zoneID, "", rr); err != nil {
+			return nil, err
+		}
+	}
+
 	uri := fmt.Sprintf("/zones/%s/dns_records", zoneID)
 	res, err := api.makeRequestContext(ctx, http.MethodPost, uri, rr)
 	if err != nil {
@@ -87,10 +167,26 @@ func (api *API) CreateDNSRecord(ctx context.Context, zoneID string, rr DNSRecord
 
 // DNSRecords returns a slice of DNS records for the given zone identifier.
 //
-// This takes a DNSRecord to allow filtering of the results returned.
+// This takes a DNSRecord to allow filtering of the results returned. Use
+// ListDNSRecords for more filters and to list large zones faster.
 //
 // API reference: https://api.cloudflare.com/#dns-records-for-a-zone-list-dns-records
 func (api *API) DNSRecords(ctx context.Context, zoneID string, rr DNSRecord) ([]DNSRecord, error) {
+	records, err := api.DNSRecordsIterator(ctx, zoneID, rr).All()
+	if err != nil {
+		return []DNSRecord{}, err
+	}
+
+	return records, nil
+}
+
+// DNSRecordsIterator returns an Iterator over the DNS records for the given
+// zone identifier, fetching each page of results as it is needed.
+//
+// This takes a DNSRecord to allow filtering of the results returned.
+//
+// API reference: https://api.cloudflare.com/#dns-records-for-a-zone-list-dns-records
+func (api *API) DNSRecordsIterator(ctx context.Context, zoneID string, rr DNSRecord) *Iterator[DNSRecord] {
 	// Construct a query string
 	v := url.Values{}
 	// Using default per_page value as specified by the API
@@ -104,30 +200,224 @@ func (api *API) DNSRecords(ctx context.Context, zoneID string, rr DNSRecord) ([]
 		v.Set("content", rr.Content)
 	}
 
-	var records []DNSRecord
-	page := 1
-
-	// Loop over makeRequest until what we've fetched all records
-	for {
-		v.Set("page", strconv.Itoa(page))
+	return newIterator(ctx, ResultInfo{Page: 1}, func(ctx context.Context, info ResultInfo) ([]DNSRecord, ResultInfo, error) {
+		v.Set("page", strconv.Itoa(info.Page))
 		uri := fmt.Sprintf("/zones/%s/dns_records?%s", zoneID, v.Encode())
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return []DNSRecord{}, err
+			return nil, ResultInfo{}, err
 		}
 		var r DNSListResponse
 		err = json.Unmarshal(res, &r)
 		if err != nil {
-			return []DNSRecord{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+			return nil, ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
 		}
-		records = append(records, r.Result...)
-		if r.ResultInfo.Page >= r.ResultInfo.TotalPages {
-			break
+		return r.Result, r.ResultInfo, nil
+	})
+}
+
+// DNSListParams are the filters, ordering and pagination of ListDNSRecords.
+type DNSListParams struct {
+	Type    string `url:"type,omitempty"`
+	Name    string `url:"name,omitempty"`
+	Content string `url:"content,omitempty"`
+	Proxied *bool  `url:"proxied,omitempty"`
+	Comment string `url:"comment,omitempty"`
+
+	// NameStartsWith, NameEndsWith and NameContains search for part of the
+	// record names.
+	NameStartsWith string `url:"name.startswith,omitempty"`
+	NameEndsWith   string `url:"name.endswith,omitempty"`
+	NameContains   string `url:"name.contains,omitempty"`
+
+	// Tags filters on tags, either a tag name or "name:value".
+	Tags []string `url:"tag,omitempty"`
+
+	// TagMatch is "any" or "all" (the default) of Tags.
+	TagMatch string `url:"tag_match,omitempty"`
+
+	// Match is "any" or "all" (the default) of the filters.
+	Match string `url:"match,omitempty"`
+
+	// Order is the field to sort by: "type", "name", "content", "ttl" or
+	// "proxied".
+	Order string `url:"order,omitempty"`
+
+	// Direction is "asc" or "desc".
+	Direction string `url:"direction,omitempty"`
+
+	// Concurrency is the number of pages fetched at once when paginating
+	// automatically. Defaults to 8.
+	Concurrency int `url:"-"`
+
+	ResultInfo
+}
+
+const (
+	listDNSRecordsPerPage     = 100
+	listDNSRecordsConcurrency = 8
+)
+
+// ListDNSRecords returns the DNS records of a zone matching the filters of
+// `params`.
+//
+// Every page is fetched unless params.Page is set. The first page is fetched
+// to learn the number of pages, after which the remaining pages are fetched
+// concurrently, which is much faster than DNSRecords for large zones.
+//
+// API reference: https://api.cloudflare.com/#dns-records-for-a-zone-list-dns-records
+func (api *API) ListDNSRecords(ctx context.Context, rc *ResourceContainer, params DNSListParams) ([]DNSRecord, *ResultInfo, error) {
+	if rc.Identifier == "" {
+		return []DNSRecord{}, &ResultInfo{}, ErrMissingZoneID
+	}
+
+	// only complete names can be converted, the ASCII form of a fragment
+	// isn't part of the ASCII form of the names containing it.
+	if params.Name != "" {
+		params.Name = toUTS46ASCII(params.Name)
+	}
+
+	autoPaginate := params.Page < 1
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PerPage < 1 {
+		params.PerPage = listDNSRecordsPerPage
+	}
+
+	uri := fmt.Sprintf("/zones/%s/dns_records", rc.Identifier)
+	res, err := api.makeRequestContext(ctx, http.MethodGet, buildURI(uri, params), nil)
+	if err != nil {
+		return []DNSRecord{}, &ResultInfo{}, err
+	}
+
+	var r DNSListResponse
+	err = json.Unmarshal(res, &r)
+	if err != nil {
+		return []DNSRecord{}, &ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+
+	if !autoPaginate || r.TotalPages < 2 {
+		return r.Result, &r.ResultInfo, nil
+	}
+
+	// the API may use a smaller page size than the one requested so the
+	// offsets of the pages are worked out from the one it used.
+	perPage := r.PerPage
+	if perPage < 1 || len(r.Result) != perPage || r.Total <= perPage*(r.TotalPages-1) {
+		return []DNSRecord{}, &ResultInfo{}, errors.New(errResultInfo)
+	}
+	params.PerPage = perPage
+
+	// records is allocated up front so pages can be copied in concurrently.
+	records := make([]DNSRecord, r.Total)
+	copy(records, r.Result)
+
+	concurrency := params.Concurrency
+	if concurrency < 1 {
+		concurrency = listDNSRecordsConcurrency
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errc := make(chan error, 1) // getting the first error
+	sem := make(chan struct{}, concurrency)
+
+	for page := 2; page <= r.TotalPages; page++ {
+		params.Page = page
+
+		start := perPage * (page - 1)
+		pageSize := perPage
+		if page == r.TotalPages {
+			pageSize = r.Total - start
 		}
-		// Loop around and fetch the next page
-		page++
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go api.listDNSRecordsFetch(ctx, &wg, sem, errc, cancel, buildURI(uri, params), pageSize, records[start:])
 	}
-	return records, nil
+
+	wg.Wait()
+
+	select {
+	case err := <-errc:
+		return []DNSRecord{}, &ResultInfo{}, err
+	default:
+		info := r.ResultInfo
+		info.Page = 1
+		info.Count = len(records)
+		return records, &info, nil
+	}
+}
+
+// ListDNSRecordsIterator returns an Iterator over the DNS records of a zone
+// matching the filters of `params`. Unlike ListDNSRecords, pages are fetched
+// one at a time as they are needed so `params.Concurrency` is unused.
+// `params.Page` and `params.PerPage` control where iteration starts and the
+// size of each page.
+//
+// API reference: https://api.cloudflare.com/#dns-records-for-a-zone-list-dns-records
+func (api *API) ListDNSRecordsIterator(ctx context.Context, rc *ResourceContainer, params DNSListParams) *Iterator[DNSRecord] {
+	start := ResultInfo{Page: params.Page, PerPage: params.PerPage}
+	if start.PerPage < 1 {
+		start.PerPage = listDNSRecordsPerPage
+	}
+	if start.Page < 1 {
+		start.Page = 1
+	}
+
+	return newIterator(ctx, start, func(ctx context.Context, info ResultInfo) ([]DNSRecord, ResultInfo, error) {
+		params.ResultInfo = info
+
+		items, resultInfo, err := api.ListDNSRecords(ctx, rc, params)
+		if err != nil {
+			return nil, ResultInfo{}, err
+		}
+
+		return items, *resultInfo, nil
+	})
+}
+
+// listDNSRecordsFetch fetches a page of DNS records into `buf`, releasing its
+// slot of `sem` when done. The first error is sent to `errc` and cancels the
+// other fetches.
+func (api *API) listDNSRecordsFetch(ctx context.Context, wg *sync.WaitGroup, sem chan struct{}, errc chan error,
+	cancel context.CancelFunc, uri string, pageSize int, buf []DNSRecord) {
+	defer func() {
+		<-sem
+		wg.Done()
+	}()
+
+	// recordError sends the error to errc in a non-blocking manner
+	recordError := func(err error) {
+		select {
+		case errc <- err:
+			cancel()
+		default:
+		}
+	}
+
+	res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		recordError(err)
+		return
+	}
+
+	var r DNSListResponse
+	err = json.Unmarshal(res, &r)
+	if err != nil {
+		recordError(fmt.Errorf("%s: %w", errUnmarshalError, err))
+		return
+	}
+
+	if len(r.Result) != pageSize {
+		recordError(errors.New(errResultInfo))
+		return
+	}
+
+	copy(buf, r.Result)
 }
 
 // DNSRecord returns a single DNS record for the given zone & record
@@ -155,6 +445,12 @@ func (api *API) DNSRecord(ctx context.Context, zoneID, recordID string) (DNSReco
 func (api *API) UpdateDNSRecord(ctx context.Context, zoneID, recordID string, rr DNSRecord) error {
 	rr.Name = toUTS46ASCII(rr.Name)
 
+	if api.dnsPreflight {
+		if err := api.preflightDNSRecord(ctx, zoneID, recordID, rr); err != nil {
+			return err
+		}
+	}
+
 	// Populate the record name from the existing one if the update didn't
 	// specify it.
 	if rr.Name == "" || rr.Type == "" {
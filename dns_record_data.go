@@ -0,0 +1,510 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// DNSRecordData is the typed form of DNSRecord.Data for record types that
+// are created from structured data rather than content.
+//
+// A DNSRecordData may be set as the Data of a DNSRecord; the record type is
+// then filled in from the data when it isn't set. Records returned by the API
+// keep their data as a map[string]interface{}, use DNSRecord.DecodeData to
+// get the typed form.
+type DNSRecordData interface {
+	// DNSRecordType returns the record type of the data, e.g. "SRV".
+	DNSRecordType() string
+
+	// Content returns the presentation format of the data, as found in a
+	// zone file.
+	Content() string
+}
+
+// SRVRecordData is the data of a SRV record.
+type SRVRecordData struct {
+	Service  string `json:"service,omitempty"`
+	Proto    string `json:"proto,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Priority uint16 `json:"priority"`
+	Weight   uint16 `json:"weight"`
+	Port     uint16 `json:"port"`
+	Target   string `json:"target"`
+}
+
+// CAARecordData is the data of a CAA record.
+type CAARecordData struct {
+	Flags uint8  `json:"flags"`
+	Tag   string `json:"tag"`
+	Value string `json:"value"`
+}
+
+// LOCRecordData is the data of a LOC record. Distances are in meters.
+type LOCRecordData struct {
+	LatDegrees    uint8   `json:"lat_degrees"`
+	LatMinutes    uint8   `json:"lat_minutes"`
+	LatSeconds    float64 `json:"lat_seconds"`
+	LatDirection  string  `json:"lat_direction"`
+	LongDegrees   uint8   `json:"long_degrees"`
+	LongMinutes   uint8   `json:"long_minutes"`
+	LongSeconds   float64 `json:"long_seconds"`
+	LongDirection string  `json:"long_direction"`
+	Altitude      float64 `json:"altitude"`
+	Size          float64 `json:"size"`
+	PrecisionHorz float64 `json:"precision_horz"`
+	PrecisionVert float64 `json:"precision_vert"`
+}
+
+// TLSARecordData is the data of a TLSA record.
+type TLSARecordData struct {
+	Usage        uint8  `json:"usage"`
+	Selector     uint8  `json:"selector"`
+	MatchingType uint8  `json:"matching_type"`
+	Certificate  string `json:"certificate"`
+}
+
+// SSHFPRecordData is the data of a SSHFP record.
+type SSHFPRecordData struct {
+	Algorithm   uint8  `json:"algorithm"`
+	Type        uint8  `json:"type"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// DSRecordData is the data of a DS record.
+type DSRecordData struct {
+	KeyTag     uint16 `json:"key_tag"`
+	Algorithm  uint8  `json:"algorithm"`
+	DigestType uint8  `json:"digest_type"`
+	Digest     string `json:"digest"`
+}
+
+// DNSKEYRecordData is the data of a DNSKEY record.
+type DNSKEYRecordData struct {
+	Flags     uint16 `json:"flags"`
+	Protocol  uint8  `json:"protocol"`
+	Algorithm uint8  `json:"algorithm"`
+	PublicKey string `json:"public_key"`
+}
+
+// NAPTRRecordData is the data of a NAPTR record.
+type NAPTRRecordData struct {
+	Order       uint16 `json:"order"`
+	Preference  uint16 `json:"preference"`
+	Flags       string `json:"flags"`
+	Service     string `json:"service"`
+	Regex       string `json:"regex"`
+	Replacement string `json:"replacement"`
+}
+
+// SVCBRecordData is the data of a SVCB record. Value holds the service
+// parameters in presentation format, e.g. `alpn="h2,h3" port=8443`.
+type SVCBRecordData struct {
+	Priority uint16 `json:"priority"`
+	Target   string `json:"target"`
+	Value    string `json:"value"`
+}
+
+// HTTPSRecordData is the data of a HTTPS record.
+type HTTPSRecordData SVCBRecordData
+
+// URIRecordData is the data of a URI record. The API takes the priority of
+// URI records in DNSRecord.Priority, which is set from the data when a
+// record is marshalled.
+type URIRecordData struct {
+	Priority uint16 `json:"-"`
+	Weight   uint16 `json:"weight"`
+	Target   string `json:"target"`
+}
+
+func (SRVRecordData) DNSRecordType() string    { return "SRV" }
+func (CAARecordData) DNSRecordType() string    { return "CAA" }
+func (LOCRecordData) DNSRecordType() string    { return "LOC" }
+func (TLSARecordData) DNSRecordType() string   { return "TLSA" }
+func (SSHFPRecordData) DNSRecordType() string  { return "SSHFP" }
+func (DSRecordData) DNSRecordType() string     { return "DS" }
+func (DNSKEYRecordData) DNSRecordType() string { return "DNSKEY" }
+func (NAPTRRecordData) DNSRecordType() string  { return "NAPTR" }
+func (SVCBRecordData) DNSRecordType() string   { return "SVCB" }
+func (HTTPSRecordData) DNSRecordType() string  { return "HTTPS" }
+func (URIRecordData) DNSRecordType() string    { return "URI" }
+
+func (d SRVRecordData) Content() string {
+	return fmt.Sprintf("%d %d %d %s", d.Priority, d.Weight, d.Port, d.Target)
+}
+
+func (d CAARecordData) Content() string {
+	return fmt.Sprintf("%d %s %s", d.Flags, d.Tag, quoteZoneFileString(d.Value))
+}
+
+func (d LOCRecordData) Content() string {
+	return fmt.Sprintf("%d %d %s %s %d %d %s %s %sm %sm %sm %sm",
+		d.LatDegrees, d.LatMinutes, formatLOCFloat(d.LatSeconds), d.LatDirection,
+		d.LongDegrees, d.LongMinutes, formatLOCFloat(d.LongSeconds), d.LongDirection,
+		formatLOCFloat(d.Altitude), formatLOCFloat(d.Size), formatLOCFloat(d.PrecisionHorz), formatLOCFloat(d.PrecisionVert))
+}
+
+func (d TLSARecordData) Content() string {
+	return fmt.Sprintf("%d %d %d %s", d.Usage, d.Selector, d.MatchingType, d.Certificate)
+}
+
+func (d SSHFPRecordData) Content() string {
+	return fmt.Sprintf("%d %d %s", d.Algorithm, d.Type, d.Fingerprint)
+}
+
+func (d DSRecordData) Content() string {
+	return fmt.Sprintf("%d %d %d %s", d.KeyTag, d.Algorithm, d.DigestType, d.Digest)
+}
+
+func (d DNSKEYRecordData) Content() string {
+	return fmt.Sprintf("%d %d %d %s", d.Flags, d.Protocol, d.Algorithm, d.PublicKey)
+}
+
+func (d NAPTRRecordData) Content() string {
+	return fmt.Sprintf("%d %d %s %s %s %s", d.Order, d.Preference,
+		quoteZoneFileString(d.Flags), quoteZoneFileString(d.Service), quoteZoneFileString(d.Regex), d.Replacement)
+}
+
+func (d SVCBRecordData) Content() string {
+	return strings.TrimSuffix(fmt.Sprintf("%d %s %s", d.Priority, d.Target, d.Value), " ")
+}
+
+func (d HTTPSRecordData) Content() string {
+	return SVCBRecordData(d).Content()
+}
+
+func (d URIRecordData) Content() string {
+	return fmt.Sprintf("%d %d %s", d.Priority, d.Weight, quoteZoneFileString(d.Target))
+}
+
+// ParseDNSRecordData parses the presentation format of the data of a record
+// of type `rrType`, e.g. `10 5 5060 sip.example.com` for a SRV record. It is
+// the inverse of DNSRecordData.Content.
+func ParseDNSRecordData(rrType, content string) (DNSRecordData, error) {
+	depth := 0
+	tokens, err := splitZoneFileLine(content, nil, &depth)
+	if err != nil {
+		return nil, err
+	}
+
+	r := rdataReader{tokens: tokens}
+	data, err := parseDNSRecordData(strings.ToUpper(rrType), &r, func(name string) (string, error) {
+		return strings.TrimSuffix(name, "."), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s record: %w", strings.ToUpper(rrType), err)
+	}
+
+	if r.remaining() > 0 {
+		return nil, fmt.Errorf("%s record: unexpected data %q", strings.ToUpper(rrType), r.peek())
+	}
+
+	return data, nil
+}
+
+// parseDNSRecordData parses record data from presentation format tokens.
+// Domain names are passed through `name`.
+func parseDNSRecordData(rrType string, r *rdataReader, name func(string) (string, error)) (DNSRecordData, error) {
+	var data DNSRecordData
+	var err error
+
+	switch rrType {
+	case "SRV":
+		d := SRVRecordData{
+			Priority: uint16(r.uint("priority", 16)),
+			Weight:   uint16(r.uint("weight", 16)),
+			Port:     uint16(r.uint("port", 16)),
+		}
+		d.Target, err = name(r.next())
+		data = d
+
+	case "CAA":
+		data = CAARecordData{
+			Flags: uint8(r.uint("flags", 8)),
+			Tag:   r.next(),
+			Value: r.next(),
+		}
+
+	case "LOC":
+		data, err = parseLOCRecordData(r)
+
+	case "TLSA":
+		data = TLSARecordData{
+			Usage:        uint8(r.uint("usage", 8)),
+			Selector:     uint8(r.uint("selector", 8)),
+			MatchingType: uint8(r.uint("matching type", 8)),
+			Certificate:  r.join(""),
+		}
+
+	case "SSHFP":
+		data = SSHFPRecordData{
+			Algorithm:   uint8(r.uint("algorithm", 8)),
+			Type:        uint8(r.uint("type", 8)),
+			Fingerprint: r.join(""),
+		}
+
+	case "DS":
+		data = DSRecordData{
+			KeyTag:     uint16(r.uint("key tag", 16)),
+			Algorithm:  uint8(r.uint("algorithm", 8)),
+			DigestType: uint8(r.uint("digest type", 8)),
+			Digest:     r.join(""),
+		}
+
+	case "DNSKEY":
+		data = DNSKEYRecordData{
+			Flags:     uint16(r.uint("flags", 16)),
+			Protocol:  uint8(r.uint("protocol", 8)),
+			Algorithm: uint8(r.uint("algorithm", 8)),
+			PublicKey: r.join(""),
+		}
+
+	case "NAPTR":
+		d := NAPTRRecordData{
+			Order:      uint16(r.uint("order", 16)),
+			Preference: uint16(r.uint("preference", 16)),
+			Flags:      r.next(),
+			Service:    r.next(),
+			Regex:      r.next(),
+		}
+		if d.Replacement = r.next(); d.Replacement != "." {
+			d.Replacement, err = name(d.Replacement)
+		}
+		data = d
+
+	case "SVCB", "HTTPS":
+		d := SVCBRecordData{Priority: uint16(r.uint("priority", 16))}
+		if d.Target = r.next(); d.Target != "." {
+			d.Target, err = name(d.Target)
+		}
+		d.Value = r.rest()
+		data = d
+		if rrType == "HTTPS" {
+			data = HTTPSRecordData(d)
+		}
+
+	case "URI":
+		data = URIRecordData{
+			Priority: uint16(r.uint("priority", 16)),
+			Weight:   uint16(r.uint("weight", 16)),
+			Target:   r.next(),
+		}
+
+	default:
+		return nil, errors.New("unsupported record type")
+	}
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return data, err
+}
+
+// parseLOCRecordData parses the RFC 1876 presentation format of a LOC
+// record, in which the minutes, seconds, size and precisions are optional.
+func parseLOCRecordData(r *rdataReader) (LOCRecordData, error) {
+	var d LOCRecordData
+
+	for _, axis := range []struct {
+		name       string
+		directions string
+		maxDegrees uint64
+		degrees    *uint8
+		minutes    *uint8
+		seconds    *float64
+		direction  *string
+	}{
+		{"lat", "NS", 90, &d.LatDegrees, &d.LatMinutes, &d.LatSeconds, &d.LatDirection},
+		{"long", "EW", 180, &d.LongDegrees, &d.LongMinutes, &d.LongSeconds, &d.LongDirection},
+	} {
+		degrees := r.uint(axis.name+" degrees", 8)
+		if degrees > axis.maxDegrees {
+			return d, fmt.Errorf("%s degrees must be at most %d", axis.name, axis.maxDegrees)
+		}
+		*axis.degrees = uint8(degrees)
+
+		isDirection := func(s string) bool {
+			return len(s) == 1 && strings.Contains(axis.directions, strings.ToUpper(s))
+		}
+		if r.remaining() > 0 && !isDirection(r.peek()) {
+			*axis.minutes = uint8(r.uint(axis.name+" minutes", 8))
+			if r.remaining() > 0 && !isDirection(r.peek()) {
+				*axis.seconds = r.float(axis.name + " seconds")
+			}
+		}
+
+		direction := r.next()
+		if r.err == nil && !isDirection(direction) {
+			return d, fmt.Errorf("invalid %s direction %q", axis.name, direction)
+		}
+		*axis.direction = strings.ToUpper(direction)
+	}
+
+	d.Altitude = r.meters("altitude", 0)
+	d.Size = r.meters("size", 1)
+	d.PrecisionHorz = r.meters("horizontal precision", 10000)
+	d.PrecisionVert = r.meters("vertical precision", 10)
+
+	return d, r.err
+}
+
+func formatLOCFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// quoteZoneFileString returns `s` as a quoted zone file string.
+func quoteZoneFileString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// DecodeData returns the typed form of the record's data, e.g. a
+// SRVRecordData for a SRV record. Data is decoded when it is set, as it is
+// for records returned by the API, otherwise Content is parsed as
+// presentation format.
+func (rr DNSRecord) DecodeData() (DNSRecordData, error) {
+	if d, ok := typedDNSRecordData(rr.Data); ok {
+		return d, nil
+	}
+
+	if len(recordDataMap(rr.Data)) == 0 {
+		if rr.Content == "" {
+			return nil, fmt.Errorf("%s record has no data", rr.Type)
+		}
+		return ParseDNSRecordData(rr.Type, rr.Content)
+	}
+
+	raw, err := json.Marshal(rr.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	var data DNSRecordData
+	switch strings.ToUpper(rr.Type) {
+	case "SRV":
+		data, err = decodeDNSRecordData[SRVRecordData](raw)
+	case "CAA":
+		data, err = decodeDNSRecordData[CAARecordData](raw)
+	case "LOC":
+		data, err = decodeDNSRecordData[LOCRecordData](raw)
+	case "TLSA":
+		data, err = decodeDNSRecordData[TLSARecordData](raw)
+	case "SSHFP":
+		data, err = decodeDNSRecordData[SSHFPRecordData](raw)
+	case "DS":
+		data, err = decodeDNSRecordData[DSRecordData](raw)
+	case "DNSKEY":
+		data, err = decodeDNSRecordData[DNSKEYRecordData](raw)
+	case "NAPTR":
+		data, err = decodeDNSRecordData[NAPTRRecordData](raw)
+	case "SVCB":
+		data, err = decodeDNSRecordData[SVCBRecordData](raw)
+	case "HTTPS":
+		data, err = decodeDNSRecordData[HTTPSRecordData](raw)
+	case "URI":
+		var d URIRecordData
+		d, err = decodeDNSRecordData[URIRecordData](raw)
+		if rr.Priority != nil {
+			d.Priority = *rr.Priority
+		}
+		data = d
+	default:
+		return nil, fmt.Errorf("%s record: unsupported record type", rr.Type)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+
+	return data, nil
+}
+
+// typedDNSRecordData returns `data` when it is a DNSRecordData or a non-nil
+// pointer to one.
+func typedDNSRecordData(data interface{}) (DNSRecordData, bool) {
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+
+	if !v.IsValid() {
+		return nil, false
+	}
+
+	d, ok := v.Interface().(DNSRecordData)
+	return d, ok
+}
+
+func decodeDNSRecordData[T DNSRecordData](raw []byte) (T, error) {
+	var d T
+	err := json.Unmarshal(raw, &d)
+	return d, err
+}
+
+// DNSRecordMeta is the typed form of DNSRecord.Meta.
+type DNSRecordMeta struct {
+	AutoAdded           bool   `json:"auto_added"`
+	ManagedByApps       bool   `json:"managed_by_apps"`
+	ManagedByArgoTunnel bool   `json:"managed_by_argo_tunnel"`
+	Source              string `json:"source"`
+}
+
+// DecodeMeta returns the typed form of the record's metadata.
+func (rr DNSRecord) DecodeMeta() (DNSRecordMeta, error) {
+	var meta DNSRecordMeta
+	if rr.Meta == nil {
+		return meta, nil
+	}
+
+	raw, err := json.Marshal(rr.Meta)
+	if err != nil {
+		return meta, err
+	}
+
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return meta, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+
+	return meta, nil
+}
+
+// MarshalJSON fills in the record type and, for URI records, the priority
+// from typed data before marshalling the record.
+func (rr DNSRecord) MarshalJSON() ([]byte, error) {
+	type dnsRecord DNSRecord
+
+	if d, ok := typedDNSRecordData(rr.Data); ok {
+		if rr.Type == "" {
+			rr.Type = d.DNSRecordType()
+		}
+
+		if uri, ok := d.(URIRecordData); ok && rr.Priority == nil {
+			rr.Priority = &uri.Priority
+		}
+	}
+
+	return json.Marshal(dnsRecord(rr))
+}
+
+// recordDataMap returns the JSON representation of record data, so that maps
+// and typed data with numbers of any type compare alike. It is nil when the
+// data can't be represented as a JSON object.
+func recordDataMap(data interface{}) map[string]interface{} {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil
+	}
+
+	return m
+}
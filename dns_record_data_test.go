@@ -0,0 +1,203 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNSRecordData_Content(t *testing.T) {
+	tests := []struct {
+		data    DNSRecordData
+		content string
+	}{
+		{SRVRecordData{Priority: 10, Weight: 20, Port: 5060, Target: "sip.example.com"}, "10 20 5060 sip.example.com"},
+		{CAARecordData{Flags: 128, Tag: "issue", Value: "letsencrypt.org"}, `128 issue "letsencrypt.org"`},
+		{LOCRecordData{
+			LatDegrees: 51, LatMinutes: 30, LatSeconds: 12.748, LatDirection: "N",
+			LongDegrees: 0, LongMinutes: 7, LongSeconds: 39.611, LongDirection: "W",
+			Altitude: 10.5, Size: 1, PrecisionHorz: 10000, PrecisionVert: 10,
+		}, "51 30 12.748 N 0 7 39.611 W 10.5m 1m 10000m 10m"},
+		{TLSARecordData{Usage: 3, Selector: 1, MatchingType: 1, Certificate: "0C72AC70"}, "3 1 1 0C72AC70"},
+		{SSHFPRecordData{Algorithm: 4, Type: 2, Fingerprint: "123456789abcdef6"}, "4 2 123456789abcdef6"},
+		{DSRecordData{KeyTag: 2371, Algorithm: 13, DigestType: 2, Digest: "1F987CC6583E"}, "2371 13 2 1F987CC6583E"},
+		{DNSKEYRecordData{Flags: 257, Protocol: 3, Algorithm: 13, PublicKey: "mdsswUyr3DPW"}, "257 3 13 mdsswUyr3DPW"},
+		{NAPTRRecordData{Order: 100, Preference: 10, Flags: "U", Service: "E2U+sip", Regex: `!^.*$!sip:info@example.com!`, Replacement: "."}, `100 10 "U" "E2U+sip" "!^.*$!sip:info@example.com!" .`},
+		{SVCBRecordData{Priority: 1, Target: "svc.example.com", Value: "port=8443"}, "1 svc.example.com port=8443"},
+		{HTTPSRecordData{Priority: 1, Target: ".", Value: `alpn="h2,h3"`}, `1 . alpn="h2,h3"`},
+		{HTTPSRecordData{Priority: 0, Target: "example.net"}, "0 example.net"},
+		{URIRecordData{Priority: 10, Weight: 1, Target: "https://example.com/"}, `10 1 "https://example.com/"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.data.DNSRecordType(), func(t *testing.T) {
+			assert.Equal(t, tc.content, tc.data.Content())
+
+			data, err := ParseDNSRecordData(tc.data.DNSRecordType(), tc.content)
+			require.NoError(t, err)
+			assert.Equal(t, tc.data, data)
+		})
+	}
+}
+
+func TestParseDNSRecordData(t *testing.T) {
+	data, err := ParseDNSRecordData("srv", "10 20 5060 sip.example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, SRVRecordData{Priority: 10, Weight: 20, Port: 5060, Target: "sip.example.com"}, data)
+
+	data, err = ParseDNSRecordData("CAA", `0 issue "ca.example.net; account=230123"`)
+	require.NoError(t, err)
+	assert.Equal(t, CAARecordData{Tag: "issue", Value: "ca.example.net; account=230123"}, data)
+
+	_, err = ParseDNSRecordData("SRV", "10 20 5060")
+	assert.EqualError(t, err, "SRV record: missing data")
+
+	_, err = ParseDNSRecordData("CAA", "256 issue example.net")
+	assert.EqualError(t, err, `CAA record: invalid flags "256"`)
+
+	_, err = ParseDNSRecordData("TLSA", "3 1 1 abc extra")
+	require.NoError(t, err, "certificates may be split across tokens")
+
+	_, err = ParseDNSRecordData("LOC", "91 N 0 W 0m")
+	assert.EqualError(t, err, "LOC record: lat degrees must be at most 90")
+
+	_, err = ParseDNSRecordData("A", "192.0.2.1")
+	assert.EqualError(t, err, "A record: unsupported record type")
+}
+
+func TestDNSRecord_DecodeData(t *testing.T) {
+	var rr DNSRecord
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": "SRV",
+		"name": "_sip._tcp.example.com",
+		"content": "20 5060 sip.example.com",
+		"priority": 10,
+		"meta": {"auto_added": false, "source": "primary"},
+		"data": {
+			"service": "_sip",
+			"proto": "_tcp",
+			"name": "example.com",
+			"priority": 10,
+			"weight": 20,
+			"port": 5060,
+			"target": "sip.example.com"
+		}
+	}`), &rr))
+
+	// Data is still a map so existing type assertions keep working.
+	assert.IsType(t, map[string]interface{}{}, rr.Data)
+
+	data, err := rr.DecodeData()
+	require.NoError(t, err)
+	assert.Equal(t, SRVRecordData{
+		Service:  "_sip",
+		Proto:    "_tcp",
+		Name:     "example.com",
+		Priority: 10,
+		Weight:   20,
+		Port:     5060,
+		Target:   "sip.example.com",
+	}, data)
+
+	priority := uint16(5)
+	data, err = DNSRecord{Type: "URI", Priority: &priority, Data: map[string]interface{}{"weight": 1, "target": "https://example.com/"}}.DecodeData()
+	require.NoError(t, err)
+	assert.Equal(t, URIRecordData{Priority: 5, Weight: 1, Target: "https://example.com/"}, data)
+
+	data, err = DNSRecord{Type: "CAA", Content: `0 issue "letsencrypt.org"`, Data: map[string]interface{}{}}.DecodeData()
+	require.NoError(t, err)
+	assert.Equal(t, CAARecordData{Tag: "issue", Value: "letsencrypt.org"}, data)
+
+	data, err = DNSRecord{Data: &TLSARecordData{Usage: 3}}.DecodeData()
+	require.NoError(t, err)
+	assert.Equal(t, TLSARecordData{Usage: 3}, data)
+
+	_, err = DNSRecord{Type: "A", Content: "192.0.2.1"}.DecodeData()
+	assert.Error(t, err)
+}
+
+func TestDNSRecord_DecodeMeta(t *testing.T) {
+	meta, err := DNSRecord{Meta: map[string]interface{}{"auto_added": true, "source": "primary"}}.DecodeMeta()
+	require.NoError(t, err)
+	assert.Equal(t, DNSRecordMeta{AutoAdded: true, Source: "primary"}, meta)
+
+	meta, err = DNSRecord{}.DecodeMeta()
+	require.NoError(t, err)
+	assert.Equal(t, DNSRecordMeta{}, meta)
+}
+
+func TestDNSRecord_MarshalJSON(t *testing.T) {
+	b, err := json.Marshal(DNSRecord{
+		Name: "example.com",
+		Data: URIRecordData{Priority: 10, Weight: 1, Target: "https://example.com/"},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"created_on": "0001-01-01T00:00:00Z",
+		"modified_on": "0001-01-01T00:00:00Z",
+		"type": "URI",
+		"name": "example.com",
+		"priority": 10,
+		"data": {"weight": 1, "target": "https://example.com/"}
+	}`, string(b))
+
+	// Records without typed data are marshalled as before.
+	priority := uint16(10)
+	b, err = json.Marshal(DNSRecord{Type: "MX", Name: "example.com", Content: "mx.example.com", Priority: &priority})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"created_on": "0001-01-01T00:00:00Z",
+		"modified_on": "0001-01-01T00:00:00Z",
+		"type": "MX",
+		"name": "example.com",
+		"content": "mx.example.com",
+		"priority": 10
+	}`, string(b))
+}
+
+func TestCreateDNSRecord_TypedData(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var rr map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &rr))
+		assert.Equal(t, "CAA", rr["type"])
+		assert.Equal(t, map[string]interface{}{"flags": 0.0, "tag": "issue", "value": "letsencrypt.org"}, rr["data"])
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": {
+				"id": "372e67954025e0ba6aaa6d586b9e0b59",
+				"type": "CAA",
+				"name": "example.com",
+				"content": "0 issue letsencrypt.org",
+				"data": {"flags": 0, "tag": "issue", "value": "letsencrypt.org"}
+			}
+		}`)
+	})
+
+	res, err := client.CreateDNSRecord(context.Background(), testZoneID, DNSRecord{
+		Name: "example.com",
+		Data: CAARecordData{Tag: "issue", Value: "letsencrypt.org"},
+	})
+	require.NoError(t, err)
+
+	data, err := res.Result.DecodeData()
+	require.NoError(t, err)
+	assert.Equal(t, CAARecordData{Tag: "issue", Value: "letsencrypt.org"}, data)
+}
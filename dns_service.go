@@ -0,0 +1,146 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const defaultDNSRecordsPerPage = 100
+
+var ErrMissingDNSRecordID = errors.New("required missing DNS record ID")
+
+type DNSService service
+
+type DNSRecordListParams struct {
+	Type    string `url:"type,omitempty"`
+	Name    string `url:"name,omitempty"`
+	Content string `url:"content,omitempty"`
+	Proxied *bool  `url:"proxied,omitempty"`
+
+	ResultInfo
+}
+
+type DNSRecordCreateParams struct {
+	Type     string      `json:"type"`
+	Name     string      `json:"name"`
+	Content  string      `json:"content,omitempty"`
+	Data     interface{} `json:"data,omitempty"`
+	TTL      int         `json:"ttl,omitempty"`
+	Priority *uint16     `json:"priority,omitempty"`
+	Proxied  *bool       `json:"proxied,omitempty"`
+}
+
+type DNSRecordUpdateParams struct {
+	ID       string      `json:"-"`
+	Type     string      `json:"type,omitempty"`
+	Name     string      `json:"name,omitempty"`
+	Content  string      `json:"content,omitempty"`
+	Data     interface{} `json:"data,omitempty"`
+	TTL      int         `json:"ttl,omitempty"`
+	Priority *uint16     `json:"priority,omitempty"`
+	Proxied  *bool       `json:"proxied,omitempty"`
+}
+
+// List returns the DNS records of a zone that match `params`.
+//
+// Pagination is automatically handled unless `params.Page` is supplied.
+//
+// API reference: https://api.cloudflare.com/#dns-records-for-a-zone-list-dns-records
+func (s *DNSService) List(ctx context.Context, rc *ResourceContainer, params DNSRecordListParams) ([]DNSRecord, *ResultInfo, error) {
+	if err := requireLevel(rc, ZoneRouteLevel); err != nil {
+		return []DNSRecord{}, &ResultInfo{}, err
+	}
+
+	if params.PerPage < 1 {
+		params.PerPage = defaultDNSRecordsPerPage
+	}
+
+	uri := fmt.Sprintf("/zones/%s/dns_records", rc.Identifier)
+	return listAll(ctx, params.ResultInfo, func(ctx context.Context, info ResultInfo) ([]DNSRecord, ResultInfo, error) {
+		params.ResultInfo = info
+		return getPage[DNSRecord](ctx, s.client, buildURI(uri, params))
+	})
+}
+
+// Get fetches a single DNS record.
+//
+// API reference: https://api.cloudflare.com/#dns-records-for-a-zone-dns-record-details
+func (s *DNSService) Get(ctx context.Context, rc *ResourceContainer, recordID string) (DNSRecord, error) {
+	if err := requireLevel(rc, ZoneRouteLevel); err != nil {
+		return DNSRecord{}, err
+	}
+
+	if recordID == "" {
+		return DNSRecord{}, ErrMissingDNSRecordID
+	}
+
+	res, err := s.client.get(ctx, fmt.Sprintf("/zones/%s/dns_records/%s", rc.Identifier, recordID), nil)
+	if err != nil {
+		return DNSRecord{}, err
+	}
+
+	return unmarshalDNSRecord(res)
+}
+
+// Create creates a DNS record.
+//
+// API reference: https://api.cloudflare.com/#dns-records-for-a-zone-create-dns-record
+func (s *DNSService) Create(ctx context.Context, rc *ResourceContainer, params DNSRecordCreateParams) (DNSRecord, error) {
+	if err := requireLevel(rc, ZoneRouteLevel); err != nil {
+		return DNSRecord{}, err
+	}
+
+	res, err := s.client.post(ctx, fmt.Sprintf("/zones/%s/dns_records", rc.Identifier), params)
+	if err != nil {
+		return DNSRecord{}, err
+	}
+
+	return unmarshalDNSRecord(res)
+}
+
+// Update modifies the fields of a DNS record that are set in `params`.
+//
+// API reference: https://api.cloudflare.com/#dns-records-for-a-zone-patch-dns-record
+func (s *DNSService) Update(ctx context.Context, rc *ResourceContainer, params DNSRecordUpdateParams) (DNSRecord, error) {
+	if err := requireLevel(rc, ZoneRouteLevel); err != nil {
+		return DNSRecord{}, err
+	}
+
+	if params.ID == "" {
+		return DNSRecord{}, ErrMissingDNSRecordID
+	}
+
+	res, err := s.client.patch(ctx, fmt.Sprintf("/zones/%s/dns_records/%s", rc.Identifier, params.ID), params)
+	if err != nil {
+		return DNSRecord{}, err
+	}
+
+	return unmarshalDNSRecord(res)
+}
+
+// Delete deletes a DNS record.
+//
+// API reference: https://api.cloudflare.com/#dns-records-for-a-zone-delete-dns-record
+func (s *DNSService) Delete(ctx context.Context, rc *ResourceContainer, recordID string) error {
+	if err := requireLevel(rc, ZoneRouteLevel); err != nil {
+		return err
+	}
+
+	if recordID == "" {
+		return ErrMissingDNSRecordID
+	}
+
+	_, err := s.client.delete(ctx, fmt.Sprintf("/zones/%s/dns_records/%s", rc.Identifier, recordID), nil)
+	return err
+}
+
+func unmarshalDNSRecord(res []byte) (DNSRecord, error) {
+	var r DNSRecordResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return DNSRecord{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+
+	return r.Result, nil
+}
@@ -0,0 +1,87 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNSService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		assert.Equal(t, "A", r.URL.Query().Get("type"))
+		assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{
+			"success": true, "errors": [], "messages": [],
+			"result": [{"id": "record-%s", "type": "A", "name": "www.example.com", "content": "198.51.100.4"}],
+			"result_info": {"page": %s, "per_page": 100, "count": 1, "total_count": 2, "total_pages": 2}
+		}`, page, page)
+	})
+
+	records, info, err := newExperimentalTestClient(t).DNS.List(context.Background(), ZoneIdentifier(testZoneID), DNSRecordListParams{Type: "A"})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "record-1", records[0].ID)
+	assert.Equal(t, "record-2", records[1].ID)
+	assert.Equal(t, 2, info.TotalPages)
+
+	_, _, err = newExperimentalTestClient(t).DNS.List(context.Background(), AccountIdentifier(testAccountID), DNSRecordListParams{})
+	assert.Error(t, err)
+}
+
+func TestDNSService_CreateUpdateDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"type": "A", "name": "www", "content": "198.51.100.4", "ttl": 120}`, string(body))
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "372e67954025e0ba6aaa6d586b9e0b59", "type": "A", "name": "www.example.com", "content": "198.51.100.4", "ttl": 120}}`)
+	})
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records/372e67954025e0ba6aaa6d586b9e0b59", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		switch r.Method {
+		case http.MethodPatch:
+			var params map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&params))
+			assert.Equal(t, map[string]interface{}{"content": "198.51.100.5"}, params)
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "372e67954025e0ba6aaa6d586b9e0b59", "content": "198.51.100.5"}}`)
+		case http.MethodDelete:
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "372e67954025e0ba6aaa6d586b9e0b59"}}`)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	c := newExperimentalTestClient(t)
+	rc := ZoneIdentifier(testZoneID)
+
+	record, err := c.DNS.Create(context.Background(), rc, DNSRecordCreateParams{Type: "A", Name: "www", Content: "198.51.100.4", TTL: 120})
+	require.NoError(t, err)
+	assert.Equal(t, "www.example.com", record.Name)
+
+	record, err = c.DNS.Update(context.Background(), rc, DNSRecordUpdateParams{ID: record.ID, Content: "198.51.100.5"})
+	require.NoError(t, err)
+	assert.Equal(t, "198.51.100.5", record.Content)
+
+	assert.NoError(t, c.DNS.Delete(context.Background(), rc, record.ID))
+	assert.ErrorIs(t, c.DNS.Delete(context.Background(), rc, ""), ErrMissingDNSRecordID)
+}
@@ -0,0 +1,620 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DNSSyncChangeType is the kind of change a DNS sync makes to a record.
+type DNSSyncChangeType string
+
+// The changes a DNS sync makes.
+const (
+	DNSSyncCreate DNSSyncChangeType = "create"
+	DNSSyncUpdate DNSSyncChangeType = "update"
+	DNSSyncDelete DNSSyncChangeType = "delete"
+)
+
+// defaultDNSSyncConcurrency is the number of changes applied at once when
+// ApplyDNSSyncParams.Concurrency isn't set.
+const defaultDNSSyncConcurrency = 4
+
+// defaultDNSSyncRollbackTimeout bounds the rollback of a failed sync when
+// ApplyDNSSyncParams.RollbackTimeout isn't set.
+const defaultDNSSyncRollbackTimeout = time.Minute
+
+// ErrMissingDNSSyncPlan is returned by ApplyDNSSyncPlan when the plan is nil.
+var ErrMissingDNSSyncPlan = errors.New("required missing DNS sync plan")
+
+// DNSRecordOwnership selects the existing records a DNS sync manages. Records
+// that aren't owned are never updated or deleted. The zero value owns every
+// record of the zone.
+type DNSRecordOwnership struct {
+	// Comment, if set, only owns records with this comment. It is set on the
+	// records the sync creates and updates.
+	Comment string
+
+	// Tag, if set, only owns records with this tag, e.g. "managed-by:git".
+	// It is added to the records the sync creates and updates.
+	Tag string
+
+	// Filter, if set, is called for records that pass the Comment and Tag
+	// checks and reports whether the record is owned.
+	Filter func(rr DNSRecord) bool
+}
+
+// owns reports whether `rr` is managed by the sync.
+func (o DNSRecordOwnership) owns(rr DNSRecord) bool {
+	if o.Comment != "" && rr.Comment != o.Comment {
+		return false
+	}
+
+	if o.Tag != "" && !containsString(rr.Tags, o.Tag) {
+		return false
+	}
+
+	return o.Filter == nil || o.Filter(rr)
+}
+
+// mark returns `rr` with the ownership comment and tag set.
+func (o DNSRecordOwnership) mark(rr DNSRecord) DNSRecord {
+	if o.Comment != "" {
+		rr.Comment = o.Comment
+	}
+
+	if o.Tag != "" && !containsString(rr.Tags, o.Tag) {
+		rr.Tags = append(append([]string{}, rr.Tags...), o.Tag)
+	}
+
+	return rr
+}
+
+// DNSSyncParams are the parameters of PlanDNSSync.
+type DNSSyncParams struct {
+	// Records is the desired state of the owned records of the zone. Names
+	// must be fully qualified.
+	Records []DNSRecord
+
+	Ownership DNSRecordOwnership
+}
+
+// DNSSyncChange is a single change of a DNSSyncPlan.
+type DNSSyncChange struct {
+	Type DNSSyncChangeType
+
+	// Current is the existing record. It is unset for creates.
+	Current DNSRecord
+
+	// Desired is the record after the change. It is unset for deletes.
+	Desired DNSRecord
+}
+
+func (c DNSSyncChange) String() string {
+	switch c.Type {
+	case DNSSyncCreate:
+		return "+ " + dnsSyncDescribe(c.Desired)
+	case DNSSyncDelete:
+		return "- " + dnsSyncDescribe(c.Current)
+	default:
+		return "~ " + dnsSyncDescribe(c.Current) + " => " + dnsSyncDescribe(c.Desired)
+	}
+}
+
+// DNSSyncPlan is the set of changes that brings the owned records of a zone
+// to their desired state.
+type DNSSyncPlan struct {
+	ZoneID string
+
+	// Changes are ordered as they are applied: deletes, then updates, then
+	// creates.
+	Changes []DNSSyncChange
+}
+
+// Empty reports whether the zone is already in the desired state.
+func (p *DNSSyncPlan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String returns the changes one per line, prefixed by "+" for creates, "~"
+// for updates and "-" for deletes.
+func (p *DNSSyncPlan) String() string {
+	var b strings.Builder
+	for _, c := range p.Changes {
+		b.WriteString(c.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// PlanDNSSync compares the desired records of a zone with its current
+// records and returns the changes needed to reconcile them. Nothing is
+// changed until the plan is passed to ApplyDNSSyncPlan.
+//
+// Records are matched on type, name and content, or on data for records
+// such as SRV and CAA that are set using Data. When the ownership sets a
+// comment or a tag, a desired record identical to a record that isn't owned
+// yet adopts it with an update that marks it as owned, instead of creating a
+// duplicate. Desired records that only differ from an owned record of the
+// same type and name in their content are planned as updates; other unmatched
+// desired records are created and unmatched owned records are deleted.
+// Existing records only need an update when a field set in the desired
+// record differs.
+func (api *API) PlanDNSSync(ctx context.Context, zoneID string, params DNSSyncParams) (*DNSSyncPlan, error) {
+	if zoneID == "" {
+		return nil, ErrMissingZoneID
+	}
+
+	desired := make([]DNSRecord, 0, len(params.Records))
+	seen := make(map[string]bool, len(params.Records))
+	for i, rr := range params.Records {
+		if rr.Type == "" || rr.Name == "" {
+			return nil, fmt.Errorf("desired record %d: type and name are required", i)
+		}
+
+		rr.Type = strings.ToUpper(rr.Type)
+		rr.Name = normalizeDNSName(rr.Name)
+
+		key := rr.Type + " " + rr.Name + " " + dnsSyncValue(rr)
+		if seen[key] {
+			return nil, fmt.Errorf("desired record %d: duplicate %s record %s", i, rr.Type, rr.Name)
+		}
+		seen[key] = true
+
+		desired = append(desired, params.Ownership.mark(rr))
+	}
+
+	current, _, err := api.ListDNSRecords(ctx, ZoneIdentifier(zoneID), DNSListParams{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Owned records by type and name, in the order the API returned them,
+	// and the records that can be adopted by marking them as owned.
+	adoptable := params.Ownership.Comment != "" || params.Ownership.Tag != ""
+	owned := make(map[string][]DNSRecord)
+	unowned := make(map[string][]DNSRecord)
+	for _, rr := range current {
+		key := dnsSyncGroup(rr)
+		switch {
+		case params.Ownership.owns(rr):
+			owned[key] = append(owned[key], rr)
+		case adoptable:
+			unowned[key] = append(unowned[key], rr)
+		}
+	}
+
+	plan := &DNSSyncPlan{ZoneID: zoneID}
+	var updates, creates []DNSSyncChange
+	var unmatched []DNSRecord
+
+	// Match the desired records to owned records with the same value first so
+	// they aren't paired with a record that merely has the same name.
+	for _, rr := range desired {
+		group := owned[dnsSyncGroup(rr)]
+		i := indexDNSRecord(group, func(cur DNSRecord) bool { return dnsSyncSameValue(cur, rr) })
+		if i < 0 {
+			if cur, ok := takeDNSRecord(unowned, rr); ok {
+				updates = append(updates, DNSSyncChange{Type: DNSSyncUpdate, Current: cur, Desired: rr})
+				continue
+			}
+			unmatched = append(unmatched, rr)
+			continue
+		}
+
+		if dnsSyncNeedsUpdate(group[i], rr) {
+			updates = append(updates, DNSSyncChange{Type: DNSSyncUpdate, Current: group[i], Desired: rr})
+		}
+		owned[dnsSyncGroup(rr)] = append(group[:i:i], group[i+1:]...)
+	}
+
+	for _, rr := range unmatched {
+		group := owned[dnsSyncGroup(rr)]
+		if len(group) == 0 {
+			creates = append(creates, DNSSyncChange{Type: DNSSyncCreate, Desired: rr})
+			continue
+		}
+
+		updates = append(updates, DNSSyncChange{Type: DNSSyncUpdate, Current: group[0], Desired: rr})
+		owned[dnsSyncGroup(rr)] = group[1:]
+	}
+
+	for _, rr := range current {
+		group := owned[dnsSyncGroup(rr)]
+		if i := indexDNSRecord(group, func(cur DNSRecord) bool { return cur.ID == rr.ID }); i >= 0 {
+			plan.Changes = append(plan.Changes, DNSSyncChange{Type: DNSSyncDelete, Current: rr})
+		}
+	}
+
+	plan.Changes = append(plan.Changes, updates...)
+	plan.Changes = append(plan.Changes, creates...)
+
+	return plan, nil
+}
+
+// ApplyDNSSyncParams are the parameters of ApplyDNSSyncPlan.
+type ApplyDNSSyncParams struct {
+	// Concurrency is the number of changes applied at once. Defaults to 4.
+	Concurrency int
+
+	// DisableRollback leaves the changes that were applied in place when a
+	// change fails.
+	DisableRollback bool
+
+	// RollbackTimeout bounds the rollback, which doesn't use the context
+	// passed to ApplyDNSSyncPlan so that it still runs once that context is
+	// cancelled. Defaults to one minute.
+	RollbackTimeout time.Duration
+}
+
+// DNSSyncChangeError is a change that couldn't be applied or rolled back.
+type DNSSyncChangeError struct {
+	Change DNSSyncChange
+	Err    error
+}
+
+func (e DNSSyncChangeError) Error() string {
+	return e.Change.String() + ": " + e.Err.Error()
+}
+
+// DNSSyncError is returned by ApplyDNSSyncPlan when changes fail.
+type DNSSyncError struct {
+	// Errors are the changes that failed.
+	Errors []DNSSyncChangeError
+
+	// RolledBack reports whether the changes that were applied before the
+	// failure were reverted.
+	RolledBack bool
+
+	// RollbackErrors are the applied changes that couldn't be reverted.
+	RollbackErrors []DNSSyncChangeError
+}
+
+func (e *DNSSyncError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, ce := range e.Errors {
+		msgs = append(msgs, ce.Error())
+	}
+
+	msg := fmt.Sprintf("DNS sync failed: %s", strings.Join(msgs, "; "))
+	if len(e.RollbackErrors) > 0 {
+		msg += fmt.Sprintf(" (%d changes couldn't be rolled back)", len(e.RollbackErrors))
+	}
+
+	return msg
+}
+
+// Unwrap returns the error of the first failed change.
+func (e *DNSSyncError) Unwrap() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+
+	return e.Errors[0].Err
+}
+
+// ApplyDNSSyncPlan applies the changes of a plan returned by PlanDNSSync.
+// Deletes are applied first, so that a name is free before a record of a
+// conflicting type such as a CNAME is created, then updates and creates.
+// Changes of the same kind are applied concurrently.
+//
+// When a change fails no further changes are started and, unless
+// DisableRollback is set, the changes already applied are reverted in
+// reverse order: created records are deleted, updated records are restored
+// and deleted records are created again with a new identifier. The returned
+// error is a *DNSSyncError.
+func (api *API) ApplyDNSSyncPlan(ctx context.Context, plan *DNSSyncPlan, params ApplyDNSSyncParams) error {
+	if plan == nil {
+		return ErrMissingDNSSyncPlan
+	}
+
+	if plan.ZoneID == "" {
+		return ErrMissingZoneID
+	}
+
+	concurrency := params.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultDNSSyncConcurrency
+	}
+
+	var (
+		mu      sync.Mutex
+		applied []DNSSyncChange
+		failed  []DNSSyncChangeError
+	)
+
+	for _, phase := range []DNSSyncChangeType{DNSSyncDelete, DNSSyncUpdate, DNSSyncCreate} {
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, concurrency)
+
+		for _, c := range plan.Changes {
+			if c.Type != phase {
+				continue
+			}
+
+			sem <- struct{}{}
+			mu.Lock()
+			stop := len(failed) > 0
+			mu.Unlock()
+			if stop {
+				<-sem
+				break
+			}
+
+			wg.Add(1)
+			go func(c DNSSyncChange) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+
+				c, err := api.applyDNSSyncChange(ctx, plan.ZoneID, c)
+
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					failed = append(failed, DNSSyncChangeError{Change: c, Err: err})
+					return
+				}
+				applied = append(applied, c)
+			}(c)
+		}
+
+		wg.Wait()
+		if len(failed) > 0 {
+			break
+		}
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	syncErr := &DNSSyncError{Errors: failed}
+	if params.DisableRollback {
+		return syncErr
+	}
+
+	// The failure may be the cancellation of ctx, which mustn't leave the
+	// zone half synced.
+	timeout := params.RollbackTimeout
+	if timeout <= 0 {
+		timeout = defaultDNSSyncRollbackTimeout
+	}
+	rollbackCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	for i := len(applied) - 1; i >= 0; i-- {
+		if _, err := api.applyDNSSyncChange(rollbackCtx, plan.ZoneID, applied[i].revert()); err != nil {
+			syncErr.RollbackErrors = append(syncErr.RollbackErrors, DNSSyncChangeError{Change: applied[i], Err: err})
+		}
+	}
+	syncErr.RolledBack = len(syncErr.RollbackErrors) == 0
+
+	return syncErr
+}
+
+// applyDNSSyncChange makes a single change. It returns the change with the
+// identifier of created records set so that it can be reverted.
+func (api *API) applyDNSSyncChange(ctx context.Context, zoneID string, c DNSSyncChange) (DNSSyncChange, error) {
+	switch c.Type {
+	case DNSSyncCreate:
+		res, err := api.CreateDNSRecord(ctx, zoneID, c.Desired)
+		if err != nil {
+			return c, err
+		}
+		c.Desired = res.Result
+		return c, nil
+	case DNSSyncUpdate:
+		return c, api.UpdateDNSRecord(ctx, zoneID, c.Current.ID, c.Desired)
+	case DNSSyncDelete:
+		return c, api.DeleteDNSRecord(ctx, zoneID, c.Current.ID)
+	default:
+		return c, fmt.Errorf("unknown change type %q", c.Type)
+	}
+}
+
+// revert returns the change that undoes `c` once it has been applied.
+//
+// Updates are reverted by setting the fields of the previous record again,
+// so fields that were previously empty keep their new value.
+func (c DNSSyncChange) revert() DNSSyncChange {
+	switch c.Type {
+	case DNSSyncCreate:
+		return DNSSyncChange{Type: DNSSyncDelete, Current: c.Desired}
+	case DNSSyncDelete:
+		return DNSSyncChange{Type: DNSSyncCreate, Desired: dnsSyncWritable(c.Current)}
+	default:
+		current := c.Desired
+		current.ID = c.Current.ID
+		return DNSSyncChange{Type: DNSSyncUpdate, Current: current, Desired: dnsSyncWritable(c.Current)}
+	}
+}
+
+// dnsSyncWritable returns the fields of an existing record that can be sent
+// back to the API.
+func dnsSyncWritable(rr DNSRecord) DNSRecord {
+	w := DNSRecord{
+		Type:     rr.Type,
+		Name:     rr.Name,
+		Content:  rr.Content,
+		Data:     rr.Data,
+		Priority: rr.Priority,
+		TTL:      rr.TTL,
+		Proxied:  rr.Proxied,
+		Comment:  rr.Comment,
+		Tags:     rr.Tags,
+	}
+
+	// The API derives the content of records set using data, and returns
+	// empty data for the other records.
+	if len(recordDataMap(w.Data)) > 0 {
+		w.Content = ""
+	} else {
+		w.Data = nil
+	}
+
+	return w
+}
+
+// dnsSyncNeedsUpdate reports whether a field set in `desired` differs from
+// `current`.
+func dnsSyncNeedsUpdate(current, desired DNSRecord) bool {
+	switch {
+	case !dnsSyncSameValue(current, desired):
+		return true
+	case desired.TTL != 0 && desired.TTL != current.TTL:
+		return true
+	case desired.Proxied != nil && (current.Proxied == nil || *desired.Proxied != *current.Proxied):
+		return true
+	case desired.Priority != nil && (current.Priority == nil || *desired.Priority != *current.Priority):
+		return true
+	case desired.Comment != "" && desired.Comment != current.Comment:
+		return true
+	case desired.Tags != nil && !dnsSyncSameTags(current.Tags, desired.Tags):
+		return true
+	}
+
+	return false
+}
+
+// dnsSyncSameValue reports whether `current` has the content or data of
+// `desired`.
+func dnsSyncSameValue(current, desired DNSRecord) bool {
+	if desired.Content != "" {
+		return dnsSyncContent(current) == dnsSyncContent(desired)
+	}
+
+	return dnsSyncSameData(current.Data, desired.Data)
+}
+
+// dnsSyncSameData reports whether every field of `desired` has the same value
+// in `current`. The API may return more fields than were set.
+func dnsSyncSameData(current, desired interface{}) bool {
+	if desired == nil {
+		return current == nil
+	}
+
+	cur, des := dnsSyncDataMap(current), dnsSyncDataMap(desired)
+	if cur == nil || des == nil {
+		return false
+	}
+
+	for k, v := range des {
+		if !reflect.DeepEqual(cur[k], v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dnsSyncDataMap returns record data as a map for comparison, with the
+// trailing dot of names such as SRV targets removed.
+func dnsSyncDataMap(data interface{}) map[string]interface{} {
+	m := recordDataMap(data)
+	for k, v := range m {
+		if s, ok := v.(string); ok {
+			m[k] = strings.TrimSuffix(s, ".")
+		}
+	}
+
+	return m
+}
+
+// dnsSyncContent returns the content of `rr` in the form the API returns it.
+func dnsSyncContent(rr DNSRecord) string {
+	switch rr.Type {
+	case "AAAA":
+		if ip := net.ParseIP(rr.Content); ip != nil {
+			return ip.String()
+		}
+	case "CNAME", "MX", "NS", "PTR":
+		return normalizeDNSName(rr.Content)
+	}
+
+	return rr.Content
+}
+
+// dnsSyncValue returns a string identifying the content or data of `rr`.
+func dnsSyncValue(rr DNSRecord) string {
+	if rr.Content != "" || rr.Data == nil {
+		return dnsSyncContent(rr)
+	}
+
+	b, _ := json.Marshal(dnsSyncDataMap(rr.Data))
+	return string(b)
+}
+
+func dnsSyncGroup(rr DNSRecord) string {
+	return strings.ToUpper(rr.Type) + " " + normalizeDNSName(rr.Name)
+}
+
+func dnsSyncSameTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	a = append([]string{}, a...)
+	b = append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+
+	return reflect.DeepEqual(a, b)
+}
+
+func dnsSyncDescribe(rr DNSRecord) string {
+	s := rr.Type + " " + rr.Name + " " + dnsSyncValue(rr)
+	if rr.Priority != nil {
+		s += " priority=" + strconv.Itoa(int(*rr.Priority))
+	}
+	if rr.TTL != 0 {
+		s += " ttl=" + strconv.Itoa(rr.TTL)
+	}
+	if rr.Proxied != nil {
+		s += " proxied=" + strconv.FormatBool(*rr.Proxied)
+	}
+	return s
+}
+
+func indexDNSRecord(records []DNSRecord, match func(DNSRecord) bool) int {
+	for i, rr := range records {
+		if match(rr) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// takeDNSRecord removes the record with the same value as `rr` from its group
+// of `groups` and returns it.
+func takeDNSRecord(groups map[string][]DNSRecord, rr DNSRecord) (DNSRecord, bool) {
+	key := dnsSyncGroup(rr)
+	group := groups[key]
+	i := indexDNSRecord(group, func(cur DNSRecord) bool { return dnsSyncSameValue(cur, rr) })
+	if i < 0 {
+		return DNSRecord{}, false
+	}
+
+	groups[key] = append(group[:i:i], group[i+1:]...)
+	return group[i], true
+}
+
+func containsString(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,364 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDNSZone serves the DNS record endpoints of testZoneID from memory.
+type fakeDNSZone struct {
+	mu      sync.Mutex
+	records map[string]DNSRecord
+	order   []string
+	nextID  int
+	fail    func(rr DNSRecord) bool
+}
+
+func newFakeDNSZone(t *testing.T, records ...DNSRecord) *fakeDNSZone {
+	z := &fakeDNSZone{records: map[string]DNSRecord{}}
+	for _, rr := range records {
+		z.add(rr)
+	}
+
+	respond := func(w http.ResponseWriter, result interface{}) {
+		w.Header().Set("content-type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"success":     true,
+			"errors":      []interface{}{},
+			"messages":    []interface{}{},
+			"result":      result,
+			"result_info": map[string]int{"page": 1, "total_pages": 1},
+		}))
+	}
+
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		z.mu.Lock()
+		defer z.mu.Unlock()
+
+		switch r.Method {
+		case http.MethodGet:
+			list := []DNSRecord{}
+			for _, id := range z.order {
+				if name := r.URL.Query().Get("name"); name == "" || z.records[id].Name == name {
+					list = append(list, z.records[id])
+				}
+			}
+			respond(w, list)
+		case http.MethodPost:
+			var rr DNSRecord
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&rr))
+			if z.fail != nil && z.fail(rr) {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"success": false, "errors": [{"code": 81057, "message": "Record already exists."}], "messages": [], "result": null}`)
+				return
+			}
+			respond(w, z.add(rr))
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records/", func(w http.ResponseWriter, r *http.Request) {
+		z.mu.Lock()
+		defer z.mu.Unlock()
+
+		id := strings.TrimPrefix(r.URL.Path, "/zones/"+testZoneID+"/dns_records/")
+		_, ok := z.records[id]
+		require.True(t, ok, "unknown record %s", id)
+
+		switch r.Method {
+		case http.MethodGet:
+			respond(w, z.records[id])
+		case http.MethodPatch:
+			// PATCH only changes the fields present in the body, so decode
+			// them over the stored record like the API does.
+			rr := z.records[id]
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&rr))
+			z.records[id] = rr
+			respond(w, rr)
+		case http.MethodDelete:
+			delete(z.records, id)
+			for i, o := range z.order {
+				if o == id {
+					z.order = append(z.order[:i], z.order[i+1:]...)
+					break
+				}
+			}
+			respond(w, map[string]string{"id": id})
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	return z
+}
+
+// add stores a record with a new identifier. z.mu must be held once the zone
+// is serving requests.
+func (z *fakeDNSZone) add(rr DNSRecord) DNSRecord {
+	z.nextID++
+	rr.ID = fmt.Sprintf("record-%d", z.nextID)
+	z.records[rr.ID] = rr
+	z.order = append(z.order, rr.ID)
+	return rr
+}
+
+// state returns the records of the zone as "TYPE name content ttl comment".
+func (z *fakeDNSZone) state() []string {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	var s []string
+	for _, id := range z.order {
+		rr := z.records[id]
+		s = append(s, fmt.Sprintf("%s %s %s %d %s", rr.Type, rr.Name, rr.Content, rr.TTL, rr.Comment))
+	}
+	return s
+}
+
+func testDNSSyncZone(t *testing.T) *fakeDNSZone {
+	return newFakeDNSZone(t,
+		DNSRecord{Type: "A", Name: "www.example.com", Content: "192.0.2.1", TTL: 300, Comment: "managed", Data: map[string]interface{}{}},
+		DNSRecord{Type: "A", Name: "old.example.com", Content: "192.0.2.2", TTL: 300, Comment: "managed"},
+		DNSRecord{Type: "CNAME", Name: "api.example.com", Content: "a.example.net", TTL: 300, Comment: "managed"},
+		DNSRecord{Type: "TXT", Name: "example.com", Content: "hand made", TTL: 300},
+		DNSRecord{Type: "A", Name: "same.example.com", Content: "192.0.2.3", TTL: 300, Comment: "managed"},
+	)
+}
+
+var testDNSSyncDesired = []DNSRecord{
+	{Type: "A", Name: "www.example.com.", Content: "192.0.2.1", TTL: 600},
+	{Type: "CNAME", Name: "API.example.com", Content: "b.example.net.", TTL: 300},
+	{Type: "A", Name: "new.example.com", Content: "192.0.2.4", TTL: 300},
+	{Type: "A", Name: "same.example.com", Content: "192.0.2.3", TTL: 300},
+}
+
+func TestPlanDNSSync(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testDNSSyncZone(t)
+
+	plan, err := client.PlanDNSSync(context.Background(), testZoneID, DNSSyncParams{
+		Records:   testDNSSyncDesired,
+		Ownership: DNSRecordOwnership{Comment: "managed"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, testZoneID, plan.ZoneID)
+	assert.False(t, plan.Empty())
+	assert.Equal(t, `- A old.example.com 192.0.2.2 ttl=300
+~ A www.example.com 192.0.2.1 ttl=300 => A www.example.com 192.0.2.1 ttl=600
+~ CNAME api.example.com a.example.net ttl=300 => CNAME api.example.com b.example.net ttl=300
++ A new.example.com 192.0.2.4 ttl=300
+`, plan.String())
+
+	for _, c := range plan.Changes {
+		if c.Type != DNSSyncDelete {
+			assert.Equal(t, "managed", c.Desired.Comment, "desired records are marked as owned")
+		}
+	}
+}
+
+func TestPlanDNSSync_Adopt(t *testing.T) {
+	setup()
+	defer teardown()
+
+	zone := newFakeDNSZone(t,
+		DNSRecord{Type: "A", Name: "www.example.com", Content: "192.0.2.1", TTL: 300},
+		DNSRecord{Type: "A", Name: "www.example.com", Content: "192.0.2.2", TTL: 300, Comment: "managed"},
+	)
+	desired := []DNSRecord{{Type: "A", Name: "www.example.com", Content: "192.0.2.1", TTL: 300}}
+
+	plan, err := client.PlanDNSSync(context.Background(), testZoneID, DNSSyncParams{
+		Records:   desired,
+		Ownership: DNSRecordOwnership{Comment: "managed"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `- A www.example.com 192.0.2.2 ttl=300
+~ A www.example.com 192.0.2.1 ttl=300 => A www.example.com 192.0.2.1 ttl=300
+`, plan.String(), "the identical record is adopted rather than duplicated")
+
+	require.NoError(t, client.ApplyDNSSyncPlan(context.Background(), plan, ApplyDNSSyncParams{}))
+	assert.Equal(t, []string{"A www.example.com 192.0.2.1 300 managed"}, zone.state())
+
+	// Without a marker the record can't be adopted and is left alone.
+	plan, err = client.PlanDNSSync(context.Background(), testZoneID, DNSSyncParams{
+		Records:   desired,
+		Ownership: DNSRecordOwnership{Filter: func(rr DNSRecord) bool { return false }},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "+ A www.example.com 192.0.2.1 ttl=300\n", plan.String())
+}
+
+func TestPlanDNSSync_Errors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.PlanDNSSync(context.Background(), "", DNSSyncParams{})
+	assert.ErrorIs(t, err, ErrMissingZoneID)
+
+	err = client.ApplyDNSSyncPlan(context.Background(), nil, ApplyDNSSyncParams{})
+	assert.ErrorIs(t, err, ErrMissingDNSSyncPlan)
+
+	_, err = client.PlanDNSSync(context.Background(), testZoneID, DNSSyncParams{Records: []DNSRecord{{Type: "A"}}})
+	assert.EqualError(t, err, "desired record 0: type and name are required")
+
+	_, err = client.PlanDNSSync(context.Background(), testZoneID, DNSSyncParams{Records: []DNSRecord{
+		{Type: "A", Name: "www.example.com", Content: "192.0.2.1"},
+		{Type: "a", Name: "www.example.com.", Content: "192.0.2.1"},
+	}})
+	assert.EqualError(t, err, "desired record 1: duplicate A record www.example.com")
+}
+
+func TestPlanDNSSync_Data(t *testing.T) {
+	setup()
+	defer teardown()
+
+	newFakeDNSZone(t, DNSRecord{
+		Type:    "SRV",
+		Name:    "_sip._tcp.example.com",
+		Content: "20 5060 sip.example.com",
+		Data: map[string]interface{}{
+			"service":  "_sip",
+			"proto":    "_tcp",
+			"name":     "example.com",
+			"priority": 10,
+			"weight":   20,
+			"port":     5060,
+			"target":   "sip.example.com",
+		},
+		TTL: 300,
+	})
+
+	desired := []DNSRecord{{Type: "SRV", Name: "_sip._tcp.example.com", TTL: 300, Data: map[string]interface{}{
+		"priority": uint64(10),
+		"weight":   uint64(20),
+		"port":     uint64(5060),
+		"target":   "sip.example.com.",
+	}}}
+
+	plan, err := client.PlanDNSSync(context.Background(), testZoneID, DNSSyncParams{Records: desired})
+	require.NoError(t, err)
+	assert.True(t, plan.Empty(), plan.String())
+}
+
+func TestApplyDNSSyncPlan(t *testing.T) {
+	setup()
+	defer teardown()
+
+	zone := testDNSSyncZone(t)
+
+	plan, err := client.PlanDNSSync(context.Background(), testZoneID, DNSSyncParams{
+		Records:   testDNSSyncDesired,
+		Ownership: DNSRecordOwnership{Comment: "managed"},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, client.ApplyDNSSyncPlan(context.Background(), plan, ApplyDNSSyncParams{Concurrency: 2}))
+	assert.Equal(t, []string{
+		"A www.example.com 192.0.2.1 600 managed",
+		"CNAME api.example.com b.example.net. 300 managed",
+		"TXT example.com hand made 300 ",
+		"A same.example.com 192.0.2.3 300 managed",
+		"A new.example.com 192.0.2.4 300 managed",
+	}, zone.state())
+
+	plan, err = client.PlanDNSSync(context.Background(), testZoneID, DNSSyncParams{
+		Records:   testDNSSyncDesired,
+		Ownership: DNSRecordOwnership{Comment: "managed"},
+	})
+	require.NoError(t, err)
+	assert.True(t, plan.Empty(), plan.String())
+}
+
+func TestApplyDNSSyncPlan_Rollback(t *testing.T) {
+	setup()
+	defer teardown()
+
+	zone := testDNSSyncZone(t)
+	before := zone.state()
+	zone.fail = func(rr DNSRecord) bool {
+		return rr.Name == "new.example.com"
+	}
+
+	plan, err := client.PlanDNSSync(context.Background(), testZoneID, DNSSyncParams{
+		Records:   testDNSSyncDesired,
+		Ownership: DNSRecordOwnership{Comment: "managed"},
+	})
+	require.NoError(t, err)
+
+	err = client.ApplyDNSSyncPlan(context.Background(), plan, ApplyDNSSyncParams{})
+
+	var syncErr *DNSSyncError
+	require.ErrorAs(t, err, &syncErr)
+	assert.True(t, syncErr.RolledBack)
+	require.Len(t, syncErr.Errors, 1)
+	assert.Equal(t, "new.example.com", syncErr.Errors[0].Change.Desired.Name)
+	assert.Contains(t, err.Error(), "+ A new.example.com 192.0.2.4 ttl=300: ")
+
+	// The deleted record is created again with a new identifier.
+	assert.ElementsMatch(t, before, zone.state())
+}
+
+func TestApplyDNSSyncPlan_RollbackAfterCancel(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	zone := testDNSSyncZone(t)
+	before := zone.state()
+	zone.fail = func(rr DNSRecord) bool {
+		if rr.Name != "new.example.com" {
+			return false
+		}
+		cancel()
+		return true
+	}
+
+	plan, err := client.PlanDNSSync(ctx, testZoneID, DNSSyncParams{
+		Records:   testDNSSyncDesired,
+		Ownership: DNSRecordOwnership{Comment: "managed"},
+	})
+	require.NoError(t, err)
+
+	err = client.ApplyDNSSyncPlan(ctx, plan, ApplyDNSSyncParams{})
+
+	var syncErr *DNSSyncError
+	require.ErrorAs(t, err, &syncErr)
+	assert.True(t, syncErr.RolledBack, "the rollback doesn't use the cancelled context")
+	assert.ElementsMatch(t, before, zone.state())
+}
+
+func TestApplyDNSSyncPlan_DisableRollback(t *testing.T) {
+	setup()
+	defer teardown()
+
+	zone := testDNSSyncZone(t)
+	zone.fail = func(rr DNSRecord) bool {
+		return rr.Name == "new.example.com"
+	}
+
+	plan, err := client.PlanDNSSync(context.Background(), testZoneID, DNSSyncParams{
+		Records:   testDNSSyncDesired,
+		Ownership: DNSRecordOwnership{Comment: "managed"},
+	})
+	require.NoError(t, err)
+
+	err = client.ApplyDNSSyncPlan(context.Background(), plan, ApplyDNSSyncParams{DisableRollback: true})
+
+	var syncErr *DNSSyncError
+	require.ErrorAs(t, err, &syncErr)
+	assert.False(t, syncErr.RolledBack)
+	assert.NotContains(t, zone.state(), "A old.example.com 192.0.2.2 300 managed")
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"testing"
 	"time"
 
@@ -601,3 +602,260 @@ func TestDeleteDNSRecord(t *testing.T) {
 	err := client.DeleteDNSRecord(context.Background(), testZoneID, dnsRecordID)
 	require.NoError(t, err)
 }
+
+func TestDNSRecordsIterator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		assert.Equal(t, "A", r.URL.Query().Get("type"))
+
+		w.Header().Set("content-type", "application/json")
+		page := r.URL.Query().Get("page")
+		fmt.Fprintf(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": [
+				{
+					"id": "record-%[1]s",
+					"type": "A",
+					"name": "%[1]s.example.com",
+					"content": "198.51.100.4"
+				}
+			],
+			"result_info": {
+				"page": %[1]s,
+				"per_page": 1,
+				"count": 1,
+				"total_count": 3,
+				"total_pages": 3
+			}
+		}`, page)
+	}
+
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records", handler)
+
+	it := client.DNSRecordsIterator(context.Background(), testZoneID, DNSRecord{Type: "A"})
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+		if len(ids) == 2 {
+			it.Stop()
+		}
+	}
+	require.NoError(t, it.Err())
+
+	assert.Equal(t, []string{"record-1", "record-2"}, ids)
+	assert.Equal(t, 2, it.ResultInfo().Page)
+}
+
+func TestListDNSRecords(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+
+		q := r.URL.Query()
+		assert.Equal(t, "A", q.Get("type"))
+		assert.Equal(t, "true", q.Get("proxied"))
+		assert.Equal(t, "any", q.Get("match"))
+		assert.Equal(t, "name", q.Get("order"))
+		assert.Equal(t, "desc", q.Get("direction"))
+		assert.Equal(t, "managed", q.Get("comment"))
+		assert.Equal(t, []string{"env:prod", "team"}, q["tag"])
+		assert.Equal(t, "all", q.Get("tag_match"))
+		assert.Equal(t, "xn--138h.example.com", q.Get("name"))
+		assert.Equal(t, "😺", q.Get("name.startswith"), "name fragments aren't converted")
+		assert.Equal(t, ".example.com", q.Get("name.endswith"))
+		assert.Equal(t, "api", q.Get("name.contains"))
+		assert.Equal(t, "2", q.Get("page"))
+		assert.Equal(t, "5", q.Get("per_page"))
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": [
+				{"id": "372e67954025e0ba6aaa6d586b9e0b59", "type": "A", "name": "xn--138h-api.example.com", "content": "198.51.100.4"}
+			],
+			"result_info": {
+				"page": 2,
+				"per_page": 5,
+				"count": 1,
+				"total_count": 6,
+				"total_pages": 2
+			}
+		}`)
+	})
+
+	proxied := true
+	records, info, err := client.ListDNSRecords(context.Background(), ZoneIdentifier(testZoneID), DNSListParams{
+		Type:           "A",
+		Proxied:        &proxied,
+		Match:          "any",
+		Order:          "name",
+		Direction:      "desc",
+		Comment:        "managed",
+		Tags:           []string{"env:prod", "team"},
+		TagMatch:       "all",
+		Name:           "😺.example.com",
+		NameStartsWith: "😺",
+		NameEndsWith:   ".example.com",
+		NameContains:   "api",
+		ResultInfo:     ResultInfo{Page: 2, PerPage: 5},
+	})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "372e67954025e0ba6aaa6d586b9e0b59", records[0].ID)
+	assert.Equal(t, &ResultInfo{Page: 2, PerPage: 5, Count: 1, Total: 6, TotalPages: 2}, info)
+
+	_, _, err = client.ListDNSRecords(context.Background(), ZoneIdentifier(""), DNSListParams{})
+	assert.ErrorIs(t, err, ErrMissingZoneID)
+}
+
+func TestListDNSRecords_Pagination(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const total, perPage = 23, 5
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "A", r.URL.Query().Get("type"))
+		assert.Equal(t, "5", r.URL.Query().Get("per_page"))
+
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		require.NoError(t, err)
+
+		var result []DNSRecord
+		for i := (page - 1) * perPage; i < page*perPage && i < total; i++ {
+			result = append(result, DNSRecord{ID: fmt.Sprintf("record-%d", i), Type: "A"})
+		}
+
+		w.Header().Set("content-type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"success":     true,
+			"errors":      []interface{}{},
+			"messages":    []interface{}{},
+			"result":      result,
+			"result_info": ResultInfo{Page: page, PerPage: perPage, Count: len(result), Total: total, TotalPages: 5},
+		}))
+	})
+
+	records, info, err := client.ListDNSRecords(context.Background(), ZoneIdentifier(testZoneID), DNSListParams{
+		Type:        "A",
+		Concurrency: 2,
+		ResultInfo:  ResultInfo{PerPage: perPage},
+	})
+	require.NoError(t, err)
+	require.Len(t, records, total)
+	for i, rr := range records {
+		assert.Equal(t, fmt.Sprintf("record-%d", i), rr.ID)
+	}
+	assert.Equal(t, total, info.Count)
+	assert.Equal(t, 5, info.TotalPages)
+}
+
+func TestListDNSRecords_PaginationSmallerPages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// the API caps the page size below the one requested.
+	const total, perPage = 12, 5
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		require.NoError(t, err)
+		if page > 1 {
+			assert.Equal(t, "5", r.URL.Query().Get("per_page"))
+		}
+
+		var result []DNSRecord
+		for i := (page - 1) * perPage; i < page*perPage && i < total; i++ {
+			result = append(result, DNSRecord{ID: fmt.Sprintf("record-%d", i)})
+		}
+
+		w.Header().Set("content-type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"success":     true,
+			"errors":      []interface{}{},
+			"messages":    []interface{}{},
+			"result":      result,
+			"result_info": ResultInfo{Page: page, PerPage: perPage, Count: len(result), Total: total, TotalPages: 3},
+		}))
+	})
+
+	records, _, err := client.ListDNSRecords(context.Background(), ZoneIdentifier(testZoneID), DNSListParams{})
+	require.NoError(t, err)
+	require.Len(t, records, total)
+	for i, rr := range records {
+		assert.Equal(t, fmt.Sprintf("record-%d", i), rr.ID)
+	}
+}
+
+func TestListDNSRecordsIterator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const total, perPage = 5, 2
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "TXT", r.URL.Query().Get("type"))
+		assert.Equal(t, "2", r.URL.Query().Get("per_page"))
+
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		require.NoError(t, err)
+
+		var result []DNSRecord
+		for i := (page - 1) * perPage; i < page*perPage && i < total; i++ {
+			result = append(result, DNSRecord{ID: fmt.Sprintf("record-%d", i)})
+		}
+
+		w.Header().Set("content-type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"success":     true,
+			"errors":      []interface{}{},
+			"messages":    []interface{}{},
+			"result":      result,
+			"result_info": ResultInfo{Page: page, PerPage: perPage, Count: len(result), Total: total, TotalPages: 3},
+		}))
+	})
+
+	records, err := client.ListDNSRecordsIterator(context.Background(), ZoneIdentifier(testZoneID), DNSListParams{
+		Type:       "TXT",
+		ResultInfo: ResultInfo{PerPage: perPage},
+	}).All()
+	require.NoError(t, err)
+	require.Len(t, records, total)
+	for i, rr := range records {
+		assert.Equal(t, fmt.Sprintf("record-%d", i), rr.ID)
+	}
+}
+
+func TestListDNSRecords_PaginationError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		if r.URL.Query().Get("page") == "3" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"success": false, "errors": [{"code": 1000, "message": "bad page"}], "messages": [], "result": null}`)
+			return
+		}
+
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": [{"id": "a"}, {"id": "b"}],
+			"result_info": {"page": 1, "per_page": 2, "count": 2, "total_count": 8, "total_pages": 4}
+		}`)
+	})
+
+	_, _, err := client.ListDNSRecords(context.Background(), ZoneIdentifier(testZoneID), DNSListParams{
+		ResultInfo: ResultInfo{PerPage: 2},
+	})
+	assert.ErrorContains(t, err, "bad page")
+}
@@ -0,0 +1,202 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// proxiableDNSRecordTypes are the record types that can be proxied.
+var proxiableDNSRecordTypes = map[string]bool{"A": true, "AAAA": true, "CNAME": true}
+
+// ValidateDNSRecords checks a set of records of the zone named `zone` for
+// mistakes the API would reject or that conflict with each other:
+//
+//   - the mistakes DNSRecord.Validate finds in each record
+//   - names outside the zone and CNAME records at the apex of the zone
+//   - CNAME records sharing their name with other records
+//   - duplicate records
+//
+// Like the API, "@" is the apex of the zone and names that neither end with a
+// dot nor with the zone name are relative to the zone. International names
+// are compared in their ASCII form. The zone checks are skipped when `zone`
+// is empty. Invalid records are returned as a *ValidationError whose field
+// names start with the index of the record, e.g. "[2].Content".
+func ValidateDNSRecords(zone string, records []DNSRecord) error {
+	return validateDNSRecords(zone, records).err("DNS records")
+}
+
+func validateDNSRecords(zone string, records []DNSRecord) fieldErrors {
+	zone = normalizeDNSName(zone)
+
+	var errs fieldErrors
+	names := make([]string, len(records))
+	types := make([]string, len(records))
+	byName := make(map[string][]int)
+	seen := make(map[string]int)
+
+	for i, rr := range records {
+		field := func(name string) string {
+			return fmt.Sprintf("[%d].%s", i, name)
+		}
+
+		if err, ok := rr.Validate().(*ValidationError); ok {
+			for _, fe := range err.Errors {
+				errs = append(errs, FieldError{Field: field(fe.Field), Message: fe.Message})
+			}
+		}
+
+		typ := strings.ToUpper(rr.Type)
+		if d, ok := typedDNSRecordData(rr.Data); ok && typ == "" {
+			typ = d.DNSRecordType()
+		}
+
+		name := qualifyDNSName(rr.Name, zone)
+		names[i], types[i] = name, typ
+		if name == "" {
+			continue
+		}
+		byName[name] = append(byName[name], i)
+
+		if zone != "" {
+			if name != zone && !strings.HasSuffix(name, "."+zone) {
+				errs.add(field("Name"), "%s is outside of zone %s", name, zone)
+			} else if name == zone && typ == "CNAME" {
+				errs.add(field("Type"), "CNAME records can't be created at the zone apex")
+			}
+		}
+
+		key := typ + " " + name + " " + dnsSyncValue(rr)
+		if j, ok := seen[key]; ok {
+			errs.add(field("Name"), "duplicates record %d", j)
+		} else {
+			seen[key] = i
+		}
+	}
+
+	// A CNAME record must be the only record at its name. The conflict is
+	// reported for every record involved.
+	for i, name := range names {
+		group := byName[name]
+		if len(group) < 2 {
+			continue
+		}
+
+		cnames := 0
+		for _, j := range group {
+			if types[j] == "CNAME" {
+				cnames++
+			}
+		}
+
+		switch {
+		case types[i] == "CNAME":
+			errs.add(fmt.Sprintf("[%d].Type", i), "CNAME record conflicts with %d other records at %s", len(group)-1, name)
+		case cnames > 0:
+			errs.add(fmt.Sprintf("[%d].Type", i), "%s record conflicts with the CNAME record at %s", types[i], name)
+		}
+	}
+
+	return errs
+}
+
+// preflightDNSRecord validates a record about to be created or updated
+// against the zone and the other records at its name. `recordID` is empty for
+// new records. Only problems with the record itself are returned.
+func (api *API) preflightDNSRecord(ctx context.Context, zoneID, recordID string, rr DNSRecord) error {
+	zone, err := api.ZoneDetails(ctx, zoneID)
+	if err != nil {
+		return err
+	}
+
+	if recordID != "" {
+		current, err := api.DNSRecord(ctx, zoneID, recordID)
+		if err != nil {
+			return err
+		}
+		// The API makes the TTL of records it starts proxying automatic.
+		if rr.Proxied != nil && *rr.Proxied && rr.TTL == 0 {
+			current.TTL = 1
+		}
+		rr = mergeDNSRecord(current, rr)
+	}
+
+	rr.Name = qualifyDNSName(rr.Name, zone.Name)
+
+	existing, err := api.DNSRecords(ctx, zoneID, DNSRecord{Name: rr.Name})
+	if err != nil {
+		return err
+	}
+
+	// The record goes last so that it is the one reported as a duplicate.
+	var records []DNSRecord
+	for _, e := range existing {
+		if e.ID != recordID {
+			records = append(records, e)
+		}
+	}
+	records = append(records, rr)
+	prefix := fmt.Sprintf("[%d].", len(records)-1)
+
+	var errs fieldErrors
+	for _, fe := range validateDNSRecords(zone.Name, records) {
+		if strings.HasPrefix(fe.Field, prefix) {
+			errs = append(errs, FieldError{Field: strings.TrimPrefix(fe.Field, prefix), Message: fe.Message})
+		}
+	}
+
+	return errs.err("DNSRecord")
+}
+
+// mergeDNSRecord returns `current` with the fields set in the partial update
+// `rr` applied.
+func mergeDNSRecord(current, rr DNSRecord) DNSRecord {
+	if rr.Type != "" {
+		current.Type = rr.Type
+	}
+	if rr.Name != "" {
+		current.Name = rr.Name
+	}
+	if rr.Content != "" {
+		current.Content = rr.Content
+	}
+	if rr.Data != nil {
+		current.Data = rr.Data
+	}
+	if rr.Priority != nil {
+		current.Priority = rr.Priority
+	}
+	if rr.TTL != 0 {
+		current.TTL = rr.TTL
+	}
+	if rr.Proxied != nil {
+		current.Proxied = rr.Proxied
+	}
+	if rr.Comment != "" {
+		current.Comment = rr.Comment
+	}
+	if rr.Tags != nil {
+		current.Tags = rr.Tags
+	}
+
+	return current
+}
+
+// qualifyDNSName returns the normalized, fully qualified form of `name` in
+// `zone`. "@" is the apex and names that neither end with a dot nor with the
+// zone name are relative to the zone.
+func qualifyDNSName(name, zone string) string {
+	zone = normalizeDNSName(zone)
+	n := normalizeDNSName(name)
+
+	switch {
+	case name == "@":
+		return zone
+	case n == "" || zone == "":
+		return n
+	case strings.HasSuffix(name, "."), n == zone, strings.HasSuffix(n, "."+zone):
+		return n
+	}
+
+	return n + "." + zone
+}
@@ -0,0 +1,169 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDNSRecords(t *testing.T) {
+	proxied := true
+	priority := uint16(10)
+
+	valid := []DNSRecord{
+		{Type: "A", Name: "@", Content: "192.0.2.1", Proxied: &proxied, TTL: 1},
+		{Type: "AAAA", Name: "example.com.", Content: "2001:db8::1"},
+		{Type: "CNAME", Name: "www.example.com", Content: "example.com", Proxied: &proxied},
+		{Type: "MX", Name: "example.com", Content: "mx.example.com", Priority: &priority},
+		{Type: "A", Name: "😺.example.com", Content: "192.0.2.2"},
+		{Type: "A", Name: "api", Content: "192.0.2.3"},
+		{Name: "example.com", Data: CAARecordData{Tag: "issue", Value: "letsencrypt.org"}},
+	}
+	assert.NoError(t, ValidateDNSRecords("example.com", valid))
+
+	tests := map[string]struct {
+		records []DNSRecord
+		errors  []FieldError
+	}{
+		"CNAME at apex": {
+			records: []DNSRecord{{Type: "CNAME", Name: "@", Content: "example.net"}},
+			errors:  []FieldError{{"[0].Type", "CNAME records can't be created at the zone apex"}},
+		},
+		"CNAME alongside other records": {
+			records: []DNSRecord{
+				{Type: "CNAME", Name: "www.example.com", Content: "example.net"},
+				{Type: "TXT", Name: "WWW.example.com.", Content: "hello"},
+				{Type: "A", Name: "api.example.com", Content: "192.0.2.1"},
+			},
+			errors: []FieldError{
+				{"[0].Type", "CNAME record conflicts with 1 other records at www.example.com"},
+				{"[1].Type", "TXT record conflicts with the CNAME record at www.example.com"},
+			},
+		},
+		"proxied TTL": {
+			records: []DNSRecord{{Type: "A", Name: "www.example.com", Content: "192.0.2.1", Proxied: &proxied, TTL: 300}},
+			errors:  []FieldError{{"[0].TTL", "must be 1 (automatic) for proxied records, got 300"}},
+		},
+		"proxied type": {
+			records: []DNSRecord{{Type: "TXT", Name: "www.example.com", Content: "hello", Proxied: &proxied}},
+			errors:  []FieldError{{"[0].Proxied", "TXT records can't be proxied"}},
+		},
+		"malformed addresses": {
+			records: []DNSRecord{
+				{Type: "A", Name: "a.example.com", Content: "2001:db8::1"},
+				{Type: "AAAA", Name: "b.example.com", Content: "192.0.2.1"},
+				{Type: "A", Name: "c.example.com", Content: "192.0.2"},
+			},
+			errors: []FieldError{
+				{"[0].Content", `"2001:db8::1" isn't an IPv4 address`},
+				{"[1].Content", `"192.0.2.1" isn't an IPv6 address`},
+				{"[2].Content", `"192.0.2" isn't an IPv4 address`},
+			},
+		},
+		"MX priority": {
+			records: []DNSRecord{{Type: "MX", Name: "example.com", Content: "mx.example.com"}},
+			errors:  []FieldError{{"[0].Priority", "is required for MX records"}},
+		},
+		"outside of zone": {
+			records: []DNSRecord{{Type: "A", Name: "www.example.net.", Content: "192.0.2.1"}},
+			errors:  []FieldError{{"[0].Name", "www.example.net is outside of zone example.com"}},
+		},
+		"relative names": {
+			records: []DNSRecord{
+				{Type: "CNAME", Name: "www", Content: "example.net"},
+				{Type: "TXT", Name: "www.example.com", Content: "hello"},
+				{Type: "A", Name: "www.example.net", Content: "192.0.2.1"},
+			},
+			errors: []FieldError{
+				{"[0].Type", "CNAME record conflicts with 1 other records at www.example.com"},
+				{"[1].Type", "TXT record conflicts with the CNAME record at www.example.com"},
+			},
+		},
+		"duplicates": {
+			records: []DNSRecord{
+				{Type: "A", Name: "www.example.com", Content: "192.0.2.1"},
+				{Type: "A", Name: "www.example.com.", Content: "192.0.2.1"},
+			},
+			errors: []FieldError{{"[1].Name", "duplicates record 0"}},
+		},
+		"record fields": {
+			records: []DNSRecord{{Type: "TXT", Name: "example.com", TTL: 5}},
+			errors: []FieldError{
+				{"[0].Content", "is required unless Data is set"},
+				{"[0].TTL", "must be 1 (automatic) or between 30 and 86400, got 5"},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateDNSRecords("example.com.", tc.records)
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, "DNS records", validationErr.Type)
+			assert.Equal(t, tc.errors, validationErr.Errors)
+		})
+	}
+
+	assert.NoError(t, ValidateDNSRecords("", []DNSRecord{{Type: "A", Name: "www.example.net", Content: "192.0.2.1"}}),
+		"zone checks are skipped without a zone")
+}
+
+func TestCreateDNSRecord_Preflight(t *testing.T) {
+	setup(UsingDNSPreflight())
+	defer teardown()
+
+	handleTestZoneDetails()
+	zone := newFakeDNSZone(t, DNSRecord{Type: "CNAME", Name: "www.example.com", Content: "example.net", TTL: 1})
+
+	_, err := client.CreateDNSRecord(context.Background(), testZoneID, DNSRecord{Type: "A", Name: "www.example.com", Content: "192.0.2.1"})
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []FieldError{{"Type", "A record conflicts with the CNAME record at www.example.com"}}, validationErr.Errors)
+	assert.Len(t, zone.state(), 1, "the record isn't created")
+
+	_, err = client.CreateDNSRecord(context.Background(), testZoneID, DNSRecord{Type: "TXT", Name: "www", Content: "hello"})
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []FieldError{{"Type", "TXT record conflicts with the CNAME record at www.example.com"}}, validationErr.Errors,
+		"relative names are qualified with the zone name")
+
+	_, err = client.CreateDNSRecord(context.Background(), testZoneID, DNSRecord{Type: "A", Name: "api.example.com", Content: "192.0.2.1"})
+	require.NoError(t, err)
+	assert.Len(t, zone.state(), 2)
+}
+
+func TestUpdateDNSRecord_Preflight(t *testing.T) {
+	setup(UsingDNSPreflight())
+	defer teardown()
+
+	handleTestZoneDetails()
+	zone := newFakeDNSZone(t, DNSRecord{Type: "TXT", Name: "www.example.com", Content: "hello", TTL: 300})
+
+	proxied := true
+	err := client.UpdateDNSRecord(context.Background(), testZoneID, "record-1", DNSRecord{Proxied: &proxied})
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []FieldError{{"Proxied", "TXT records can't be proxied"}}, validationErr.Errors)
+
+	require.NoError(t, client.UpdateDNSRecord(context.Background(), testZoneID, "record-1", DNSRecord{Content: "bye"}))
+	assert.Equal(t, []string{"TXT www.example.com bye 300 "}, zone.state())
+}
+
+func handleTestZoneDetails() {
+	mux.HandleFunc("/zones/"+testZoneID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": {"id": "%s", "name": "example.com"}
+		}`, testZoneID)
+	})
+}
@@ -0,0 +1,190 @@
+package cloudflare
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// ErrDryRun is returned for mutating requests made in dry run mode unless the
+// plan is configured to return a synthetic success.
+var ErrDryRun = errors.New("request not sent in dry run mode")
+
+// PlannedRequest is a mutating request captured in dry run mode.
+type PlannedRequest struct {
+	Method string
+
+	// URI is the endpoint relative to the client's base URL, including any
+	// query string.
+	URI string
+
+	// Body is the request body as it would have been sent, with the values
+	// of known secret fields (such as the text of a Workers secret) redacted
+	// so that plans can be printed safely.
+	Body []byte
+}
+
+// DryRunPlan captures the mutating (POST, PUT, PATCH and DELETE) requests a
+// client would have made. Other requests are sent as normal so that code
+// which looks resources up before changing them still works.
+//
+//	plan := &cloudflare.DryRunPlan{}
+//	api, err := cloudflare.NewWithAPIToken(token, cloudflare.UsingDryRun(plan))
+//	...
+//	runMigration(ctx, api)
+//	plan.WriteTo(os.Stdout)
+type DryRunPlan struct {
+	// SyntheticSuccess makes captured requests return a successful response
+	// instead of ErrDryRun. The result is the request body (or null when
+	// there isn't a JSON body) so callers see the resource they asked for
+	// but server assigned fields such as IDs will be empty.
+	SyntheticSuccess bool
+
+	mu       sync.Mutex
+	requests []PlannedRequest
+}
+
+// Requests returns the captured requests in the order they were made.
+func (p *DryRunPlan) Requests() []PlannedRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]PlannedRequest(nil), p.requests...)
+}
+
+// Reset discards the captured requests.
+func (p *DryRunPlan) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.requests = nil
+}
+
+// Middleware returns the middleware that captures mutating requests. Use
+// UsingDryRun or ClientParams.DryRun rather than adding it directly.
+func (p *DryRunPlan) Middleware() Middleware {
+	return func(next RequestHandler) RequestHandler {
+		return func(req *MiddlewareRequest) (*MiddlewareResponse, error) {
+			if !isMutating(req.Method) {
+				return next(req)
+			}
+
+			var body []byte
+			if r := req.Request; r.Body != nil && r.Body != http.NoBody {
+				var err error
+				body, err = ioutil.ReadAll(r.Body)
+				r.Body.Close()
+				if err != nil {
+					return nil, fmt.Errorf("could not read request body: %w", err)
+				}
+			}
+
+			planned := PlannedRequest{Method: req.Method, URI: req.URI}
+			if len(body) > 0 {
+				planned.Body = []byte(redactBody(body))
+			}
+
+			p.mu.Lock()
+			p.requests = append(p.requests, planned)
+			p.mu.Unlock()
+
+			if !p.SyntheticSuccess {
+				return nil, fmt.Errorf("%s %s: %w", req.Method, req.URI, ErrDryRun)
+			}
+
+			return syntheticDryRunResponse(req, body)
+		}
+	}
+}
+
+// syntheticDryRunResponse builds a successful response envelope echoing the
+// request body as the result.
+func syntheticDryRunResponse(req *MiddlewareRequest, body []byte) (*MiddlewareResponse, error) {
+	result := json.RawMessage("null")
+	if json.Valid(body) {
+		result = body
+	}
+
+	envelope := struct {
+		Response
+		Result json.RawMessage `json:"result"`
+	}{
+		Response: Response{
+			Success:  true,
+			Errors:   []ResponseInfo{},
+			Messages: []ResponseInfo{{Message: "dry run: request not sent"}},
+		},
+		Result: result,
+	}
+
+	respBody, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errMakeRequestError, err)
+	}
+
+	return &MiddlewareResponse{
+		Response: &http.Response{
+			Status:     "200 OK",
+			StatusCode: http.StatusOK,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(bytes.NewReader(respBody)),
+			Request:    req.Request,
+		},
+		Envelope: &envelope.Response,
+	}, nil
+}
+
+// String returns the plan in the format written by WriteTo.
+func (p *DryRunPlan) String() string {
+	var b strings.Builder
+	_, _ = p.WriteTo(&b)
+	return b.String()
+}
+
+// WriteTo writes a human readable summary of the captured requests to `w`,
+// pretty printing JSON bodies.
+func (p *DryRunPlan) WriteTo(w io.Writer) (int64, error) {
+	requests := p.Requests()
+
+	var b bytes.Buffer
+	if len(requests) == 0 {
+		b.WriteString("No changes.\n")
+	} else {
+		fmt.Fprintf(&b, "%d change(s) planned:\n", len(requests))
+	}
+
+	for i, r := range requests {
+		fmt.Fprintf(&b, "\n%d. %s %s\n", i+1, r.Method, r.URI)
+
+		var indented bytes.Buffer
+		switch {
+		case len(r.Body) == 0:
+		case json.Indent(&indented, r.Body, "   ", "  ") == nil:
+			fmt.Fprintf(&b, "   %s\n", indented.String())
+		default:
+			fmt.Fprintf(&b, "   <%d byte body>\n", len(r.Body))
+		}
+	}
+
+	n, err := w.Write(b.Bytes())
+	return int64(n), err
+}
+
+// isMutating reports whether requests using `method` change state.
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
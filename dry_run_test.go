@@ -0,0 +1,142 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRun(t *testing.T) {
+	setup()
+	defer teardown()
+
+	plan := &DryRunPlan{}
+	require.NoError(t, UsingDryRun(plan)(client))
+
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records/372e67954025e0ba6aaa6d586b9e0b59", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "mutating request was sent")
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "372e67954025e0ba6aaa6d586b9e0b59", "name": "www.example.com"}}`)
+	})
+
+	record, err := client.DNSRecord(context.Background(), testZoneID, "372e67954025e0ba6aaa6d586b9e0b59")
+	require.NoError(t, err)
+	assert.Equal(t, "www.example.com", record.Name)
+
+	_, err = client.Raw(context.Background(), http.MethodPatch, "/zones/"+testZoneID+"/dns_records/372e67954025e0ba6aaa6d586b9e0b59", map[string]string{"content": "198.51.100.4"}, nil)
+	assert.ErrorIs(t, err, ErrDryRun)
+
+	// DELETE is idempotent but a dry run error mustn't be retried.
+	err = client.DeleteDNSRecord(context.Background(), testZoneID, "372e67954025e0ba6aaa6d586b9e0b59")
+	assert.ErrorIs(t, err, ErrDryRun)
+
+	assert.Equal(t, []PlannedRequest{
+		{Method: http.MethodPatch, URI: "/zones/" + testZoneID + "/dns_records/372e67954025e0ba6aaa6d586b9e0b59", Body: []byte(`{"content":"198.51.100.4"}`)},
+		{Method: http.MethodDelete, URI: "/zones/" + testZoneID + "/dns_records/372e67954025e0ba6aaa6d586b9e0b59"},
+	}, plan.Requests())
+
+	want := `2 change(s) planned:
+
+1. PATCH /zones/` + testZoneID + `/dns_records/372e67954025e0ba6aaa6d586b9e0b59
+   {
+     "content": "198.51.100.4"
+   }
+
+2. DELETE /zones/` + testZoneID + `/dns_records/372e67954025e0ba6aaa6d586b9e0b59
+`
+	assert.Equal(t, want, plan.String())
+
+	plan.Reset()
+	assert.Equal(t, "No changes.\n", plan.String())
+}
+
+func TestDryRun_MiddlewareOrder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var seen []string
+	recorder := func(next RequestHandler) RequestHandler {
+		return func(req *MiddlewareRequest) (*MiddlewareResponse, error) {
+			seen = append(seen, req.Method)
+			return next(req)
+		}
+	}
+
+	// the dry run is added first but still runs after the other middleware.
+	plan := &DryRunPlan{}
+	require.NoError(t, UsingDryRun(plan)(client))
+	require.NoError(t, UsingMiddleware(recorder)(client))
+
+	err := client.DeleteDNSRecord(context.Background(), testZoneID, "372e67954025e0ba6aaa6d586b9e0b59")
+	assert.ErrorIs(t, err, ErrDryRun)
+	assert.Equal(t, []string{http.MethodDelete}, seen)
+	assert.Len(t, plan.Requests(), 1)
+}
+
+func TestDryRun_SyntheticSuccess(t *testing.T) {
+	setup()
+	defer teardown()
+
+	plan := &DryRunPlan{SyntheticSuccess: true}
+	require.NoError(t, UsingDryRun(plan)(client))
+
+	res, err := client.CreateDNSRecord(context.Background(), testZoneID, DNSRecord{Type: "A", Name: "www", Content: "198.51.100.4"})
+	require.NoError(t, err)
+	assert.True(t, res.Success)
+	assert.Equal(t, "www", res.Result.Name)
+	assert.Equal(t, "198.51.100.4", res.Result.Content)
+	assert.Empty(t, res.Result.ID)
+
+	require.Len(t, plan.Requests(), 1)
+	assert.Equal(t, http.MethodPost, plan.Requests()[0].Method)
+}
+
+func TestDryRun_RedactsSecrets(t *testing.T) {
+	setup(UsingAccount(testAccountID))
+	defer teardown()
+
+	plan := &DryRunPlan{}
+	require.NoError(t, UsingDryRun(plan)(client))
+
+	_, err := client.SetWorkersSecret(context.Background(), "my-script", &WorkersPutSecretRequest{
+		Name: "API_KEY",
+		Text: "super-secret-value",
+		Type: WorkerSecretTextBindingType,
+	})
+	assert.ErrorIs(t, err, ErrDryRun)
+
+	require.Len(t, plan.Requests(), 1)
+	assert.NotContains(t, string(plan.Requests()[0].Body), "super-secret-value")
+	assert.NotContains(t, plan.String(), "super-secret-value")
+	assert.Contains(t, plan.String(), `"text": "[REDACTED]"`)
+	assert.Contains(t, plan.String(), `"name": "API_KEY"`)
+}
+
+func TestDryRun_ExperimentalClient(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/zones/"+testZoneID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "mutating request was sent")
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"id": %q, "name": "example.com"}}`, testZoneID)
+	})
+
+	baseURL, _ := url.Parse(server.URL)
+	plan := &DryRunPlan{}
+	c, err := NewExperimental(&ClientParams{BaseURL: baseURL, Token: "deadbeef", DryRun: plan})
+	require.NoError(t, err)
+
+	zone, err := c.Zones.Get(context.Background(), ZoneIdentifier(testZoneID))
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", zone.Name)
+
+	_, err = c.Call(context.Background(), http.MethodDelete, "/zones/"+testZoneID, nil)
+	assert.ErrorIs(t, err, ErrDryRun)
+	assert.Equal(t, []PlannedRequest{{Method: http.MethodDelete, URI: "/zones/" + testZoneID}}, plan.Requests())
+}
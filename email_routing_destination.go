@@ -81,6 +81,33 @@ func (api *API) ListEmailRoutingDestinationAddresses(ctx context.Context, rc *Re
 	return addresses, &eResponse.ResultInfo, nil
 }
 
+// ListEmailRoutingDestinationAddressesIterator returns an Iterator over the
+// destination addresses of an account, fetching each page of results as it is
+// needed. `params.Page` and `params.PerPage` control where iteration starts
+// and the size of each page.
+//
+// API reference: https://api.cloudflare.com/#email-routing-destination-addresses-list-destination-addresses
+func (api *API) ListEmailRoutingDestinationAddressesIterator(ctx context.Context, rc *ResourceContainer, params ListEmailRoutingAddressParameters) *Iterator[EmailRoutingDestinationAddress] {
+	start := ResultInfo{Page: params.Page, PerPage: params.PerPage}
+	if start.PerPage < 1 {
+		start.PerPage = 50
+	}
+	if start.Page < 1 {
+		start.Page = 1
+	}
+
+	return newIterator(ctx, start, func(ctx context.Context, info ResultInfo) ([]EmailRoutingDestinationAddress, ResultInfo, error) {
+		params.ResultInfo = info
+
+		items, resultInfo, err := api.ListEmailRoutingDestinationAddresses(ctx, rc, params)
+		if err != nil {
+			return nil, ResultInfo{}, err
+		}
+
+		return items, *resultInfo, nil
+	})
+}
+
 // CreateEmailRoutingDestinationAddress Create a destination address to forward your emails to.
 // Destination addresses need to be verified before they become active.
 //
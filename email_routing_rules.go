@@ -125,6 +125,32 @@ func (api *API) ListEmailRoutingRules(ctx context.Context, rc *ResourceContainer
 	return rules, &rResponse.ResultInfo, nil
 }
 
+// ListEmailRoutingRulesIterator returns an Iterator over the routing rules of
+// a zone, fetching each page of results as it is needed. `params.Page` and
+// `params.PerPage` control where iteration starts and the size of each page.
+//
+// API reference: https://api.cloudflare.com/#email-routing-routing-rules-list-routing-rules
+func (api *API) ListEmailRoutingRulesIterator(ctx context.Context, rc *ResourceContainer, params ListEmailRoutingRulesParameters) *Iterator[EmailRoutingRule] {
+	start := ResultInfo{Page: params.Page, PerPage: params.PerPage}
+	if start.PerPage < 1 {
+		start.PerPage = 50
+	}
+	if start.Page < 1 {
+		start.Page = 1
+	}
+
+	return newIterator(ctx, start, func(ctx context.Context, info ResultInfo) ([]EmailRoutingRule, ResultInfo, error) {
+		params.ResultInfo = info
+
+		items, resultInfo, err := api.ListEmailRoutingRules(ctx, rc, params)
+		if err != nil {
+			return nil, ResultInfo{}, err
+		}
+
+		return items, *resultInfo, nil
+	})
+}
+
 // CreateEmailRoutingRule Rules consist of a set of criteria for matching emails (such as an email being sent to a specific custom email address) plus a set of actions to take on the email (like forwarding it to a specific destination address).
 //
 // API reference: https://api.cloudflare.com/#email-routing-routing-rules-create-routing-rule
@@ -1,8 +1,10 @@
 package cloudflare
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"regexp"
 	"strings"
 
 	"errors"
@@ -12,6 +14,7 @@ const (
 	errEmptyCredentials                       = "invalid credentials: key & email must not be empty" //nolint:gosec,unused
 	errEmptyAPIToken                          = "invalid credentials: API Token must not be empty"   //nolint:gosec,unused
 	errInternalServiceError                   = "internal service error"
+	errRateLimitRetriesExceeded               = "exceeded available rate limit retries"
 	errMakeRequestError                       = "error from makeRequest"
 	errUnmarshalError                         = "error unmarshalling the JSON response"
 	errUnmarshalErrorBody                     = "error unmarshalling the JSON response error body"
@@ -70,6 +73,9 @@ type Error struct {
 	// ErrorMessages is a list of all the error codes.
 	ErrorMessages []string
 
+	// Messages are the informational messages that accompanied the errors.
+	Messages []ResponseInfo
+
 	// RayID is the internal identifier for the request that was made.
 	RayID string
 }
@@ -127,6 +133,29 @@ func (e RequestError) Type() ErrorType {
 	return e.cloudflareError.Type
 }
 
+func (e RequestError) Messages() []ResponseInfo {
+	return e.cloudflareError.Messages
+}
+
+func (e RequestError) Unwrap() error {
+	if e.cloudflareError == nil {
+		return nil
+	}
+	return e.cloudflareError
+}
+
+func (e RequestError) As(target interface{}) bool {
+	switch t := target.(type) {
+	case *RequestError:
+		*t = e
+		return true
+	case **RequestError:
+		*t = &e
+		return true
+	}
+	return false
+}
+
 func NewRequestError(e *Error) RequestError {
 	return RequestError{
 		cloudflareError: e,
@@ -137,6 +166,10 @@ func NewRequestError(e *Error) RequestError {
 // slow down.
 type RatelimitError struct {
 	cloudflareError *Error
+
+	// Quota is the rate limiting state reported by the API in the response
+	// headers.
+	Quota RatelimitQuota
 }
 
 func (e RatelimitError) Error() string {
@@ -167,12 +200,68 @@ func (e RatelimitError) Type() ErrorType {
 	return e.cloudflareError.Type
 }
 
+func (e RatelimitError) Messages() []ResponseInfo {
+	return e.cloudflareError.Messages
+}
+
+func (e RatelimitError) Unwrap() error {
+	if e.cloudflareError == nil {
+		return nil
+	}
+	return e.cloudflareError
+}
+
+func (e RatelimitError) As(target interface{}) bool {
+	switch t := target.(type) {
+	case *RatelimitError:
+		*t = e
+		return true
+	case **RatelimitError:
+		*t = &e
+		return true
+	}
+	return false
+}
+
 func NewRatelimitError(e *Error) RatelimitError {
 	return RatelimitError{
 		cloudflareError: e,
 	}
 }
 
+// newRatelimitErrorFromResponse builds a RatelimitError from a HTTP 429
+// response, falling back to a generic message when the body doesn't contain
+// any errors.
+func newRatelimitErrorFromResponse(resp *http.Response, body []byte, quota RatelimitQuota) *RatelimitError {
+	errBody := &Response{}
+	_ = json.Unmarshal(body, &errBody)
+
+	errs := errBody.Errors
+	if len(errs) == 0 {
+		errs = []ResponseInfo{{Message: errRateLimitRetriesExceeded}}
+	}
+
+	errCodes := make([]int, 0, len(errs))
+	errMsgs := make([]string, 0, len(errs))
+	for _, e := range errs {
+		errCodes = append(errCodes, e.Code)
+		errMsgs = append(errMsgs, e.Message)
+	}
+
+	return &RatelimitError{
+		cloudflareError: &Error{
+			Type:          ErrorTypeRateLimit,
+			StatusCode:    resp.StatusCode,
+			RayID:         resp.Header.Get("cf-ray"),
+			Errors:        errs,
+			ErrorCodes:    errCodes,
+			ErrorMessages: errMsgs,
+			Messages:      errBody.Messages,
+		},
+		Quota: quota,
+	}
+}
+
 // ServiceError is a handler for 5xx errors returned to the client.
 type ServiceError struct {
 	cloudflareError *Error
@@ -206,6 +295,29 @@ func (e ServiceError) Type() ErrorType {
 	return e.cloudflareError.Type
 }
 
+func (e ServiceError) Messages() []ResponseInfo {
+	return e.cloudflareError.Messages
+}
+
+func (e ServiceError) Unwrap() error {
+	if e.cloudflareError == nil {
+		return nil
+	}
+	return e.cloudflareError
+}
+
+func (e ServiceError) As(target interface{}) bool {
+	switch t := target.(type) {
+	case *ServiceError:
+		*t = e
+		return true
+	case **ServiceError:
+		*t = &e
+		return true
+	}
+	return false
+}
+
 func NewServiceError(e *Error) ServiceError {
 	return ServiceError{
 		cloudflareError: e,
@@ -245,6 +357,29 @@ func (e AuthenticationError) Type() ErrorType {
 	return e.cloudflareError.Type
 }
 
+func (e AuthenticationError) Messages() []ResponseInfo {
+	return e.cloudflareError.Messages
+}
+
+func (e AuthenticationError) Unwrap() error {
+	if e.cloudflareError == nil {
+		return nil
+	}
+	return e.cloudflareError
+}
+
+func (e AuthenticationError) As(target interface{}) bool {
+	switch t := target.(type) {
+	case *AuthenticationError:
+		*t = e
+		return true
+	case **AuthenticationError:
+		*t = &e
+		return true
+	}
+	return false
+}
+
 func NewAuthenticationError(e *Error) AuthenticationError {
 	return AuthenticationError{
 		cloudflareError: e,
@@ -284,6 +419,29 @@ func (e AuthorizationError) Type() ErrorType {
 	return e.cloudflareError.Type
 }
 
+func (e AuthorizationError) Messages() []ResponseInfo {
+	return e.cloudflareError.Messages
+}
+
+func (e AuthorizationError) Unwrap() error {
+	if e.cloudflareError == nil {
+		return nil
+	}
+	return e.cloudflareError
+}
+
+func (e AuthorizationError) As(target interface{}) bool {
+	switch t := target.(type) {
+	case *AuthorizationError:
+		*t = e
+		return true
+	case **AuthorizationError:
+		*t = &e
+		return true
+	}
+	return false
+}
+
 func NewAuthorizationError(e *Error) AuthorizationError {
 	return AuthorizationError{
 		cloudflareError: e,
@@ -323,6 +481,29 @@ func (e NotFoundError) Type() ErrorType {
 	return e.cloudflareError.Type
 }
 
+func (e NotFoundError) Messages() []ResponseInfo {
+	return e.cloudflareError.Messages
+}
+
+func (e NotFoundError) Unwrap() error {
+	if e.cloudflareError == nil {
+		return nil
+	}
+	return e.cloudflareError
+}
+
+func (e NotFoundError) As(target interface{}) bool {
+	switch t := target.(type) {
+	case *NotFoundError:
+		*t = e
+		return true
+	case **NotFoundError:
+		*t = &e
+		return true
+	}
+	return false
+}
+
 func NewNotFoundError(e *Error) NotFoundError {
 	return NotFoundError{
 		cloudflareError: e,
@@ -364,3 +545,54 @@ func (e *Error) ErrorMessageContains(s string) bool {
 	}
 	return false
 }
+
+// Sentinel errors for well-known API error codes. The error types returned
+// for API responses unwrap to an *Error that matches these using errors.Is,
+// so callers don't need to compare codes or messages themselves:
+//
+//	if errors.Is(err, cloudflare.ErrRecordAlreadyExists) {
+//		// ...
+//	}
+var (
+	ErrRecordAlreadyExists = errors.New("record already exists")
+	ErrZoneNotFound        = errors.New("zone not found")
+	ErrQuotaExceeded       = errors.New("quota exceeded")
+	ErrInvalidToken        = errors.New("invalid API token")
+	ErrRateLimited         = errors.New("rate limited")
+)
+
+// zoneRouteNotFound matches the message returned alongside code 7003 when a
+// zone identifier can't be routed.
+var zoneRouteNotFound = regexp.MustCompile(`^Could not route to (/client/v4)?/zones/[^/]+, perhaps your object identifier is invalid\?$`)
+
+// errorCodeSentinels maps each sentinel error to the API error codes it
+// represents.
+var errorCodeSentinels = map[error][]int{
+	ErrRecordAlreadyExists: {81053, 81057, 81058},
+	ErrZoneNotFound:        {1001, 1003},
+	ErrQuotaExceeded:       {81045},
+	ErrInvalidToken:        {1000, 6111, 9103, 9109},
+}
+
+// Is reports whether `target` is a sentinel error matching one of the error
+// codes in the response.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrRateLimited:
+		return e.Type == ErrorTypeRateLimit || e.StatusCode == http.StatusTooManyRequests
+	case ErrZoneNotFound:
+		for _, err := range e.Errors {
+			if err.Code == 7003 && zoneRouteNotFound.MatchString(err.Message) {
+				return true
+			}
+		}
+	}
+
+	for _, code := range errorCodeSentinels[target] {
+		if e.InternalErrorCodeIs(code) {
+			return true
+		}
+	}
+
+	return false
+}
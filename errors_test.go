@@ -1,9 +1,14 @@
 package cloudflare
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestError_Error(t *testing.T) {
@@ -50,3 +55,103 @@ func TestError_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestError_Is(t *testing.T) {
+	tests := map[string]struct {
+		err    *Error
+		target error
+		want   bool
+	}{
+		"record already exists": {
+			err:    &Error{StatusCode: 400, ErrorCodes: []int{81057}},
+			target: ErrRecordAlreadyExists,
+			want:   true,
+		},
+		"zone not found by code": {
+			err:    &Error{StatusCode: 400, ErrorCodes: []int{1001}},
+			target: ErrZoneNotFound,
+			want:   true,
+		},
+		"zone not found by route": {
+			err: &Error{StatusCode: 404, ErrorCodes: []int{7003}, Errors: []ResponseInfo{{
+				Code:    7003,
+				Message: "Could not route to /zones/023e105f4ecef8ad9ca31a8372d0c353, perhaps your object identifier is invalid?",
+			}}},
+			target: ErrZoneNotFound,
+			want:   true,
+		},
+		"unknown route below a zone": {
+			err: &Error{StatusCode: 404, ErrorCodes: []int{7003}, Errors: []ResponseInfo{{
+				Code:    7003,
+				Message: "Could not route to /zones/023e105f4ecef8ad9ca31a8372d0c353/dns_records/foo, perhaps your object identifier is invalid?",
+			}}},
+			target: ErrZoneNotFound,
+			want:   false,
+		},
+		"rate limited": {
+			err:    &Error{StatusCode: 429, Type: ErrorTypeRateLimit},
+			target: ErrRateLimited,
+			want:   true,
+		},
+		"different code": {
+			err:    &Error{StatusCode: 400, ErrorCodes: []int{1004}},
+			target: ErrRecordAlreadyExists,
+			want:   false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := error(&RequestError{cloudflareError: tc.err})
+			assert.Equal(t, tc.want, errors.Is(err, tc.target))
+			assert.Equal(t, tc.want, errors.Is(fmt.Errorf("wrapped: %w", err), tc.target))
+		})
+	}
+}
+
+func TestError_As(t *testing.T) {
+	cfErr := &Error{StatusCode: 404, RayID: "7a5d8d4e8b2b0b4f-LHR", Type: ErrorTypeNotFound}
+
+	for name, err := range map[string]error{
+		"pointer": &NotFoundError{cloudflareError: cfErr},
+		"value":   NewNotFoundError(cfErr),
+	} {
+		t.Run(name, func(t *testing.T) {
+			var ptr *NotFoundError
+			require.True(t, errors.As(err, &ptr))
+			assert.Equal(t, "7a5d8d4e8b2b0b4f-LHR", ptr.RayID())
+
+			var val NotFoundError
+			require.True(t, errors.As(err, &val))
+			assert.Equal(t, "7a5d8d4e8b2b0b4f-LHR", val.RayID())
+
+			var base *Error
+			require.True(t, errors.As(err, &base))
+			assert.Equal(t, 404, base.StatusCode)
+
+			var requestErr *RequestError
+			assert.False(t, errors.As(err, &requestErr))
+		})
+	}
+}
+
+func TestError_ResponseDetailsAreKept(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		w.Header().Set("cf-ray", "7a5d8d4e8b2b0b4f-LHR")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"success": false, "errors": [{"code": 81057, "message": "Record already exists."}], "messages": [{"code": 10000, "message": "Consider using an update instead"}], "result": null}`)
+	})
+
+	_, err := client.CreateDNSRecord(context.Background(), testZoneID, DNSRecord{Type: "A", Name: "example.com", Content: "198.51.100.4"})
+	assert.ErrorIs(t, err, ErrRecordAlreadyExists)
+	assert.NotErrorIs(t, err, ErrZoneNotFound)
+
+	var requestErr *RequestError
+	require.ErrorAs(t, err, &requestErr)
+	assert.Equal(t, "7a5d8d4e8b2b0b4f-LHR", requestErr.RayID())
+	assert.Equal(t, []ResponseInfo{{Code: 10000, Message: "Consider using an update instead"}}, requestErr.Messages())
+}
@@ -148,6 +148,32 @@ func (api *API) Filters(ctx context.Context, rc *ResourceContainer, params Filte
 	return filters, &fResponse.ResultInfo, nil
 }
 
+// FiltersIterator returns an Iterator over all filters for a zone, fetching
+// each page of results as it is needed. `params.Page` and `params.PerPage`
+// control where iteration starts and the size of each page.
+//
+// API reference: https://developers.cloudflare.com/firewall/api/cf-filters/get/#get-all-filters
+func (api *API) FiltersIterator(ctx context.Context, rc *ResourceContainer, params FilterListParams) *Iterator[Filter] {
+	start := ResultInfo{Page: params.Page, PerPage: params.PerPage}
+	if start.PerPage < 1 {
+		start.PerPage = 50
+	}
+	if start.Page < 1 {
+		start.Page = 1
+	}
+
+	return newIterator(ctx, start, func(ctx context.Context, info ResultInfo) ([]Filter, ResultInfo, error) {
+		params.ResultInfo = info
+
+		filters, resultInfo, err := api.Filters(ctx, rc, params)
+		if err != nil {
+			return nil, ResultInfo{}, err
+		}
+
+		return filters, *resultInfo, nil
+	})
+}
+
 // CreateFilters creates new filters.
 //
 // API reference: https://developers.cloudflare.com/firewall/api/cf-filters/post/
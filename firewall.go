@@ -62,6 +62,16 @@ func (api *API) ListUserAccessRules(ctx context.Context, accessRule AccessRule,
 	return api.listAccessRules(ctx, "/user", accessRule, page)
 }
 
+// ListUserAccessRulesIterator returns an Iterator over all access rules for the logged-in user,
+// fetching each page of results as it is needed.
+//
+// This takes an AccessRule to allow filtering of the results returned.
+//
+// API reference: https://api.cloudflare.com/#user-level-firewall-access-rule-list-access-rules
+func (api *API) ListUserAccessRulesIterator(ctx context.Context, accessRule AccessRule) *Iterator[AccessRule] {
+	return api.listAccessRulesIterator(ctx, "/user", accessRule)
+}
+
 // CreateUserAccessRule creates a firewall access rule for the logged-in user.
 //
 // API reference: https://api.cloudflare.com/#user-level-firewall-access-rule-create-access-rule
@@ -102,6 +112,17 @@ func (api *API) ListZoneAccessRules(ctx context.Context, zoneID string, accessRu
 	return api.listAccessRules(ctx, fmt.Sprintf("/zones/%s", zoneID), accessRule, page)
 }
 
+// ListZoneAccessRulesIterator returns an Iterator over all access rules for the given zone
+// identifier,
+// fetching each page of results as it is needed.
+//
+// This takes an AccessRule to allow filtering of the results returned.
+//
+// API reference: https://api.cloudflare.com/#firewall-access-rule-for-a-zone-list-access-rules
+func (api *API) ListZoneAccessRulesIterator(ctx context.Context, zoneID string, accessRule AccessRule) *Iterator[AccessRule] {
+	return api.listAccessRulesIterator(ctx, fmt.Sprintf("/zones/%s", zoneID), accessRule)
+}
+
 // CreateZoneAccessRule creates a firewall access rule for the given zone
 // identifier.
 //
@@ -143,6 +164,17 @@ func (api *API) ListAccountAccessRules(ctx context.Context, accountID string, ac
 	return api.listAccessRules(ctx, fmt.Sprintf("/accounts/%s", accountID), accessRule, page)
 }
 
+// ListAccountAccessRulesIterator returns an Iterator over all access rules for the given
+// account identifier,
+// fetching each page of results as it is needed.
+//
+// This takes an AccessRule to allow filtering of the results returned.
+//
+// API reference: https://api.cloudflare.com/#account-level-firewall-access-rule-list-access-rules
+func (api *API) ListAccountAccessRulesIterator(ctx context.Context, accountID string, accessRule AccessRule) *Iterator[AccessRule] {
+	return api.listAccessRulesIterator(ctx, fmt.Sprintf("/accounts/%s", accountID), accessRule)
+}
+
 // CreateAccountAccessRule creates a firewall access rule for the given
 // account identifier.
 //
@@ -214,6 +246,17 @@ func (api *API) listAccessRules(ctx context.Context, prefix string, accessRule A
 	return response, nil
 }
 
+func (api *API) listAccessRulesIterator(ctx context.Context, prefix string, accessRule AccessRule) *Iterator[AccessRule] {
+	return newIterator(ctx, ResultInfo{Page: 1}, func(ctx context.Context, info ResultInfo) ([]AccessRule, ResultInfo, error) {
+		r, err := api.listAccessRules(ctx, prefix, accessRule, info.Page)
+		if err != nil {
+			return nil, ResultInfo{}, err
+		}
+
+		return r.Result, r.ResultInfo, nil
+	})
+}
+
 func (api *API) createAccessRule(ctx context.Context, prefix string, accessRule AccessRule) (*AccessRuleResponse, error) {
 	uri := fmt.Sprintf("%s/firewall/access_rules/rules", prefix)
 	res, err := api.makeRequestContext(ctx, http.MethodPost, uri, accessRule)
@@ -113,6 +113,32 @@ func (api *API) FirewallRules(ctx context.Context, rc *ResourceContainer, params
 	return firewallRules, &fResponse.ResultInfo, nil
 }
 
+// FirewallRulesIterator returns an Iterator over all firewall rules of a
+// zone, fetching each page of results as it is needed. `params.Page` and
+// `params.PerPage` control where iteration starts and the size of each page.
+//
+// API reference: https://developers.cloudflare.com/firewall/api/cf-firewall-rules/get/#get-all-rules
+func (api *API) FirewallRulesIterator(ctx context.Context, rc *ResourceContainer, params FirewallRuleListParams) *Iterator[FirewallRule] {
+	start := ResultInfo{Page: params.Page, PerPage: params.PerPage}
+	if start.PerPage < 1 {
+		start.PerPage = 50
+	}
+	if start.Page < 1 {
+		start.Page = 1
+	}
+
+	return newIterator(ctx, start, func(ctx context.Context, info ResultInfo) ([]FirewallRule, ResultInfo, error) {
+		params.ResultInfo = info
+
+		items, resultInfo, err := api.FirewallRules(ctx, rc, params)
+		if err != nil {
+			return nil, ResultInfo{}, err
+		}
+
+		return items, *resultInfo, nil
+	})
+}
+
 // FirewallRule returns a single firewall rule based on the ID.
 //
 // API reference: https://developers.cloudflare.com/firewall/api/cf-firewall-rules/get/#get-by-rule-id
@@ -217,6 +217,16 @@ func (api *API) ListImages(ctx context.Context, accountID string, pageOpts Pagin
 	return imagesListResponse.Result.Images, nil
 }
 
+// ListImagesIterator returns an Iterator over all images, fetching each page
+// of results as it is needed.
+//
+// API Reference: https://api.cloudflare.com/#cloudflare-images-list-images
+func (api *API) ListImagesIterator(ctx context.Context, accountID string, pageOpts PaginationOptions) *Iterator[Image] {
+	return newPageSizeIterator(ctx, pageOpts, 100, func(ctx context.Context, pageOpts PaginationOptions) ([]Image, error) {
+		return api.ListImages(ctx, accountID, pageOpts)
+	})
+}
+
 // ImageDetails gets the details of an uploaded image.
 //
 // API Reference: https://api.cloudflare.com/#cloudflare-images-image-details
@@ -249,6 +259,17 @@ func (api *API) BaseImage(ctx context.Context, accountID string, id string) ([]b
 	return res, nil
 }
 
+// BaseImageStream gets the base image used to derive variants like BaseImage
+// but returns the body without reading it into memory. The caller is
+// responsible for closing it.
+//
+// API Reference: https://api.cloudflare.com/#cloudflare-images-base-image
+func (api *API) BaseImageStream(ctx context.Context, accountID string, id string) (io.ReadCloser, error) {
+	uri := fmt.Sprintf("/accounts/%s/images/v1/%s/blob", accountID, id)
+
+	return api.makeRequestStream(ctx, http.MethodGet, uri, nil, nil)
+}
+
 // DeleteImage deletes an image.
 //
 // API Reference: https://api.cloudflare.com/#cloudflare-images-delete-image
@@ -0,0 +1,120 @@
+package cloudflare
+
+import (
+	"context"
+	"io"
+)
+
+//go:generate go run ./internal/mockgen -source interfaces.go -out cfmock/mocks.go
+
+// The interfaces below describe narrow slices of the API surface so that
+// code depending on a single product can accept them rather than *API, and
+// substitute a test double in its tests. Mocks for every interface in this
+// file are generated into the cfmock package; run `go generate` after
+// changing them.
+
+// ZonesAPI is the subset of *API used to manage zones.
+type ZonesAPI interface {
+	CreateZone(ctx context.Context, name string, jumpstart bool, account Account, zoneType string) (Zone, error)
+	ZoneIDByName(zoneName string) (string, error)
+	ListZones(ctx context.Context, z ...string) ([]Zone, error)
+	ListZonesContext(ctx context.Context, opts ...ReqOption) (ZonesResponse, error)
+	ZoneDetails(ctx context.Context, zoneID string) (Zone, error)
+	EditZone(ctx context.Context, zoneID string, zoneOpts ZoneOptions) (Zone, error)
+	DeleteZone(ctx context.Context, zoneID string) (ZoneID, error)
+	PurgeCache(ctx context.Context, zoneID string, pcr PurgeCacheRequest) (PurgeCacheResponse, error)
+	PurgeEverything(ctx context.Context, zoneID string) (PurgeCacheResponse, error)
+	ZoneExport(ctx context.Context, zoneID string) (string, error)
+}
+
+// DNSRecordsAPI is the subset of *API used to manage the DNS records of a
+// zone.
+type DNSRecordsAPI interface {
+	CreateDNSRecord(ctx context.Context, zoneID string, rr DNSRecord) (*DNSRecordResponse, error)
+	DNSRecords(ctx context.Context, zoneID string, rr DNSRecord) ([]DNSRecord, error)
+	DNSRecordsIterator(ctx context.Context, zoneID string, rr DNSRecord) *Iterator[DNSRecord]
+	ListDNSRecords(ctx context.Context, rc *ResourceContainer, params DNSListParams) ([]DNSRecord, *ResultInfo, error)
+	ListDNSRecordsIterator(ctx context.Context, rc *ResourceContainer, params DNSListParams) *Iterator[DNSRecord]
+	DNSRecord(ctx context.Context, zoneID, recordID string) (DNSRecord, error)
+	UpdateDNSRecord(ctx context.Context, zoneID, recordID string, rr DNSRecord) error
+	DeleteDNSRecord(ctx context.Context, zoneID, recordID string) error
+	ImportZoneFile(ctx context.Context, rc *ResourceContainer, params ImportZoneFileParams) (ImportZoneFileResult, error)
+}
+
+// WorkersKVAPI is the subset of *API used to manage Workers KV namespaces
+// and their keys.
+type WorkersKVAPI interface {
+	CreateWorkersKVNamespace(ctx context.Context, req *WorkersKVNamespaceRequest) (WorkersKVNamespaceResponse, error)
+	ListWorkersKVNamespaces(ctx context.Context) ([]WorkersKVNamespace, error)
+	DeleteWorkersKVNamespace(ctx context.Context, namespaceID string) (Response, error)
+	UpdateWorkersKVNamespace(ctx context.Context, namespaceID string, req *WorkersKVNamespaceRequest) (Response, error)
+	WriteWorkersKV(ctx context.Context, namespaceID, key string, value []byte) (Response, error)
+	WriteWorkersKVBulk(ctx context.Context, namespaceID string, kvs WorkersKVBulkWriteRequest) (Response, error)
+	ReadWorkersKV(ctx context.Context, namespaceID, key string) ([]byte, error)
+	ReadWorkersKVStream(ctx context.Context, namespaceID, key string) (io.ReadCloser, error)
+	DeleteWorkersKV(ctx context.Context, namespaceID, key string) (Response, error)
+	DeleteWorkersKVBulk(ctx context.Context, namespaceID string, keys []string) (Response, error)
+	ListWorkersKVs(ctx context.Context, namespaceID string) (ListStorageKeysResponse, error)
+	ListWorkersKVsWithOptions(ctx context.Context, namespaceID string, o ListWorkersKVsOptions) (ListStorageKeysResponse, error)
+}
+
+// RulesetsAPI is the subset of *API used to manage zone and account
+// rulesets.
+type RulesetsAPI interface {
+	ListZoneRulesets(ctx context.Context, zoneID string) ([]Ruleset, error)
+	ListAccountRulesets(ctx context.Context, accountID string) ([]Ruleset, error)
+	GetZoneRuleset(ctx context.Context, zoneID, rulesetID string) (Ruleset, error)
+	GetAccountRuleset(ctx context.Context, accountID, rulesetID string) (Ruleset, error)
+	CreateZoneRuleset(ctx context.Context, zoneID string, ruleset Ruleset) (Ruleset, error)
+	CreateAccountRuleset(ctx context.Context, accountID string, ruleset Ruleset) (Ruleset, error)
+	DeleteZoneRuleset(ctx context.Context, zoneID, rulesetID string) error
+	DeleteAccountRuleset(ctx context.Context, accountID, rulesetID string) error
+	UpdateZoneRuleset(ctx context.Context, zoneID, rulesetID, description string, rules []RulesetRule) (Ruleset, error)
+	UpdateAccountRuleset(ctx context.Context, accountID, rulesetID, description string, rules []RulesetRule) (Ruleset, error)
+	GetZoneRulesetPhase(ctx context.Context, zoneID, rulesetPhase string) (Ruleset, error)
+	GetAccountRulesetPhase(ctx context.Context, accountID, rulesetPhase string) (Ruleset, error)
+	UpdateZoneRulesetPhase(ctx context.Context, zoneID, rulesetPhase string, ruleset Ruleset) (Ruleset, error)
+	UpdateAccountRulesetPhase(ctx context.Context, accountID, rulesetPhase string, ruleset Ruleset) (Ruleset, error)
+}
+
+// ListsAPI is the subset of *API used to manage account lists and their
+// items.
+type ListsAPI interface {
+	ListLists(ctx context.Context, rc *ResourceContainer, params ListListsParams) ([]List, error)
+	CreateList(ctx context.Context, rc *ResourceContainer, params ListCreateParams) (List, error)
+	GetList(ctx context.Context, rc *ResourceContainer, listID string) (List, error)
+	UpdateList(ctx context.Context, rc *ResourceContainer, params ListUpdateParams) (List, error)
+	DeleteList(ctx context.Context, rc *ResourceContainer, listID string) (ListDeleteResponse, error)
+	ListListItems(ctx context.Context, rc *ResourceContainer, params ListListItemsParams) ([]ListItem, error)
+	ListListItemsIterator(ctx context.Context, rc *ResourceContainer, params ListListItemsParams) *Iterator[ListItem]
+	GetListItem(ctx context.Context, rc *ResourceContainer, listID, itemID string) (ListItem, error)
+	CreateListItem(ctx context.Context, rc *ResourceContainer, params ListCreateItemParams) ([]ListItem, error)
+	CreateListItems(ctx context.Context, rc *ResourceContainer, params ListCreateItemsParams) ([]ListItem, error)
+	ReplaceListItems(ctx context.Context, rc *ResourceContainer, params ListReplaceItemsParams) ([]ListItem, error)
+	DeleteListItems(ctx context.Context, rc *ResourceContainer, params ListDeleteItemsParams) ([]ListItem, error)
+	GetListBulkOperation(ctx context.Context, rc *ResourceContainer, ID string) (ListBulkOperation, error)
+}
+
+// TunnelsAPI is the subset of *API used to manage Cloudflare Tunnels.
+type TunnelsAPI interface {
+	Tunnels(ctx context.Context, rc *ResourceContainer, params TunnelListParams) ([]Tunnel, error)
+	TunnelsIterator(ctx context.Context, rc *ResourceContainer, params TunnelListParams) *Iterator[Tunnel]
+	Tunnel(ctx context.Context, rc *ResourceContainer, tunnelID string) (Tunnel, error)
+	CreateTunnel(ctx context.Context, rc *ResourceContainer, params TunnelCreateParams) (Tunnel, error)
+	UpdateTunnel(ctx context.Context, rc *ResourceContainer, params TunnelUpdateParams) (Tunnel, error)
+	DeleteTunnel(ctx context.Context, rc *ResourceContainer, tunnelID string) error
+	TunnelConnections(ctx context.Context, rc *ResourceContainer, tunnelID string) ([]Connection, error)
+	CleanupTunnelConnections(ctx context.Context, rc *ResourceContainer, tunnelID string) error
+	TunnelToken(ctx context.Context, rc *ResourceContainer, tunnelID string) (string, error)
+	GetTunnelConfiguration(ctx context.Context, rc *ResourceContainer, tunnelID string) (TunnelConfigurationResult, error)
+	UpdateTunnelConfiguration(ctx context.Context, rc *ResourceContainer, params TunnelConfigurationParams) (TunnelConfigurationResult, error)
+}
+
+var (
+	_ ZonesAPI      = (*API)(nil)
+	_ DNSRecordsAPI = (*API)(nil)
+	_ WorkersKVAPI  = (*API)(nil)
+	_ RulesetsAPI   = (*API)(nil)
+	_ ListsAPI      = (*API)(nil)
+	_ TunnelsAPI    = (*API)(nil)
+)
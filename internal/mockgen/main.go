@@ -0,0 +1,349 @@
+// Command mockgen generates mock implementations of the interfaces declared
+// in a single Go source file.
+//
+// For every interface a struct of the same name is emitted with one function
+// field per method, named after the method with a Func suffix. Calling a
+// method records its arguments and delegates to the function field, or
+// returns cfmock.ErrNotMocked when the field is unset. Methods returning an
+// iterator return one whose Err method reports cfmock.ErrNotMocked instead.
+//
+//	go run ./internal/mockgen -source interfaces.go -out cfmock/mocks.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	var (
+		source     = flag.String("source", "", "Go source file declaring the interfaces")
+		out        = flag.String("out", "", "file to write the mocks to, defaults to stdout")
+		pkg        = flag.String("package", "cfmock", "package name of the generated file")
+		importPath = flag.String("import", "github.com/cloudflare/cloudflare-go", "import path of the source package")
+	)
+	flag.Parse()
+
+	if *source == "" {
+		log.Fatal("mockgen: -source is required")
+	}
+
+	src, err := os.ReadFile(*source)
+	if err != nil {
+		log.Fatalf("mockgen: %s", err)
+	}
+
+	res, err := generate(*source, src, *pkg, *importPath)
+	if err != nil {
+		log.Fatalf("mockgen: %s", err)
+	}
+
+	if *out == "" {
+		os.Stdout.Write(res)
+		return
+	}
+
+	if err := os.WriteFile(*out, res, 0o644); err != nil {
+		log.Fatalf("mockgen: %s", err)
+	}
+}
+
+// generate returns the formatted source of the mocks for every interface
+// declared in src.
+func generate(filename string, src []byte, pkg, importPath string) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		srcPkg:  f.Name.Name,
+		imports: make(map[string]string),
+		used:    map[string]string{f.Name.Name: importPath},
+	}
+	for _, spec := range f.Imports {
+		p, _ := strconv.Unquote(spec.Path.Value)
+		name := path.Base(p)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		g.imports[name] = p
+	}
+
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			iface, ok := ts.Type.(*ast.InterfaceType)
+			if !ok || !ts.Name.IsExported() {
+				continue
+			}
+			if err := g.mock(ts.Name.Name, iface); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by mockgen from %s; DO NOT EDIT.\n\n", path.Base(filename))
+	fmt.Fprintf(&buf, "package %s\n\nimport (\n", pkg)
+	var std, other []string
+	for name, p := range g.used {
+		spec := strconv.Quote(p)
+		if name != path.Base(p) && name != g.srcPkg {
+			spec = name + " " + spec
+		}
+		if strings.Contains(strings.Split(p, "/")[0], ".") {
+			other = append(other, spec)
+		} else {
+			std = append(std, spec)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+	for _, spec := range std {
+		fmt.Fprintf(&buf, "\t%s\n", spec)
+	}
+	if len(std) > 0 && len(other) > 0 {
+		buf.WriteString("\n")
+	}
+	for _, spec := range other {
+		fmt.Fprintf(&buf, "\t%s\n", spec)
+	}
+	buf.WriteString(")\n")
+	buf.Write(g.body.Bytes())
+
+	return format.Source(buf.Bytes())
+}
+
+type generator struct {
+	srcPkg  string
+	imports map[string]string
+	used    map[string]string
+	body    bytes.Buffer
+}
+
+type param struct {
+	name     string
+	typ      string
+	variadic bool
+}
+
+func (g *generator) mock(name string, iface *ast.InterfaceType) error {
+	type method struct {
+		name            string
+		params, results []param
+	}
+
+	var methods []method
+	for _, field := range iface.Methods.List {
+		ft, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) == 0 {
+			return fmt.Errorf("%s: embedded interfaces are not supported", name)
+		}
+		params, err := g.fields(ft.Params, "p")
+		if err != nil {
+			return err
+		}
+		results, err := g.fields(ft.Results, "r")
+		if err != nil {
+			return err
+		}
+		methods = append(methods, method{field.Names[0].Name, params, results})
+	}
+
+	w := &g.body
+	fmt.Fprintf(w, "\n// %s is a mock implementation of %s.%s.\n", name, g.srcPkg, name)
+	fmt.Fprintf(w, "type %s struct {\n\tRecorder\n\n", name)
+	for _, m := range methods {
+		fmt.Fprintf(w, "\t%sFunc func(%s) %s\n", m.name, signature(m.params), results(m.results))
+	}
+	fmt.Fprintf(w, "}\n\nvar _ %s.%s = (*%s)(nil)\n", g.srcPkg, name, name)
+
+	for _, m := range methods {
+		args := make([]string, len(m.params))
+		for i, p := range m.params {
+			args[i] = p.name
+		}
+		call := strings.Join(args, ", ")
+		if len(m.params) > 0 && m.params[len(m.params)-1].variadic {
+			call += "..."
+		}
+
+		fmt.Fprintf(w, "\n// %s records the call and invokes %sFunc.\n", m.name, m.name)
+		fmt.Fprintf(w, "func (m *%s) %s(%s) %s {\n", name, m.name, signature(m.params), results(m.results))
+		fmt.Fprintf(w, "\tm.record(%q", m.name)
+		for _, a := range args {
+			fmt.Fprintf(w, ", %s", a)
+		}
+		w.WriteString(")\n")
+
+		if len(m.results) == 0 {
+			fmt.Fprintf(w, "\tif m.%sFunc != nil {\n\t\tm.%sFunc(%s)\n\t}\n}\n", m.name, m.name, call)
+			continue
+		}
+
+		fmt.Fprintf(w, "\tif m.%sFunc == nil {\n", m.name)
+		// iterators report the missing mock through Err rather than
+		// returning a nil iterator that panics on the first call to Next.
+		if elem, ok := g.iteratorElem(m.results); ok {
+			fmt.Fprintf(w, "\t\treturn notMockedIterator[%s](%q, %q)\n\t}\n", elem, name, m.name)
+			fmt.Fprintf(w, "\treturn m.%sFunc(%s)\n}\n", m.name, call)
+			continue
+		}
+		zero := make([]string, len(m.results))
+		for i, r := range m.results {
+			zero[i] = r.name
+			if i == len(m.results)-1 && r.typ == "error" {
+				zero[i] = fmt.Sprintf("notMocked(%q, %q)", name, m.name)
+				continue
+			}
+			fmt.Fprintf(w, "\t\tvar %s %s\n", r.name, r.typ)
+		}
+		fmt.Fprintf(w, "\t\treturn %s\n\t}\n", strings.Join(zero, ", "))
+		fmt.Fprintf(w, "\treturn m.%sFunc(%s)\n}\n", m.name, call)
+	}
+
+	return nil
+}
+
+// iteratorElem returns the element type of a method whose only result is a
+// *Iterator of the source package.
+func (g *generator) iteratorElem(results []param) (string, bool) {
+	prefix := "*" + g.srcPkg + ".Iterator["
+	if len(results) != 1 || !strings.HasPrefix(results[0].typ, prefix) {
+		return "", false
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(results[0].typ, prefix), "]"), true
+}
+
+// fields flattens a parameter or result list, naming anonymous entries with
+// prefix and their position.
+func (g *generator) fields(list *ast.FieldList, prefix string) ([]param, error) {
+	if list == nil {
+		return nil, nil
+	}
+
+	var params []param
+	for _, field := range list.List {
+		typ, err := g.typeString(field.Type)
+		if err != nil {
+			return nil, err
+		}
+		_, variadic := field.Type.(*ast.Ellipsis)
+
+		if len(field.Names) == 0 || prefix == "r" {
+			n := len(field.Names)
+			if n == 0 {
+				n = 1
+			}
+			for i := 0; i < n; i++ {
+				params = append(params, param{fmt.Sprintf("%s%d", prefix, len(params)), typ, variadic})
+			}
+			continue
+		}
+		for _, ident := range field.Names {
+			params = append(params, param{ident.Name, typ, variadic})
+		}
+	}
+
+	return params, nil
+}
+
+// typeString renders expr as it must be written outside of the source
+// package, qualifying the source package's own types.
+func (g *generator) typeString(expr ast.Expr) (string, error) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if t.IsExported() {
+			return g.srcPkg + "." + t.Name, nil
+		}
+		return t.Name, nil
+	case *ast.SelectorExpr:
+		pkg, ok := t.X.(*ast.Ident)
+		if !ok {
+			return "", fmt.Errorf("unsupported selector %T", t.X)
+		}
+		p, ok := g.imports[pkg.Name]
+		if !ok {
+			return "", fmt.Errorf("unknown package %q", pkg.Name)
+		}
+		g.used[pkg.Name] = p
+		return pkg.Name + "." + t.Sel.Name, nil
+	case *ast.StarExpr:
+		s, err := g.typeString(t.X)
+		return "*" + s, err
+	case *ast.Ellipsis:
+		s, err := g.typeString(t.Elt)
+		return "..." + s, err
+	case *ast.ArrayType:
+		s, err := g.typeString(t.Elt)
+		if err != nil || t.Len == nil {
+			return "[]" + s, err
+		}
+		lit, ok := t.Len.(*ast.BasicLit)
+		if !ok {
+			return "", fmt.Errorf("unsupported array length %T", t.Len)
+		}
+		return "[" + lit.Value + "]" + s, nil
+	case *ast.MapType:
+		k, err := g.typeString(t.Key)
+		if err != nil {
+			return "", err
+		}
+		v, err := g.typeString(t.Value)
+		return "map[" + k + "]" + v, err
+	case *ast.IndexExpr:
+		x, err := g.typeString(t.X)
+		if err != nil {
+			return "", err
+		}
+		i, err := g.typeString(t.Index)
+		return x + "[" + i + "]", err
+	case *ast.InterfaceType:
+		if len(t.Methods.List) > 0 {
+			return "", fmt.Errorf("unsupported non-empty interface literal")
+		}
+		return "interface{}", nil
+	}
+
+	return "", fmt.Errorf("unsupported type %T", expr)
+}
+
+func signature(params []param) string {
+	parts := make([]string, len(params))
+	for i, p := range params {
+		parts[i] = p.name + " " + p.typ
+	}
+	return strings.Join(parts, ", ")
+}
+
+func results(params []param) string {
+	switch len(params) {
+	case 0:
+		return ""
+	case 1:
+		return params[0].typ
+	}
+
+	types := make([]string, len(params))
+	for i, p := range params {
+		types[i] = p.typ
+	}
+	return "(" + strings.Join(types, ", ") + ")"
+}
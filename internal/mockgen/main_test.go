@@ -0,0 +1,44 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMocksUpToDate(t *testing.T) {
+	src, err := os.ReadFile("../../interfaces.go")
+	require.NoError(t, err)
+
+	want, err := generate("interfaces.go", src, "cfmock", "github.com/cloudflare/cloudflare-go")
+	require.NoError(t, err)
+
+	got, err := os.ReadFile("../../cfmock/mocks.go")
+	require.NoError(t, err)
+
+	assert.Equal(t, string(want), string(got), "cfmock/mocks.go is out of date, run `go generate` in the repository root")
+}
+
+func TestGenerate(t *testing.T) {
+	src := []byte(`package widgets
+
+import "context"
+
+type WidgetsAPI interface {
+	Widgets(ctx context.Context, ids ...string) ([]Widget, error)
+	Each(ctx context.Context) *Iterator[Widget]
+	Ping()
+}
+`)
+
+	out, err := generate("widgets.go", src, "widgetsmock", "example.com/widgets")
+	require.NoError(t, err)
+
+	assert.Contains(t, string(out), "\"example.com/widgets\"")
+	assert.Contains(t, string(out), "WidgetsFunc func(ctx context.Context, ids ...string) ([]widgets.Widget, error)")
+	assert.Contains(t, string(out), "return m.WidgetsFunc(ctx, ids...)")
+	assert.Contains(t, string(out), `return notMockedIterator[widgets.Widget]("WidgetsAPI", "Each")`)
+	assert.Contains(t, string(out), "if m.PingFunc != nil {\n\t\tm.PingFunc()\n\t}")
+}
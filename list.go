@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
 	"errors"
@@ -351,6 +352,31 @@ func (api *API) ListListItems(ctx context.Context, rc *ResourceContainer, params
 	return list, nil
 }
 
+// ListListItemsIterator returns an Iterator over the items of a List,
+// following the cursor returned by each page.
+//
+// API reference: https://api.cloudflare.com/#rules-lists-list-list-items
+func (api *API) ListListItemsIterator(ctx context.Context, rc *ResourceContainer, params ListListItemsParams) *Iterator[ListItem] {
+	return newIterator(ctx, ResultInfo{}, func(ctx context.Context, info ResultInfo) ([]ListItem, ResultInfo, error) {
+		uri := fmt.Sprintf("/accounts/%s/rules/lists/%s/items", rc.Identifier, params.ID)
+		if info.Cursors.After != "" {
+			uri += "?cursor=" + url.QueryEscape(info.Cursors.After)
+		}
+
+		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
+		if err != nil {
+			return nil, ResultInfo{}, err
+		}
+
+		result := ListItemsListResponse{}
+		if err := json.Unmarshal(res, &result); err != nil {
+			return nil, ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+		}
+
+		return result.Result, result.ResultInfo, nil
+	})
+}
+
 // CreateListItemAsync creates a new List Item asynchronously. Users have to poll the operation status by
 // using the operation_id returned by this function.
 //
@@ -315,6 +315,13 @@ func TestListsItemsIP(t *testing.T) {
 	if assert.NoError(t, err) {
 		assert.Equal(t, want, actual)
 	}
+
+	actual, err = client.ListListItemsIterator(context.Background(), AccountIdentifier(testAccountID), ListListItemsParams{
+		ID: "2c0fc9fa937b11eaa1b71c4d701ab86e",
+	}).All()
+	if assert.NoError(t, err) {
+		assert.Equal(t, want, actual)
+	}
 }
 
 func TestListsItemsRedirect(t *testing.T) {
@@ -0,0 +1,219 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+type ListsService service
+
+// List returns the lists of an account.
+//
+// API reference: https://api.cloudflare.com/#rules-lists-list-lists
+func (s *ListsService) List(ctx context.Context, rc *ResourceContainer) ([]List, error) {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return []List{}, err
+	}
+
+	res, err := s.client.get(ctx, fmt.Sprintf("/accounts/%s/rules/lists", rc.Identifier), nil)
+	if err != nil {
+		return []List{}, err
+	}
+
+	var r ListListResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return []List{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+
+	return r.Result, nil
+}
+
+// Get fetches a single list.
+//
+// API reference: https://api.cloudflare.com/#rules-lists-get-list
+func (s *ListsService) Get(ctx context.Context, rc *ResourceContainer, listID string) (List, error) {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return List{}, err
+	}
+
+	if listID == "" {
+		return List{}, ErrMissingListID
+	}
+
+	res, err := s.client.get(ctx, fmt.Sprintf("/accounts/%s/rules/lists/%s", rc.Identifier, listID), nil)
+	if err != nil {
+		return List{}, err
+	}
+
+	return unmarshalList(res)
+}
+
+// Create creates a list.
+//
+// API reference: https://api.cloudflare.com/#rules-lists-create-list
+func (s *ListsService) Create(ctx context.Context, rc *ResourceContainer, params ListCreateParams) (List, error) {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return List{}, err
+	}
+
+	res, err := s.client.post(ctx, fmt.Sprintf("/accounts/%s/rules/lists", rc.Identifier), ListCreateRequest{
+		Name:        params.Name,
+		Description: params.Description,
+		Kind:        params.Kind,
+	})
+	if err != nil {
+		return List{}, err
+	}
+
+	return unmarshalList(res)
+}
+
+// Update updates the description of a list.
+//
+// API reference: https://api.cloudflare.com/#rules-lists-update-list
+func (s *ListsService) Update(ctx context.Context, rc *ResourceContainer, params ListUpdateParams) (List, error) {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return List{}, err
+	}
+
+	if params.ID == "" {
+		return List{}, ErrMissingListID
+	}
+
+	res, err := s.client.put(ctx, fmt.Sprintf("/accounts/%s/rules/lists/%s", rc.Identifier, params.ID), ListUpdateRequest{
+		Description: params.Description,
+	})
+	if err != nil {
+		return List{}, err
+	}
+
+	return unmarshalList(res)
+}
+
+// Delete deletes a list and its items.
+//
+// API reference: https://api.cloudflare.com/#rules-lists-delete-list
+func (s *ListsService) Delete(ctx context.Context, rc *ResourceContainer, listID string) error {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return err
+	}
+
+	if listID == "" {
+		return ErrMissingListID
+	}
+
+	_, err := s.client.delete(ctx, fmt.Sprintf("/accounts/%s/rules/lists/%s", rc.Identifier, listID), nil)
+	return err
+}
+
+// Items returns every item of a list.
+//
+// API reference: https://api.cloudflare.com/#rules-lists-list-list-items
+func (s *ListsService) Items(ctx context.Context, rc *ResourceContainer, params ListListItemsParams) ([]ListItem, error) {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return []ListItem{}, err
+	}
+
+	if params.ID == "" {
+		return []ListItem{}, ErrMissingListID
+	}
+
+	uri := fmt.Sprintf("/accounts/%s/rules/lists/%s/items", rc.Identifier, params.ID)
+	items, _, err := listAll(ctx, ResultInfo{}, func(ctx context.Context, info ResultInfo) ([]ListItem, ResultInfo, error) {
+		pageURI := uri
+		if info.Cursors.After != "" {
+			pageURI += "?cursor=" + url.QueryEscape(info.Cursors.After)
+		}
+		return getPage[ListItem](ctx, s.client, pageURI)
+	})
+	if err != nil {
+		return []ListItem{}, err
+	}
+
+	return items, nil
+}
+
+// CreateItems appends items to a list. The items are added asynchronously;
+// the returned operation ID can be passed to BulkOperation to check on
+// progress.
+//
+// API reference: https://api.cloudflare.com/#rules-lists-create-list-items
+func (s *ListsService) CreateItems(ctx context.Context, rc *ResourceContainer, params ListCreateItemsParams) (string, error) {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return "", err
+	}
+
+	if params.ID == "" {
+		return "", ErrMissingListID
+	}
+
+	res, err := s.client.post(ctx, fmt.Sprintf("/accounts/%s/rules/lists/%s/items", rc.Identifier, params.ID), params.Items)
+	if err != nil {
+		return "", err
+	}
+
+	var r ListItemCreateResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return "", fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+
+	return r.Result.OperationID, nil
+}
+
+// DeleteItems removes items from a list. Like CreateItems this happens
+// asynchronously and the operation ID is returned.
+//
+// API reference: https://api.cloudflare.com/#rules-lists-delete-list-items
+func (s *ListsService) DeleteItems(ctx context.Context, rc *ResourceContainer, params ListDeleteItemsParams) (string, error) {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return "", err
+	}
+
+	if params.ID == "" {
+		return "", ErrMissingListID
+	}
+
+	res, err := s.client.delete(ctx, fmt.Sprintf("/accounts/%s/rules/lists/%s/items", rc.Identifier, params.ID), params.Items)
+	if err != nil {
+		return "", err
+	}
+
+	var r ListItemDeleteResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return "", fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+
+	return r.Result.OperationID, nil
+}
+
+// BulkOperation fetches the status of an asynchronous list operation.
+//
+// API reference: https://api.cloudflare.com/#rules-lists-get-bulk-operation
+func (s *ListsService) BulkOperation(ctx context.Context, rc *ResourceContainer, operationID string) (ListBulkOperation, error) {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return ListBulkOperation{}, err
+	}
+
+	res, err := s.client.get(ctx, fmt.Sprintf("/accounts/%s/rules/lists/bulk_operations/%s", rc.Identifier, operationID), nil)
+	if err != nil {
+		return ListBulkOperation{}, err
+	}
+
+	var r ListBulkOperationResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return ListBulkOperation{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+
+	return r.Result, nil
+}
+
+func unmarshalList(res []byte) (List, error) {
+	var r ListResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return List{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+
+	return r.Result, nil
+}
@@ -0,0 +1,52 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListsService_Items(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/rules/lists/2c0fc9fa937b11eaa1b71c4d701ab86e/items", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			if r.URL.Query().Get("cursor") == "" {
+				fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [{"id": "item-1", "ip": "198.51.100.4"}], "result_info": {"cursors": {"after": "next"}}}`)
+				return
+			}
+			assert.Equal(t, "next", r.URL.Query().Get("cursor"))
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [{"id": "item-2", "ip": "198.51.100.5"}], "result_info": {"cursors": {}}}`)
+		case http.MethodPost:
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"operation_id": "4da8780eeb215e6cb7f48dd981c4ea02"}}`)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	c := newExperimentalTestClient(t)
+	rc := AccountIdentifier(testAccountID)
+
+	items, err := c.Lists.Items(context.Background(), rc, ListListItemsParams{ID: "2c0fc9fa937b11eaa1b71c4d701ab86e"})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "198.51.100.5", *items[1].IP)
+
+	ip := "198.51.100.6"
+	operationID, err := c.Lists.CreateItems(context.Background(), rc, ListCreateItemsParams{
+		ID:    "2c0fc9fa937b11eaa1b71c4d701ab86e",
+		Items: []ListItemCreateRequest{{IP: &ip}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "4da8780eeb215e6cb7f48dd981c4ea02", operationID)
+
+	_, err = c.Lists.Items(context.Background(), ZoneIdentifier(testZoneID), ListListItemsParams{ID: "2c0fc9fa937b11eaa1b71c4d701ab86e"})
+	assert.Error(t, err)
+}
@@ -31,6 +31,46 @@ type LoadBalancerPool struct {
 	CheckRegions []string `json:"check_regions"`
 }
 
+// Validate checks the pool has at least one origin and that the origins,
+// weights and coordinates are within the ranges the API accepts.
+func (pool LoadBalancerPool) Validate() error {
+	var errs fieldErrors
+
+	if pool.Name == "" {
+		errs.add("Name", "is required")
+	}
+
+	if len(pool.Origins) == 0 {
+		errs.add("Origins", "at least one origin is required")
+	}
+
+	for i, origin := range pool.Origins {
+		if origin.Name == "" {
+			errs.add(fmt.Sprintf("Origins[%d].Name", i), "is required")
+		}
+		if origin.Address == "" {
+			errs.add(fmt.Sprintf("Origins[%d].Address", i), "is required")
+		}
+		if origin.Weight < 0 || origin.Weight > 1 {
+			errs.add(fmt.Sprintf("Origins[%d].Weight", i), "must be between 0 and 1, got %v", origin.Weight)
+		}
+	}
+
+	if pool.MinimumOrigins > len(pool.Origins) {
+		errs.add("MinimumOrigins", "is greater than the number of origins (%d)", len(pool.Origins))
+	}
+
+	if pool.Latitude != nil && (*pool.Latitude < -90 || *pool.Latitude > 90) {
+		errs.add("Latitude", "must be between -90 and 90, got %v", *pool.Latitude)
+	}
+
+	if pool.Longitude != nil && (*pool.Longitude < -180 || *pool.Longitude > 180) {
+		errs.add("Longitude", "must be between -180 and 180, got %v", *pool.Longitude)
+	}
+
+	return errs.err("LoadBalancerPool")
+}
+
 // LoadBalancerOrigin represents a Load Balancer origin's properties.
 type LoadBalancerOrigin struct {
 	Name    string              `json:"name"`
@@ -67,6 +107,32 @@ type LoadBalancerMonitor struct {
 	ProbeZone       string              `json:"probe_zone"`
 }
 
+// Validate checks the monitor type and that requests time out before the
+// next one is due.
+func (monitor LoadBalancerMonitor) Validate() error {
+	var errs fieldErrors
+
+	switch monitor.Type {
+	case "", "http", "https", "tcp", "udp_icmp", "icmp_ping", "smtp":
+	default:
+		errs.add("Type", "must be one of http, https, tcp, udp_icmp, icmp_ping or smtp, got %q", monitor.Type)
+	}
+
+	if monitor.Timeout < 0 {
+		errs.add("Timeout", "must not be negative")
+	}
+
+	if monitor.Retries < 0 {
+		errs.add("Retries", "must not be negative")
+	}
+
+	if monitor.Timeout > 0 && monitor.Interval > 0 && monitor.Timeout >= monitor.Interval {
+		errs.add("Timeout", "must be less than Interval (%d), got %d", monitor.Interval, monitor.Timeout)
+	}
+
+	return errs.err("LoadBalancerMonitor")
+}
+
 // LoadBalancer represents a load balancer's properties.
 type LoadBalancer struct {
 	ID                        string                     `json:"id,omitempty"`
@@ -98,6 +164,78 @@ type LoadBalancer struct {
 	SteeringPolicy string `json:"steering_policy,omitempty"`
 }
 
+// Validate checks the load balancer has its pools and that the steering
+// policy and session affinity are known values. Whether the pools are
+// suitable for the steering policy can only be checked against the pools
+// themselves using ValidatePools.
+func (lb LoadBalancer) Validate() error {
+	var errs fieldErrors
+
+	if lb.Name == "" {
+		errs.add("Name", "is required")
+	}
+
+	if lb.FallbackPool == "" {
+		errs.add("FallbackPool", "is required")
+	}
+
+	if len(lb.DefaultPools) == 0 {
+		errs.add("DefaultPools", "at least one pool is required")
+	}
+
+	switch lb.SteeringPolicy {
+	case "", "off", "geo", "dynamic_latency", "random", "proximity":
+	default:
+		errs.add("SteeringPolicy", "must be one of off, geo, dynamic_latency, random or proximity, got %q", lb.SteeringPolicy)
+	}
+
+	if lb.RandomSteering != nil && lb.SteeringPolicy != "random" {
+		errs.add("RandomSteering", "is only used with the \"random\" steering policy")
+	}
+
+	switch lb.Persistence {
+	case "", "none", "cookie", "ip_cookie", "header":
+	default:
+		errs.add("Persistence", "must be one of none, cookie, ip_cookie or header, got %q", lb.Persistence)
+	}
+
+	if lb.PersistenceTTL != 0 && (lb.PersistenceTTL < 1800 || lb.PersistenceTTL > 604800) {
+		errs.add("PersistenceTTL", "must be between 1800 and 604800 seconds, got %d", lb.PersistenceTTL)
+	}
+
+	return errs.err("LoadBalancer")
+}
+
+// ValidatePools checks that the pools the load balancer uses exist in `pools`
+// and can be used with its steering policy. Dynamic latency steering relies
+// on health check RTTs so every pool must have a monitor.
+func (lb LoadBalancer) ValidatePools(pools []LoadBalancerPool) error {
+	byID := make(map[string]LoadBalancerPool, len(pools))
+	for _, pool := range pools {
+		byID[pool.ID] = pool
+	}
+
+	var errs fieldErrors
+	check := func(field, id string) {
+		pool, ok := byID[id]
+		switch {
+		case !ok:
+			errs.add(field, "pool %q doesn't exist", id)
+		case lb.SteeringPolicy == "dynamic_latency" && pool.Monitor == "":
+			errs.add(field, "pool %q has no monitor, which dynamic_latency steering requires", id)
+		}
+	}
+
+	for i, id := range lb.DefaultPools {
+		check(fmt.Sprintf("DefaultPools[%d]", i), id)
+	}
+	if lb.FallbackPool != "" {
+		check("FallbackPool", lb.FallbackPool)
+	}
+
+	return errs.err("LoadBalancer")
+}
+
 // LoadBalancerLoadShedding contains the settings for controlling load shedding.
 type LoadBalancerLoadShedding struct {
 	DefaultPercent float32 `json:"default_percent,omitempty"`
@@ -349,6 +487,18 @@ func (api *API) ListLoadBalancerPools(ctx context.Context, rc *ResourceContainer
 	return r.Result, nil
 }
 
+// ListLoadBalancerPoolsIterator returns an Iterator over the load balancer
+// pools connected to an account, fetching each page of results as it is
+// needed.
+//
+// API reference: https://api.cloudflare.com/#load-balancer-pools-list-pools
+func (api *API) ListLoadBalancerPoolsIterator(ctx context.Context, rc *ResourceContainer, params ListLoadBalancerPoolParams) *Iterator[LoadBalancerPool] {
+	return newPageSizeIterator(ctx, params.PaginationOptions, 50, func(ctx context.Context, pageOpts PaginationOptions) ([]LoadBalancerPool, error) {
+		params.PaginationOptions = pageOpts
+		return api.ListLoadBalancerPools(ctx, rc, params)
+	})
+}
+
 // GetLoadBalancerPool returns the details for a load balancer pool.
 //
 // API reference: https://api.cloudflare.com/#load-balancer-pools-pool-details
@@ -489,6 +639,18 @@ func (api *API) ListLoadBalancerMonitors(ctx context.Context, rc *ResourceContai
 	return r.Result, nil
 }
 
+// ListLoadBalancerMonitorsIterator returns an Iterator over the load balancer
+// monitors connected to an account, fetching each page of results as it is
+// needed.
+//
+// API reference: https://api.cloudflare.com/#load-balancer-monitors-list-monitors
+func (api *API) ListLoadBalancerMonitorsIterator(ctx context.Context, rc *ResourceContainer, params ListLoadBalancerMonitorParams) *Iterator[LoadBalancerMonitor] {
+	return newPageSizeIterator(ctx, params.PaginationOptions, 50, func(ctx context.Context, pageOpts PaginationOptions) ([]LoadBalancerMonitor, error) {
+		params.PaginationOptions = pageOpts
+		return api.ListLoadBalancerMonitors(ctx, rc, params)
+	})
+}
+
 // GetLoadBalancerMonitor returns the details for a load balancer monitor.
 //
 // API reference: https://api.cloudflare.com/#load-balancer-monitors-monitor-details
@@ -616,6 +778,17 @@ func (api *API) ListLoadBalancers(ctx context.Context, rc *ResourceContainer, pa
 	return r.Result, nil
 }
 
+// ListLoadBalancersIterator returns an Iterator over the load balancers
+// configured on a zone, fetching each page of results as it is needed.
+//
+// API reference: https://api.cloudflare.com/#load-balancers-list-load-balancers
+func (api *API) ListLoadBalancersIterator(ctx context.Context, rc *ResourceContainer, params ListLoadBalancerParams) *Iterator[LoadBalancer] {
+	return newPageSizeIterator(ctx, params.PaginationOptions, 50, func(ctx context.Context, pageOpts PaginationOptions) ([]LoadBalancer, error) {
+		params.PaginationOptions = pageOpts
+		return api.ListLoadBalancers(ctx, rc, params)
+	})
+}
+
 // GetLoadBalancer returns the details for a load balancer.
 //
 // API reference: https://api.cloudflare.com/#load-balancers-load-balancer-details
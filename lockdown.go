@@ -188,3 +188,29 @@ func (api *API) ListZoneLockdowns(ctx context.Context, rc *ResourceContainer, pa
 
 	return zoneLockdowns, &zResponse.ResultInfo, nil
 }
+
+// ListZoneLockdownsIterator returns an Iterator over every Zone Lockdown rule
+// of a zone, fetching each page of results as it is needed. `params.Page` and
+// `params.PerPage` control where iteration starts and the size of each page.
+//
+// API reference: https://api.cloudflare.com/#zone-ZoneLockdown-list-ZoneLockdown-rules
+func (api *API) ListZoneLockdownsIterator(ctx context.Context, rc *ResourceContainer, params LockdownListParams) *Iterator[ZoneLockdown] {
+	start := ResultInfo{Page: params.Page, PerPage: params.PerPage}
+	if start.PerPage < 1 {
+		start.PerPage = 50
+	}
+	if start.Page < 1 {
+		start.Page = 1
+	}
+
+	return newIterator(ctx, start, func(ctx context.Context, info ResultInfo) ([]ZoneLockdown, ResultInfo, error) {
+		params.ResultInfo = info
+
+		items, resultInfo, err := api.ListZoneLockdowns(ctx, rc, params)
+		if err != nil {
+			return nil, ResultInfo{}, err
+		}
+
+		return items, *resultInfo, nil
+	})
+}
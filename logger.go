@@ -1,10 +1,15 @@
 package cloudflare
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"log"
+	"net/http"
 	"os"
+	"regexp"
+	"strings"
 )
 
 // silentRetryLogger is the logger provided with retryable client to stop it
@@ -128,3 +133,87 @@ type LeveledLoggerInterface interface {
 	// Warnf logs a warning message using Printf conventions.
 	Warnf(format string, v ...interface{})
 }
+
+// redacted replaces credentials and secrets in debug output.
+const redacted = "[REDACTED]"
+
+var (
+	// sensitiveHeaders are the request headers that carry credentials.
+	sensitiveHeaders = []string{"Authorization", "X-Auth-Key", "X-Auth-User-Service-Key"}
+
+	// sensitiveBodyFields matches JSON string fields that hold secrets, such
+	// as the `text` of a Workers secret or binding.
+	sensitiveBodyFields = regexp.MustCompile(`("(?:text|secret|client_secret|tunnel_secret|password|private_key|token)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+)
+
+// redactHeaders returns a copy of `h` with the credentials removed. The
+// authentication scheme of the `Authorization` header is kept.
+func redactHeaders(h http.Header) http.Header {
+	out := h.Clone()
+	for _, name := range sensitiveHeaders {
+		v := out.Get(name)
+		if v == "" {
+			continue
+		}
+
+		if scheme, _, found := strings.Cut(v, " "); found && name == "Authorization" {
+			out.Set(name, scheme+" "+redacted)
+		} else {
+			out.Set(name, redacted)
+		}
+	}
+
+	return out
+}
+
+// redactBody returns `body` with the values of known secret fields removed.
+func redactBody(body []byte) string {
+	return sensitiveBodyFields.ReplaceAllString(string(body), `${1}"`+redacted+`"`)
+}
+
+// logFields formats key/value pairs as `key="value"` for structured log
+// output.
+func logFields(kv ...interface{}) string {
+	fields := make([]string, 0, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		fields = append(fields, fmt.Sprintf("%s=%q", kv[i], fmt.Sprint(kv[i+1])))
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// logRequest writes a redacted description of `req` to the debug log. The
+// request body is buffered so that it can still be sent, unless it is
+// streamed (has no GetBody) in which case only its size is logged.
+func logRequest(logger LeveledLoggerInterface, req *http.Request) {
+	logged := "<streamed body>"
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		var body []byte
+		if req.Body != nil && req.Body != http.NoBody {
+			body, _ = ioutil.ReadAll(req.Body)
+			req.Body.Close()
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+		logged = redactBody(body)
+	} else if req.ContentLength > 0 {
+		logged = fmt.Sprintf("<streamed body of %d bytes>", req.ContentLength)
+	}
+
+	logger.Debugf("REQUEST %s\n", logFields(
+		"method", req.Method,
+		"url", req.URL.String(),
+		"headers", redactHeaders(req.Header),
+		"body", logged,
+	))
+}
+
+// logResponse writes a redacted description of `resp` to the debug log.
+func logResponse(logger LeveledLoggerInterface, resp *http.Response, body []byte) {
+	logger.Debugf("RESPONSE %s\n", logFields(
+		"url", resp.Request.URL.String(),
+		"status", resp.StatusCode,
+		"ray_id", resp.Header.Get("cf-ray"),
+		"content_type", resp.Header.Get("content-type"),
+		"body", redactBody(body),
+	))
+}
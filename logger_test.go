@@ -0,0 +1,103 @@
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactHeaders(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "Bearer my-api-token")
+	h.Set("X-Auth-Key", "my-api-key")
+	h.Set("X-Auth-Email", "cloudflare@example.org")
+	h.Set("X-Auth-User-Service-Key", "v1.0-my-service-key")
+
+	redactedHeaders := redactHeaders(h)
+	assert.Equal(t, "Bearer [REDACTED]", redactedHeaders.Get("Authorization"))
+	assert.Equal(t, "[REDACTED]", redactedHeaders.Get("X-Auth-Key"))
+	assert.Equal(t, "[REDACTED]", redactedHeaders.Get("X-Auth-User-Service-Key"))
+	assert.Equal(t, "cloudflare@example.org", redactedHeaders.Get("X-Auth-Email"))
+
+	// the original headers are left alone.
+	assert.Equal(t, "my-api-key", h.Get("X-Auth-Key"))
+}
+
+func TestRedactBody(t *testing.T) {
+	for _, c := range [...]struct {
+		TestName string
+		Body     string
+		Expected string
+	}{
+		{"workers secret", `{"name":"API_KEY","text":"s3cr3t","type":"secret_text"}`, `{"name":"API_KEY","text":"[REDACTED]","type":"secret_text"}`},
+		{"escaped quotes", `{"secret": "a\"b"}`, `{"secret": "[REDACTED]"}`},
+		{"nested", `{"result":{"client_id":"abc","client_secret":"def"}}`, `{"result":{"client_id":"abc","client_secret":"[REDACTED]"}}`},
+		{"nothing to redact", `{"type":"A","name":"example.com","content":"198.51.100.4"}`, `{"type":"A","name":"example.com","content":"198.51.100.4"}`},
+	} {
+		t.Run(c.TestName, func(t *testing.T) {
+			assert.Equal(t, c.Expected, redactBody([]byte(c.Body)))
+		})
+	}
+}
+
+func TestLogFields(t *testing.T) {
+	assert.Equal(t, `method="GET" status="200"`, logFields("method", "GET", "status", 200))
+}
+
+func TestDebugLogging_RedactsCredentialsAndSecrets(t *testing.T) {
+	setup()
+	defer teardown()
+
+	out := &bytes.Buffer{}
+	client.APIToken = "my-api-token"
+	client.authType = AuthToken
+	require.NoError(t, Debug(true)(client))
+	require.NoError(t, UsingLeveledLogger(&LeveledLogger{Level: LevelDebug, stdoutOverride: out})(client))
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/workers/scripts/test-script/secrets", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer my-api-token", r.Header.Get("Authorization"))
+		w.Header().Set("content-type", "application/json")
+		w.Header().Set("cf-ray", "7a5d8d4e8b2b0b4f-LHR")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"name": "my-secret", "type": "secret_text"}}`)
+	})
+
+	client.AccountID = testAccountID
+	_, err := client.SetWorkersSecret(context.Background(), "test-script", &WorkersPutSecretRequest{
+		Name: "my-secret",
+		Text: "super-secret-value",
+		Type: WorkerSecretTextBindingType,
+	})
+	require.NoError(t, err)
+
+	logged := out.String()
+	assert.NotContains(t, logged, "my-api-token")
+	assert.NotContains(t, logged, "super-secret-value")
+	assert.Contains(t, logged, "[debug] REQUEST method=\"PUT\"")
+	assert.Contains(t, logged, "Bearer [REDACTED]")
+	assert.Contains(t, logged, "[debug] RESPONSE")
+	assert.Contains(t, logged, `ray_id="7a5d8d4e8b2b0b4f-LHR"`)
+	assert.Equal(t, 2, strings.Count(logged, "\n"))
+}
+
+func TestDebugLogging_DisabledByDefault(t *testing.T) {
+	setup()
+	defer teardown()
+
+	out := &bytes.Buffer{}
+	require.NoError(t, UsingLeveledLogger(&LeveledLogger{Level: LevelDebug, stdoutOverride: out})(client))
+
+	mux.HandleFunc("/zones/"+testZoneID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "`+testZoneID+`"}}`)
+	})
+
+	_, err := client.ZoneDetails(context.Background(), testZoneID)
+	require.NoError(t, err)
+	assert.Empty(t, out.String())
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"errors"
@@ -31,6 +32,32 @@ type LogpushJobFilters struct {
 	Where LogpushJobFilter `json:"where"`
 }
 
+// Validate checks the job's filter and the fields that only accept a fixed set
+// of values.
+func (job LogpushJob) Validate() error {
+	var errs fieldErrors
+
+	if job.DestinationConf != "" && !strings.Contains(job.DestinationConf, "://") {
+		errs.add("DestinationConf", "must be a URI such as s3://bucket/path, got %q", job.DestinationConf)
+	}
+
+	if job.Frequency != "" && job.Frequency != "high" && job.Frequency != "low" {
+		errs.add("Frequency", "must be \"high\" or \"low\", got %q", job.Frequency)
+	}
+
+	if job.Kind != "" && job.Kind != "edge" {
+		errs.add("Kind", "must be empty or \"edge\", got %q", job.Kind)
+	}
+
+	if job.Filter != nil {
+		if err := job.Filter.Where.Validate(); err != nil {
+			errs.add("Filter.Where", "%s", err)
+		}
+	}
+
+	return errs.err("LogpushJob")
+}
+
 type Operator string
 
 const (
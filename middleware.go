@@ -0,0 +1,199 @@
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// MiddlewareRequest is the request passed along a middleware chain.
+type MiddlewareRequest struct {
+	// Method is the HTTP method of the request.
+	Method string
+
+	// URI is the endpoint relative to the client's base URL, including any
+	// query string (e.g. "/zones/023e105f4ecef8ad9ca31a8372d0c353/dns_records?page=1").
+	URI string
+
+	// AuthType is the authentication method used for the request
+	// (AuthKeyEmail, AuthToken, AuthUserService or a combination).
+	AuthType int
+
+	// Request is the fully built HTTP request, including the authentication
+	// headers. Middleware may modify it (e.g. to add headers or sign it)
+	// before calling the next handler.
+	Request *http.Request
+
+	// Stream is set when the response body is handed to the caller as it is
+	// received, such as for downloads. The body isn't buffered and the
+	// envelope isn't decoded so middleware that needs the body must read
+	// and replace it itself.
+	Stream bool
+}
+
+// MiddlewareResponse is the result passed back up a middleware chain.
+type MiddlewareResponse struct {
+	// Response is the HTTP response. Unless the request is streamed, its body
+	// has already been read and replaced with an in-memory copy so it can be
+	// read again.
+	Response *http.Response
+
+	// Envelope is the decoded Cloudflare response envelope. It is nil when the
+	// body isn't a JSON envelope (e.g. a script or zone file download) or the
+	// request is streamed.
+	Envelope *Response
+}
+
+// RequestHandler sends a request and returns the response.
+type RequestHandler func(req *MiddlewareRequest) (*MiddlewareResponse, error)
+
+// Middleware wraps a RequestHandler to add behaviour around every request
+// such as logging, header mutation, request signing or fault injection.
+// Middleware may return a response without calling `next` to short circuit
+// the request. Both API and Client call the chain once per attempt, so a
+// retried request passes through it again.
+type Middleware func(next RequestHandler) RequestHandler
+
+// chainMiddleware wraps `h` in the middleware so that the first middleware is
+// the outermost.
+func chainMiddleware(h RequestHandler, middleware []Middleware) RequestHandler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		h = middleware[i](h)
+	}
+	return h
+}
+
+// sendHTTPRequest is the innermost RequestHandler. It sends the request using
+// `roundTrip` and decodes the response envelope for the middleware above it,
+// except for streamed requests whose body is left unread.
+func sendHTTPRequest(roundTrip func(*http.Request) (*http.Response, error)) RequestHandler {
+	return func(req *MiddlewareRequest) (*MiddlewareResponse, error) {
+		resp, err := roundTrip(req.Request)
+		if err != nil {
+			return nil, err
+		}
+
+		if req.Stream {
+			return &MiddlewareResponse{Response: resp}, nil
+		}
+
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("could not read response body: %w", err)
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		var envelope *Response
+		if err := json.Unmarshal(body, &envelope); err != nil {
+			envelope = nil
+		}
+
+		return &MiddlewareResponse{Response: resp, Envelope: envelope}, nil
+	}
+}
+
+// requestMiddleware returns the middleware chain of the client with the dry
+// run middleware, if any, as the innermost.
+func (api *API) requestMiddleware() []Middleware {
+	if api.dryRun == nil {
+		return api.middleware
+	}
+
+	return append(append([]Middleware(nil), api.middleware...), api.dryRun.Middleware())
+}
+
+// doWithMiddleware sends `req` through the middleware chain. The request is
+// sent straight to the HTTP client when there isn't any middleware to avoid
+// buffering the response body.
+func doWithMiddleware(client *http.Client, middleware []Middleware, req *MiddlewareRequest) (*http.Response, error) {
+	return roundTripWithMiddleware(client.Do, middleware, req)
+}
+
+// roundTripWithMiddleware sends `req` through the middleware chain, using
+// `roundTrip` to send it once it reaches the end of the chain.
+func roundTripWithMiddleware(roundTrip func(*http.Request) (*http.Response, error), middleware []Middleware, req *MiddlewareRequest) (*http.Response, error) {
+	if len(middleware) == 0 {
+		return roundTrip(req.Request)
+	}
+
+	res, err := chainMiddleware(sendHTTPRequest(roundTrip), middleware)(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil || res.Response == nil {
+		return nil, fmt.Errorf("middleware for %s %s returned no response", req.Method, req.URI)
+	}
+
+	// responses synthesised by middleware may be missing fields the
+	// client relies on.
+	resp := res.Response
+	if resp.Request == nil {
+		resp.Request = req.Request
+	}
+	if resp.Header == nil {
+		resp.Header = make(http.Header)
+	}
+	if resp.Body == nil {
+		resp.Body = http.NoBody
+	}
+
+	return resp, nil
+}
+
+type middlewareRequestContextKey struct{}
+
+// contextWithMiddlewareRequest attaches the details of a request that the
+// HTTP request alone doesn't carry for middlewareTransport to pass along the
+// chain.
+func contextWithMiddlewareRequest(ctx context.Context, req MiddlewareRequest) context.Context {
+	return context.WithValue(ctx, middlewareRequestContextKey{}, req)
+}
+
+// middlewareTransport runs the experimental client's middleware around each
+// request it sends. It sits below the retrying transport so that, as with
+// API, middleware sees every attempt rather than the call as a whole.
+// Requests that weren't sent by the client are passed straight through.
+type middlewareTransport struct {
+	next   http.RoundTripper
+	params *ClientParams
+}
+
+// withMiddlewareTransport returns a copy of `client` that sends its requests
+// through the middleware of `params`.
+func withMiddlewareTransport(client *http.Client, params *ClientParams) *http.Client {
+	next := client.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	wrapped := *client
+	wrapped.Transport = &middlewareTransport{next: next, params: params}
+
+	return &wrapped
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *middlewareTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	mreq, ok := req.Context().Value(middlewareRequestContextKey{}).(MiddlewareRequest)
+	if !ok {
+		return t.next.RoundTrip(req)
+	}
+
+	// a RoundTripper mustn't modify the request it is given but middleware
+	// may.
+	mreq.Request = req.Clone(req.Context())
+
+	return roundTripWithMiddleware(t.next.RoundTrip, t.params.Middleware, &mreq)
+}
+
+// CloseIdleConnections closes the idle connections of the wrapped transport.
+func (t *middlewareTransport) CloseIdleConnections() {
+	if ci, ok := t.next.(interface{ CloseIdleConnections() }); ok {
+		ci.CloseIdleConnections()
+	}
+}
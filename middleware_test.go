@@ -0,0 +1,216 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_UsingMiddleware(t *testing.T) {
+	var calls []string
+	var seen *MiddlewareRequest
+	var envelope *Response
+
+	recorder := func(name string) Middleware {
+		return func(next RequestHandler) RequestHandler {
+			return func(req *MiddlewareRequest) (*MiddlewareResponse, error) {
+				calls = append(calls, name+" request")
+				res, err := next(req)
+				calls = append(calls, name+" response")
+				return res, err
+			}
+		}
+	}
+
+	inspector := func(next RequestHandler) RequestHandler {
+		return func(req *MiddlewareRequest) (*MiddlewareResponse, error) {
+			seen = req
+			req.Request.Header.Set("X-Signature", "signed")
+			res, err := next(req)
+			if err == nil {
+				envelope = res.Envelope
+			}
+			return res, err
+		}
+	}
+
+	setup(UsingMiddleware(recorder("first"), recorder("second")), UsingMiddleware(inspector))
+	defer teardown()
+
+	mux.HandleFunc("/zones/"+testZoneID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "signed", r.Header.Get("X-Signature"))
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [{"code": 1000, "message": "this endpoint is deprecated"}],
+			"result": {"id": "`+testZoneID+`"}
+		}`)
+	})
+
+	zone, err := client.ZoneDetails(context.Background(), testZoneID)
+	require.NoError(t, err)
+	assert.Equal(t, testZoneID, zone.ID)
+
+	assert.Equal(t, []string{"first request", "second request", "second response", "first response"}, calls)
+	assert.Equal(t, http.MethodGet, seen.Method)
+	assert.Equal(t, "/zones/"+testZoneID, seen.URI)
+	assert.Equal(t, AuthKeyEmail, seen.AuthType)
+	require.NotNil(t, envelope)
+	assert.Equal(t, []ResponseInfo{{Code: 1000, Message: "this endpoint is deprecated"}}, envelope.Messages)
+}
+
+func TestClient_UsingMiddlewareFaultInjection(t *testing.T) {
+	fault := func(next RequestHandler) RequestHandler {
+		return func(req *MiddlewareRequest) (*MiddlewareResponse, error) {
+			return &MiddlewareResponse{Response: &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+			}}, nil
+		}
+	}
+
+	setup(UsingMiddleware(fault))
+	defer teardown()
+
+	mux.HandleFunc("/zones/"+testZoneID, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("request should not reach the server")
+	})
+
+	_, err := client.ZoneDetails(context.Background(), testZoneID)
+
+	var serviceErr *ServiceError
+	assert.ErrorAs(t, err, &serviceErr)
+}
+
+func TestExperimentalClient_Middleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "injected", r.Header.Get("X-Injected"))
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "`+testZoneID+`"}}`)
+	}))
+	defer server.Close()
+
+	var authType int
+	baseURL, _ := url.Parse(server.URL)
+	client, err := NewExperimental(&ClientParams{
+		Token:   "deadbeef",
+		BaseURL: baseURL,
+		Middleware: []Middleware{func(next RequestHandler) RequestHandler {
+			return func(req *MiddlewareRequest) (*MiddlewareResponse, error) {
+				authType = req.AuthType
+				req.Request.Header.Set("X-Injected", "injected")
+				return next(req)
+			}
+		}},
+	})
+	require.NoError(t, err)
+
+	zone, err := client.Zones.Get(context.Background(), ZoneIdentifier(testZoneID))
+	require.NoError(t, err)
+	assert.Equal(t, testZoneID, zone.ID)
+	assert.Equal(t, AuthToken, authType)
+}
+
+func TestExperimentalClient_MiddlewareSeesEveryAttempt(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "`+testZoneID+`"}}`)
+	}))
+	defer server.Close()
+
+	// fail the first attempt without sending it, as with API the retry
+	// must pass through the middleware again.
+	attempts := 0
+	fault := func(next RequestHandler) RequestHandler {
+		return func(req *MiddlewareRequest) (*MiddlewareResponse, error) {
+			attempts++
+			if attempts == 1 {
+				return &MiddlewareResponse{Response: &http.Response{
+					StatusCode: http.StatusServiceUnavailable,
+					Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+				}}, nil
+			}
+			return next(req)
+		}
+	}
+
+	baseURL, _ := url.Parse(server.URL)
+	client, err := NewExperimental(&ClientParams{
+		Token:       "deadbeef",
+		BaseURL:     baseURL,
+		RetryPolicy: RetryPolicy{MaxRetries: 2, MinRetryDelay: time.Millisecond, MaxRetryDelay: time.Millisecond},
+		Middleware:  []Middleware{fault},
+	})
+	require.NoError(t, err)
+
+	zone, err := client.Zones.Get(context.Background(), ZoneIdentifier(testZoneID))
+	require.NoError(t, err)
+	assert.Equal(t, testZoneID, zone.ID)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, 1, requests)
+}
+
+func TestClient_MiddlewareStream(t *testing.T) {
+	var seen *MiddlewareResponse
+	inspector := func(next RequestHandler) RequestHandler {
+		return func(req *MiddlewareRequest) (*MiddlewareResponse, error) {
+			assert.True(t, req.Stream)
+			res, err := next(req)
+			seen = res
+			return res, err
+		}
+	}
+
+	setup(UsingAccount(testAccountID), UsingMiddleware(inspector))
+	defer teardown()
+
+	// the rest of the value is only sent once the start has been received,
+	// which can't happen if the body is buffered.
+	release := make(chan struct{})
+	mux.HandleFunc("/accounts/"+testAccountID+"/storage/kv/namespaces/ns/values/key", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "hello")
+		w.(http.Flusher).Flush()
+		<-release
+		fmt.Fprint(w, " world")
+	})
+
+	type result struct {
+		body io.ReadCloser
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		body, err := client.ReadWorkersKVStream(context.Background(), "ns", "key")
+		done <- result{body, err}
+	}()
+
+	var res result
+	select {
+	case res = <-done:
+	case <-time.After(5 * time.Second):
+		close(release)
+		t.Fatal("the response body was buffered")
+	}
+	require.NoError(t, res.err)
+	defer res.body.Close()
+	close(release)
+
+	value, err := ioutil.ReadAll(res.body)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(value))
+	require.NotNil(t, seen)
+	assert.Nil(t, seen.Envelope)
+}
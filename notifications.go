@@ -435,6 +435,18 @@ func (api *API) ListNotificationHistory(ctx context.Context, accountID string, a
 	return r.Result, r.ResultInfo, nil
 }
 
+// ListNotificationHistoryIterator returns an Iterator over the history of
+// alerts sent for a given account, fetching each page of results as it is
+// needed.
+//
+// API Reference: https://api.cloudflare.com/#notification-history-list-history
+func (api *API) ListNotificationHistoryIterator(ctx context.Context, accountID string, alertHistoryFilter AlertHistoryFilter) *Iterator[NotificationHistory] {
+	return newPaginationIterator(ctx, alertHistoryFilter.PaginationOptions, func(ctx context.Context, pageOpts PaginationOptions) ([]NotificationHistory, ResultInfo, error) {
+		alertHistoryFilter.PaginationOptions = pageOpts
+		return api.ListNotificationHistory(ctx, accountID, alertHistoryFilter)
+	})
+}
+
 // unmarshal will unmarshal bytes and return a SaveResponse.
 func unmarshalNotificationSaveResponse(res []byte) (SaveResponse, error) {
 	var r SaveResponse
@@ -4,8 +4,6 @@ import (
 	"net/http"
 
 	"time"
-
-	"golang.org/x/time/rate"
 )
 
 // Option is a functional option for configuring the API client.
@@ -43,11 +41,16 @@ func UsingAccount(accountID string) Option {
 // If not specified the default of 4rps will be applied.
 func UsingRateLimit(rps float64) Option {
 	return func(api *API) error {
-		// because ratelimiter doesnt do any windowing
-		// setting burst makes it difficult to enforce a fixed rate
-		// so setting it equal to 1 this effectively disables bursting
-		// this doesn't check for sensible values, ultimately the api will enforce that the value is ok
-		api.rateLimiter = rate.NewLimiter(rate.Limit(rps), 1)
+		api.rateLimiter = newStaticRateLimiter(rps)
+		return nil
+	}
+}
+
+// UsingRateLimiter replaces the client's rate limiter. Passing the same
+// RateLimiter to several API instances makes them share a single quota.
+func UsingRateLimiter(limiter RateLimiter) Option {
+	return func(api *API) error {
+		api.rateLimiter = limiter
 		return nil
 	}
 }
@@ -66,6 +69,96 @@ func UsingRetryPolicy(maxRetries int, minRetryDelaySecs int, maxRetryDelaySecs i
 	}
 }
 
+// UsingRetryer replaces the default retry strategy with a custom Retryer,
+// such as a RetryPolicy that opts into replaying non-idempotent requests.
+func UsingRetryer(retryer Retryer) Option {
+	return func(api *API) error {
+		api.retryPolicy = retryer
+		return nil
+	}
+}
+
+// UsingCircuitBreaker fails requests fast with ErrCircuitOpen while the
+// endpoint group they belong to is failing. `breaker` may be shared by
+// several API instances. See CircuitBreaker for details.
+func UsingCircuitBreaker(breaker *CircuitBreaker) Option {
+	return func(api *API) error {
+		api.circuitBreaker = breaker
+		return nil
+	}
+}
+
+// UsingParamValidation checks the parameters of POST and PUT requests with
+// their Validate method, if they have one, before sending them. Invalid
+// parameters are returned as a *ValidationError listing every invalid field.
+//
+// Only the parameters themselves are checked and no additional requests are
+// made. Checks that need other resources are not covered and have to be
+// called explicitly. In particular a load balancer using "dynamic_latency"
+// steering with pools that have no monitor, or referencing pools that don't
+// exist, is only caught by LoadBalancer.ValidatePools. Records conflicting
+// with the rest of the zone are only caught by ValidateDNSRecords or
+// UsingDNSPreflight.
+func UsingParamValidation() Option {
+	return func(api *API) error {
+		api.validateParams = true
+		return nil
+	}
+}
+
+// UsingDNSPreflight validates records passed to CreateDNSRecord and
+// UpdateDNSRecord against the zone and the other records at their name
+// before sending them, see ValidateDNSRecords. Each check costs up to three
+// additional requests. Invalid records are returned as a *ValidationError.
+func UsingDNSPreflight() Option {
+	return func(api *API) error {
+		api.dnsPreflight = true
+		return nil
+	}
+}
+
+// UsingMiddleware appends middleware to the chain every request is sent
+// through. Middleware is called in the order it is provided, so the first
+// middleware sees the request first and the response last.
+func UsingMiddleware(middleware ...Middleware) Option {
+	return func(api *API) error {
+		api.middleware = append(api.middleware, middleware...)
+		return nil
+	}
+}
+
+// UsingCassette records the API interactions to, or replays them from, a
+// cassette. See Cassette for details.
+func UsingCassette(cassette *Cassette) Option {
+	return UsingMiddleware(cassette.Middleware())
+}
+
+// UsingDryRun captures mutating requests into `plan` instead of sending them.
+// Its middleware is always the innermost, regardless of the order of the
+// options, so that all other middleware sees the captured requests. See
+// DryRunPlan for details.
+func UsingDryRun(plan *DryRunPlan) Option {
+	return func(api *API) error {
+		api.dryRun = plan
+		return nil
+	}
+}
+
+// UsingResponseCache serves GET requests from `cache` where possible. See
+// ResponseCache for details.
+func UsingResponseCache(cache *ResponseCache) Option {
+	return UsingMiddleware(cache.Middleware())
+}
+
+// UsingTelemetry reports every API call to `t`, for example to trace and
+// measure them. See the cfotel package for an OpenTelemetry implementation.
+func UsingTelemetry(t Telemetry) Option {
+	return func(api *API) error {
+		api.telemetry = t
+		return nil
+	}
+}
+
 // UsingLogger can be set if you want to get log output from this API instance
 // By default no log output is emitted.
 func UsingLogger(logger Logger) Option {
@@ -94,6 +187,18 @@ func BaseURL(baseURL string) Option {
 	}
 }
 
+// UsingLeveledLogger sets the logger that debug output (enabled with `Debug`)
+// is written to. Credentials and known secret fields are redacted before
+// they are logged. By default debug output is written to stdout.
+func UsingLeveledLogger(logger LeveledLoggerInterface) Option {
+	return func(api *API) error {
+		api.leveledLogger = logger
+		return nil
+	}
+}
+
+// Debug enables logging of every request and response. Credentials and known
+// secret fields are redacted.
 func Debug(debug bool) Option {
 	return func(api *API) error {
 		api.Debug = debug
@@ -152,6 +152,17 @@ func (api *API) ListPagesDeployments(ctx context.Context, rc *ResourceContainer,
 	return r.Result, r.ResultInfo, nil
 }
 
+// ListPagesDeploymentsIterator returns an Iterator over all deployments for a
+// Pages project, fetching each page of results as it is needed.
+//
+// API reference: https://api.cloudflare.com/#pages-deployment-get-deployments
+func (api *API) ListPagesDeploymentsIterator(ctx context.Context, rc *ResourceContainer, params ListPagesDeploymentsParams) *Iterator[PagesProjectDeployment] {
+	return newPaginationIterator(ctx, params.PaginationOptions, func(ctx context.Context, pageOpts PaginationOptions) ([]PagesProjectDeployment, ResultInfo, error) {
+		params.PaginationOptions = pageOpts
+		return api.ListPagesDeployments(ctx, rc, params)
+	})
+}
+
 // GetPagesDeploymentInfo returns a deployment for a Pages project.
 //
 // API reference: https://api.cloudflare.com/#pages-deployment-get-deployment-info
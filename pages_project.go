@@ -171,6 +171,16 @@ func (api *API) ListPagesProjects(ctx context.Context, accountID string, pageOpt
 	return r.Result, r.ResultInfo, nil
 }
 
+// ListPagesProjectsIterator returns an Iterator over all Pages projects for
+// an account, fetching each page of results as it is needed.
+//
+// API reference: https://api.cloudflare.com/#pages-project-get-projects
+func (api *API) ListPagesProjectsIterator(ctx context.Context, accountID string, pageOpts PaginationOptions) *Iterator[PagesProject] {
+	return newPaginationIterator(ctx, pageOpts, func(ctx context.Context, pageOpts PaginationOptions) ([]PagesProject, ResultInfo, error) {
+		return api.ListPagesProjects(ctx, accountID, pageOpts)
+	})
+}
+
 // PagesProject returns a single Pages project by name.
 //
 // API reference: https://api.cloudflare.com/#pages-project-get-project
@@ -1,5 +1,9 @@
 package cloudflare
 
+import (
+	"context"
+)
+
 // Done returns true for the last page and false otherwise.
 func (p ResultInfo) Done() bool {
 	return p.Page > 1 && p.Page > p.TotalPages
@@ -17,3 +21,241 @@ func (p ResultInfo) Next() ResultInfo {
 func (p ResultInfo) HasMorePages() bool {
 	return p.Page > 1 && p.Page < p.TotalPages
 }
+
+// nextPage works out the ResultInfo for the page following `requested` based
+// on the pagination metadata (`p`) returned in the response and the number of
+// items that response contained. The boolean is false once there are no more
+// pages to fetch.
+//
+// Cursor based pagination takes precedence over page numbers as endpoints that
+// return a cursor don't reliably populate the page counters.
+func (p ResultInfo) nextPage(requested ResultInfo, received int) (ResultInfo, bool) {
+	if p.Cursor != "" {
+		requested.Cursor = p.Cursor
+		return requested, true
+	}
+
+	if p.Cursors.After != "" {
+		requested.Cursors = ResultInfoCursors{After: p.Cursors.After}
+		return requested, true
+	}
+
+	// we were following a cursor and the response didn't return another one.
+	if requested.Cursor != "" || requested.Cursors.After != "" {
+		return requested, false
+	}
+
+	if received == 0 {
+		return requested, false
+	}
+
+	if p.TotalPages > 0 {
+		if p.Page >= p.TotalPages {
+			return requested, false
+		}
+	} else if p.PerPage == 0 || received < p.PerPage {
+		// some endpoints don't return `total_pages` so a short (or unsized)
+		// page is the only signal that we've reached the end.
+		return requested, false
+	}
+
+	page := p.Page
+	if page < 1 {
+		page = requested.Page
+	}
+	if page < 1 {
+		page = 1
+	}
+	requested.Page = page + 1
+
+	return requested, true
+}
+
+// PageFetcher retrieves a single page of results. `info` describes the page
+// to request and the returned ResultInfo is the pagination metadata from the
+// response.
+type PageFetcher[T any] func(ctx context.Context, info ResultInfo) ([]T, ResultInfo, error)
+
+// Iterator lazily walks every item of a paginated list endpoint. Pages are
+// only requested once the previous one has been consumed so callers can
+// stream large collections without holding them in memory and stop early
+// without paying for the remaining pages.
+//
+//	it := api.DNSRecordsIterator(ctx, zoneID, DNSRecord{Type: "A"})
+//	for it.Next() {
+//		record := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch PageFetcher[T]
+
+	requested ResultInfo
+	info      ResultInfo
+	started   bool
+	done      bool
+
+	page  []T
+	index int
+	value T
+	err   error
+}
+
+// NewIterator returns an Iterator that starts at the first page and uses
+// `fetch` to retrieve each page. It is mostly useful to stub the iterator
+// methods of the API interfaces in tests.
+func NewIterator[T any](ctx context.Context, fetch PageFetcher[T]) *Iterator[T] {
+	return newIterator(ctx, ResultInfo{Page: 1}, fetch)
+}
+
+// IteratorFromSlice returns an Iterator over `items` that never fetches a
+// page.
+func IteratorFromSlice[T any](items []T) *Iterator[T] {
+	return &Iterator[T]{page: items, started: true, done: true}
+}
+
+// newIterator returns an Iterator that starts at the page described by
+// `start` and uses `fetch` to retrieve each page.
+func newIterator[T any](ctx context.Context, start ResultInfo, fetch PageFetcher[T]) *Iterator[T] {
+	return &Iterator[T]{
+		ctx:       ctx,
+		fetch:     fetch,
+		requested: start,
+	}
+}
+
+// Next advances the iterator to the next item, fetching the next page of
+// results when required. It returns false once all items have been consumed,
+// Stop has been called or an error occurred. The zero value is an empty
+// iterator.
+func (it *Iterator[T]) Next() bool {
+	for it.index >= len(it.page) {
+		if it.done || it.err != nil || it.fetch == nil {
+			return false
+		}
+
+		if it.started {
+			next, ok := it.info.nextPage(it.requested, len(it.page))
+			if !ok {
+				it.Stop()
+				return false
+			}
+			it.requested = next
+		}
+
+		if it.ctx == nil {
+			it.ctx = context.Background()
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+
+		items, info, err := it.fetch(it.ctx, it.requested)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.started = true
+		it.page = items
+		it.index = 0
+		it.info = info
+
+		if len(items) == 0 {
+			it.Stop()
+			return false
+		}
+	}
+
+	it.value = it.page[it.index]
+	it.index++
+
+	return true
+}
+
+// Value returns the current item. It is only valid after a call to Next
+// returned true.
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the first error encountered while fetching pages.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// ResultInfo returns the pagination metadata of the most recently fetched
+// page.
+func (it *Iterator[T]) ResultInfo() ResultInfo {
+	return it.info
+}
+
+// Stop ends the iteration early. No further pages are fetched and the
+// remaining items of the current page are discarded.
+func (it *Iterator[T]) Stop() {
+	it.done = true
+	it.page = nil
+	it.index = 0
+}
+
+// All consumes the remainder of the iterator and returns the collected items.
+func (it *Iterator[T]) All() ([]T, error) {
+	var items []T
+	for it.Next() {
+		items = append(items, it.Value())
+	}
+
+	if it.err != nil {
+		return nil, it.err
+	}
+
+	return items, nil
+}
+
+// newPaginationIterator returns an Iterator for the list methods that take
+// PaginationOptions and return the ResultInfo of each page. Iteration starts
+// at `opts.Page`, or the first page when it isn't set.
+func newPaginationIterator[T any](ctx context.Context, opts PaginationOptions, list func(ctx context.Context, opts PaginationOptions) ([]T, ResultInfo, error)) *Iterator[T] {
+	start := ResultInfo{Page: opts.Page, PerPage: opts.PerPage}
+	if start.Page < 1 {
+		start.Page = 1
+	}
+
+	return newIterator(ctx, start, func(ctx context.Context, info ResultInfo) ([]T, ResultInfo, error) {
+		return list(ctx, PaginationOptions{Page: info.Page, PerPage: info.PerPage})
+	})
+}
+
+// newPageSizeIterator returns an Iterator for the list methods that take
+// PaginationOptions but don't return a ResultInfo. Each page is requested
+// with `opts.PerPage` items, or `perPage` when it isn't set, and iteration
+// stops at the first page that doesn't hold exactly that many items.
+func newPageSizeIterator[T any](ctx context.Context, opts PaginationOptions, perPage int, list func(ctx context.Context, opts PaginationOptions) ([]T, error)) *Iterator[T] {
+	start := ResultInfo{Page: opts.Page, PerPage: opts.PerPage}
+	if start.Page < 1 {
+		start.Page = 1
+	}
+	if start.PerPage < 1 {
+		start.PerPage = perPage
+	}
+
+	return newIterator(ctx, start, func(ctx context.Context, info ResultInfo) ([]T, ResultInfo, error) {
+		items, err := list(ctx, PaginationOptions{Page: info.Page, PerPage: info.PerPage})
+		if err != nil {
+			return nil, ResultInfo{}, err
+		}
+
+		info.Count = len(items)
+		// a short page is the last one and a page larger than requested
+		// means the endpoint ignored `per_page`, so there is no way of
+		// telling where the next one starts.
+		if len(items) != info.PerPage {
+			info.TotalPages = info.Page
+		}
+
+		return items, info, nil
+	})
+}
@@ -0,0 +1,137 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultInfo_nextPage(t *testing.T) {
+	for _, c := range [...]struct {
+		TestName  string
+		Response  ResultInfo
+		Requested ResultInfo
+		Received  int
+		Next      ResultInfo
+		More      bool
+	}{
+		{"more pages", ResultInfo{Page: 1, PerPage: 20, TotalPages: 3}, ResultInfo{Page: 1, PerPage: 20}, 20, ResultInfo{Page: 2, PerPage: 20}, true},
+		{"last page", ResultInfo{Page: 3, PerPage: 20, TotalPages: 3}, ResultInfo{Page: 3, PerPage: 20}, 5, ResultInfo{Page: 3, PerPage: 20}, false},
+		{"no total_pages with a full page", ResultInfo{Page: 1, PerPage: 20}, ResultInfo{Page: 1, PerPage: 20}, 20, ResultInfo{Page: 2, PerPage: 20}, true},
+		{"no total_pages with a short page", ResultInfo{Page: 1, PerPage: 20}, ResultInfo{Page: 1, PerPage: 20}, 19, ResultInfo{Page: 1, PerPage: 20}, false},
+		{"no page metadata", ResultInfo{}, ResultInfo{Page: 1}, 10, ResultInfo{Page: 1}, false},
+		{"empty page", ResultInfo{Page: 1, PerPage: 20, TotalPages: 3}, ResultInfo{Page: 1, PerPage: 20}, 0, ResultInfo{Page: 1, PerPage: 20}, false},
+		{"cursor", ResultInfo{Cursor: "abc"}, ResultInfo{}, 10, ResultInfo{Cursor: "abc"}, true},
+		{"cursors", ResultInfo{Cursors: ResultInfoCursors{Before: "abc", After: "def"}}, ResultInfo{}, 10, ResultInfo{Cursors: ResultInfoCursors{After: "def"}}, true},
+		{"cursor exhausted", ResultInfo{}, ResultInfo{Cursor: "abc"}, 10, ResultInfo{Cursor: "abc"}, false},
+	} {
+		t.Run(c.TestName, func(t *testing.T) {
+			next, more := c.Response.nextPage(c.Requested, c.Received)
+			assert.Equal(t, c.More, more)
+			assert.Equal(t, c.Next, next)
+		})
+	}
+}
+
+func TestIterator_Pages(t *testing.T) {
+	pages := [][]int{{1, 2}, {3, 4}, {5}}
+	var requested []int
+
+	it := newIterator(context.Background(), ResultInfo{Page: 1, PerPage: 2}, func(ctx context.Context, info ResultInfo) ([]int, ResultInfo, error) {
+		requested = append(requested, info.Page)
+		return pages[info.Page-1], ResultInfo{Page: info.Page, PerPage: 2, TotalPages: len(pages)}, nil
+	})
+
+	items, err := it.All()
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, items)
+	assert.Equal(t, []int{1, 2, 3}, requested)
+	assert.Equal(t, 3, it.ResultInfo().Page)
+}
+
+func TestIterator_Cursor(t *testing.T) {
+	cursors := map[string]string{"": "a", "a": "b", "b": ""}
+	var requested []string
+
+	it := newIterator(context.Background(), ResultInfo{}, func(ctx context.Context, info ResultInfo) ([]string, ResultInfo, error) {
+		requested = append(requested, info.Cursor)
+		return []string{"after " + info.Cursor}, ResultInfo{Cursor: cursors[info.Cursor]}, nil
+	})
+
+	items, err := it.All()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"after ", "after a", "after b"}, items)
+	assert.Equal(t, []string{"", "a", "b"}, requested)
+}
+
+func TestIterator_EarlyStop(t *testing.T) {
+	fetches := 0
+	it := newIterator(context.Background(), ResultInfo{Page: 1}, func(ctx context.Context, info ResultInfo) ([]int, ResultInfo, error) {
+		fetches++
+		return []int{info.Page, info.Page}, ResultInfo{Page: info.Page, PerPage: 2, TotalPages: 100}, nil
+	})
+
+	require.True(t, it.Next())
+	assert.Equal(t, 1, it.Value())
+	it.Stop()
+
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+	assert.Equal(t, 1, fetches)
+}
+
+func TestIterator_Error(t *testing.T) {
+	fetchErr := errors.New("boom")
+	it := newIterator(context.Background(), ResultInfo{Page: 1}, func(ctx context.Context, info ResultInfo) ([]int, ResultInfo, error) {
+		if info.Page == 2 {
+			return nil, ResultInfo{}, fetchErr
+		}
+		return []int{1}, ResultInfo{Page: 1, PerPage: 1, TotalPages: 2}, nil
+	})
+
+	items, err := it.All()
+	assert.ErrorIs(t, err, fetchErr)
+	assert.Nil(t, items)
+	assert.False(t, it.Next())
+}
+
+func TestIterator_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	it := newIterator(ctx, ResultInfo{Page: 1}, func(ctx context.Context, info ResultInfo) ([]int, ResultInfo, error) {
+		t.Fatal("unexpected fetch with a cancelled context")
+		return nil, ResultInfo{}, nil
+	})
+
+	assert.False(t, it.Next())
+	assert.ErrorIs(t, it.Err(), context.Canceled)
+}
+
+func TestIterator_ZeroValue(t *testing.T) {
+	var it Iterator[int]
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+}
+
+func TestIteratorFromSlice(t *testing.T) {
+	items, err := IteratorFromSlice([]int{1, 2, 3}).All()
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, items)
+}
+
+func TestNewIterator(t *testing.T) {
+	var requested []int
+	it := NewIterator(context.Background(), func(ctx context.Context, info ResultInfo) ([]int, ResultInfo, error) {
+		requested = append(requested, info.Page)
+		return []int{info.Page}, ResultInfo{Page: info.Page, TotalPages: 2}, nil
+	})
+
+	items, err := it.All()
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, items)
+	assert.Equal(t, []int{1, 2}, requested)
+}
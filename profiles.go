@@ -0,0 +1,264 @@
+package cloudflare
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultProfile is the profile used when none is specified.
+	DefaultProfile = "default"
+
+	// profilesFileEnv overrides the location of the credentials file.
+	profilesFileEnv = "CLOUDFLARE_CREDENTIALS_FILE"
+
+	// profileEnv selects the profile used by NewFromProfile when no name is
+	// given.
+	profileEnv = "CLOUDFLARE_PROFILE"
+)
+
+var (
+	ErrProfileNotFound = errors.New("profile not found in credentials file")
+	ErrProfileInvalid  = errors.New("invalid credentials file")
+)
+
+// Profile is a named set of client settings loaded from a credentials file.
+//
+// The credentials file uses an INI style format with one section per
+// profile:
+//
+//	[default]
+//	api_token = 0123456789abcdef
+//	account_id = 01a7362d577a6c3019a474fd6f485823
+//
+//	[staging]
+//	api_key = deadbeef
+//	api_email = user@example.com
+//	base_url = https://api.staging.example.com/client/v4
+//	rate_limit = 2
+//	max_retries = 5
+//	min_retry_delay = 500ms
+//	max_retry_delay = 1m
+//
+// Retry delays are Go durations or a whole number of seconds.
+type Profile struct {
+	Name string
+	Credentials
+
+	AccountID     string
+	BaseURL       string
+	RateLimit     float64
+	MaxRetries    *int
+	MinRetryDelay time.Duration
+	MaxRetryDelay time.Duration
+}
+
+// DefaultProfilesPath returns the location of the credentials file, which is
+// `~/.cloudflare/credentials` unless overridden by the
+// `CLOUDFLARE_CREDENTIALS_FILE` environment variable.
+func DefaultProfilesPath() (string, error) {
+	if path := os.Getenv(profilesFileEnv); path != "" {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate credentials file: %w", err)
+	}
+
+	return filepath.Join(home, ".cloudflare", "credentials"), nil
+}
+
+// LoadProfiles reads every profile in the credentials file at `path`.
+func LoadProfiles(path string) (map[string]Profile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+	defer f.Close()
+
+	profiles := make(map[string]Profile)
+	var current *Profile
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]") {
+			if current != nil {
+				profiles[current.Name] = *current
+			}
+			current = &Profile{Name: strings.TrimSpace(text[1 : len(text)-1])}
+			continue
+		}
+
+		key, value, found := strings.Cut(text, "=")
+		if !found || current == nil {
+			return nil, fmt.Errorf("%w: line %d: expected a [profile] or key = value", ErrProfileInvalid, line)
+		}
+
+		if err := current.set(strings.TrimSpace(key), strings.Trim(strings.TrimSpace(value), `"'`)); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %s", ErrProfileInvalid, line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+
+	if current != nil {
+		profiles[current.Name] = *current
+	}
+
+	return profiles, nil
+}
+
+// LoadProfile reads the profile called `name` from the credentials file at
+// `path`.
+func LoadProfile(path, name string) (Profile, error) {
+	profiles, err := LoadProfiles(path)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("%w: %q", ErrProfileNotFound, name)
+	}
+
+	return profile, nil
+}
+
+func (p *Profile) set(key, value string) error {
+	var err error
+	switch key {
+	case "api_token":
+		p.APIToken = value
+	case "api_key":
+		p.APIKey = value
+	case "api_email":
+		p.APIEmail = value
+	case "api_user_service_key":
+		p.APIUserServiceKey = value
+	case "account_id":
+		p.AccountID = value
+	case "base_url":
+		p.BaseURL = value
+	case "rate_limit":
+		p.RateLimit, err = strconv.ParseFloat(value, 64)
+	case "max_retries":
+		var retries int
+		retries, err = strconv.Atoi(value)
+		p.MaxRetries = &retries
+	case "min_retry_delay":
+		p.MinRetryDelay, err = parseProfileDuration(value)
+	case "max_retry_delay":
+		p.MaxRetryDelay, err = parseProfileDuration(value)
+	default:
+		return fmt.Errorf("unknown key %q", key)
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// parseProfileDuration parses a Go duration, treating a bare number as
+// seconds.
+func parseProfileDuration(value string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	return time.ParseDuration(value)
+}
+
+// Options returns the client options for the profile's non-credential
+// settings.
+func (p Profile) Options() []Option {
+	var opts []Option
+	if p.BaseURL != "" {
+		opts = append(opts, BaseURL(p.BaseURL))
+	}
+	if p.AccountID != "" {
+		accountID := p.AccountID
+		opts = append(opts, func(api *API) error {
+			api.AccountID = accountID
+			return nil
+		})
+	}
+	if p.RateLimit > 0 {
+		opts = append(opts, UsingRateLimit(p.RateLimit))
+	}
+	if p.MaxRetries != nil || p.MinRetryDelay != 0 || p.MaxRetryDelay != 0 {
+		policy := RetryPolicy{
+			MaxRetries:    3,
+			MinRetryDelay: time.Second,
+			MaxRetryDelay: 30 * time.Second,
+		}
+		if p.MaxRetries != nil {
+			policy.MaxRetries = *p.MaxRetries
+		}
+		if p.MinRetryDelay != 0 {
+			policy.MinRetryDelay = p.MinRetryDelay
+		}
+		if p.MaxRetryDelay != 0 {
+			policy.MaxRetryDelay = p.MaxRetryDelay
+		}
+		opts = append(opts, UsingRetryer(policy))
+	}
+
+	return opts
+}
+
+// NewFromProfile creates a new Cloudflare v4 API client from a profile in the
+// credentials file (see DefaultProfilesPath). When `name` is empty the
+// profile named by the `CLOUDFLARE_PROFILE` environment variable is used,
+// falling back to DefaultProfile. `opts` are applied after the profile's
+// settings so they take precedence.
+func NewFromProfile(name string, opts ...Option) (*API, error) {
+	if name == "" {
+		name = os.Getenv(profileEnv)
+	}
+	if name == "" {
+		name = DefaultProfile
+	}
+
+	path, err := DefaultProfilesPath()
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := LoadProfile(path, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return newFromProfile(profile, opts...)
+}
+
+func newFromProfile(profile Profile, opts ...Option) (*API, error) {
+	opts = append(profile.Options(), opts...)
+
+	switch {
+	case profile.APIToken != "" && profile.APIKey != "":
+		return nil, ErrAPIKeysAndTokensAreMutuallyExclusive
+	case profile.APIToken != "":
+		return NewWithAPIToken(profile.APIToken, opts...)
+	case profile.APIKey != "":
+		return New(profile.APIKey, profile.APIEmail, opts...)
+	case profile.APIUserServiceKey != "":
+		return NewWithUserServiceKey(profile.APIUserServiceKey, opts...)
+	default:
+		return nil, fmt.Errorf("profile %q: %w", profile.Name, ErrMissingCredentials)
+	}
+}
@@ -0,0 +1,99 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProfiles = `# shared credentials
+[default]
+api_token = "0123456789abcdef"
+account_id = 01a7362d577a6c3019a474fd6f485823
+
+[staging]
+api_key = deadbeef
+api_email = cloudflare@example.org
+base_url = %s
+rate_limit = 2
+max_retries = 5
+min_retry_delay = 500ms
+max_retry_delay = 10
+`
+
+func writeTestProfiles(t *testing.T, baseURL string) string {
+	path := filepath.Join(t.TempDir(), "credentials")
+	require.NoError(t, os.WriteFile(path, []byte(fmt.Sprintf(testProfiles, baseURL)), 0600))
+	return path
+}
+
+func TestLoadProfiles(t *testing.T) {
+	path := writeTestProfiles(t, "https://api.staging.example.com/client/v4")
+
+	profiles, err := LoadProfiles(path)
+	require.NoError(t, err)
+	require.Len(t, profiles, 2)
+
+	assert.Equal(t, "0123456789abcdef", profiles["default"].APIToken)
+	assert.Equal(t, "01a7362d577a6c3019a474fd6f485823", profiles["default"].AccountID)
+
+	retries := 5
+	assert.Equal(t, Profile{
+		Name:          "staging",
+		Credentials:   Credentials{APIKey: "deadbeef", APIEmail: "cloudflare@example.org"},
+		BaseURL:       "https://api.staging.example.com/client/v4",
+		RateLimit:     2,
+		MaxRetries:    &retries,
+		MinRetryDelay: 500 * time.Millisecond,
+		MaxRetryDelay: 10 * time.Second,
+	}, profiles["staging"])
+
+	_, err = LoadProfile(path, "production")
+	assert.ErrorIs(t, err, ErrProfileNotFound)
+}
+
+func TestLoadProfiles_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+
+	for _, contents := range []string{"api_token = outside a profile", "[default]\nregion = eu", "[default]\nmax_retries = lots"} {
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+		_, err := LoadProfiles(path)
+		assert.ErrorIs(t, err, ErrProfileInvalid, contents)
+	}
+}
+
+func TestNewFromProfile(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "deadbeef", r.Header.Get("X-Auth-Key"))
+		assert.Equal(t, "cloudflare@example.org", r.Header.Get("X-Auth-Email"))
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "7c5dae5552338874e5053f2534d2767a", "email": "cloudflare@example.org"}}`)
+	})
+
+	t.Setenv("CLOUDFLARE_CREDENTIALS_FILE", writeTestProfiles(t, server.URL))
+	t.Setenv("CLOUDFLARE_PROFILE", "staging")
+
+	api, err := NewFromProfile("", UsingRateLimit(100000))
+	require.NoError(t, err)
+	assert.Equal(t, server.URL, api.BaseURL)
+	assert.Equal(t, RetryPolicy{MaxRetries: 5, MinRetryDelay: 500 * time.Millisecond, MaxRetryDelay: 10 * time.Second}, api.retryPolicy)
+
+	user, err := api.UserDetails(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "cloudflare@example.org", user.Email)
+
+	api, err = NewFromProfile(DefaultProfile)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789abcdef", api.APIToken)
+	assert.Equal(t, "01a7362d577a6c3019a474fd6f485823", api.AccountID)
+}
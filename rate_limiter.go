@@ -0,0 +1,205 @@
+package cloudflare
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimiter controls how quickly requests are sent to the API. A single
+// RateLimiter can be shared by several API instances (using
+// `UsingRateLimiter`) so that they stay within the same quota.
+type RateLimiter interface {
+	// Wait blocks until a request against the resource container may be
+	// sent. `rc` is nil for requests that don't target an account, zone or
+	// user.
+	Wait(ctx context.Context, rc *ResourceContainer) error
+
+	// Observe is called with the response of every request that completed
+	// so the limiter can adjust its rate.
+	Observe(rc *ResourceContainer, resp *http.Response)
+}
+
+// staticRateLimiter is a RateLimiter with a fixed rate that ignores responses.
+type staticRateLimiter struct {
+	limiter *rate.Limiter
+}
+
+func newStaticRateLimiter(rps float64) *staticRateLimiter {
+	// because ratelimiter doesnt do any windowing
+	// setting burst makes it difficult to enforce a fixed rate
+	// so setting it equal to 1 this effectively disables bursting
+	// this doesn't check for sensible values, ultimately the api will enforce that the value is ok
+	return &staticRateLimiter{limiter: rate.NewLimiter(rate.Limit(rps), 1)}
+}
+
+func (l *staticRateLimiter) Wait(ctx context.Context, _ *ResourceContainer) error {
+	return l.limiter.Wait(ctx)
+}
+
+func (l *staticRateLimiter) Observe(_ *ResourceContainer, _ *http.Response) {}
+
+// AdaptiveRateLimiter is a token bucket RateLimiter that halves its rate
+// whenever the API responds with a HTTP 429 and gradually speeds back up to
+// the configured rate as requests succeed again. It is safe for concurrent
+// use.
+type AdaptiveRateLimiter struct {
+	mu      sync.Mutex
+	limiter *rate.Limiter
+	max     rate.Limit
+	min     rate.Limit
+}
+
+// NewAdaptiveRateLimiter returns an AdaptiveRateLimiter allowing up to `rps`
+// requests per second with bursts of up to `burst` requests. The rate never
+// drops below 1/16th of `rps`.
+func NewAdaptiveRateLimiter(rps float64, burst int) *AdaptiveRateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &AdaptiveRateLimiter{
+		limiter: rate.NewLimiter(rate.Limit(rps), burst),
+		max:     rate.Limit(rps),
+		min:     rate.Limit(rps / 16),
+	}
+}
+
+// Wait implements RateLimiter.
+func (l *AdaptiveRateLimiter) Wait(ctx context.Context, _ *ResourceContainer) error {
+	return l.limiter.Wait(ctx)
+}
+
+// Observe implements RateLimiter.
+func (l *AdaptiveRateLimiter) Observe(_ *ResourceContainer, resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	current := l.limiter.Limit()
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		next := current / 2
+		if next < l.min {
+			next = l.min
+		}
+		l.limiter.SetLimit(next)
+	case resp.StatusCode < http.StatusBadRequest && current < l.max:
+		next := current + l.max/20
+		if next > l.max {
+			next = l.max
+		}
+		l.limiter.SetLimit(next)
+	}
+}
+
+// Limit returns the current rate in requests per second.
+func (l *AdaptiveRateLimiter) Limit() float64 {
+	return float64(l.limiter.Limit())
+}
+
+// RateLimitKey returns the bucket of a PerResourceRateLimiter that a request
+// against `rc` is counted in. `rc` is nil for requests that don't target an
+// account, zone or user.
+type RateLimitKey func(rc *ResourceContainer) string
+
+// RateLimitByAccount keys requests by the account they target, which is how
+// the API applies its quotas. Zone URIs don't name the account of the zone so
+// zone and user requests share a single bucket with the requests that don't
+// target an account; use a RateLimitKey that maps zone identifiers to their
+// account to count them against it instead.
+func RateLimitByAccount(rc *ResourceContainer) string {
+	if rc == nil || rc.Level != AccountRouteLevel {
+		return ""
+	}
+
+	return string(rc.Level) + "/" + rc.Identifier
+}
+
+// RateLimitByResource keys requests by the account, zone or user they target.
+func RateLimitByResource(rc *ResourceContainer) string {
+	if rc == nil {
+		return ""
+	}
+
+	return string(rc.Level) + "/" + rc.Identifier
+}
+
+// PerResourceRateLimiter keeps a separate RateLimiter for every bucket of
+// requests, by default one per account. It is safe for concurrent use.
+type PerResourceRateLimiter struct {
+	// Key selects the bucket of each request. Defaults to RateLimitByAccount.
+	// It must not be changed once the limiter is in use.
+	Key RateLimitKey
+
+	mu       sync.Mutex
+	limiters map[string]RateLimiter
+	newFunc  func() RateLimiter
+}
+
+// NewPerResourceRateLimiter returns a PerResourceRateLimiter that uses
+// `newFunc` to create the limiter for each bucket the first time it is seen.
+func NewPerResourceRateLimiter(newFunc func() RateLimiter) *PerResourceRateLimiter {
+	return &PerResourceRateLimiter{
+		limiters: make(map[string]RateLimiter),
+		newFunc:  newFunc,
+	}
+}
+
+// Wait implements RateLimiter.
+func (l *PerResourceRateLimiter) Wait(ctx context.Context, rc *ResourceContainer) error {
+	return l.limiterFor(rc).Wait(ctx, rc)
+}
+
+// Observe implements RateLimiter.
+func (l *PerResourceRateLimiter) Observe(rc *ResourceContainer, resp *http.Response) {
+	l.limiterFor(rc).Observe(rc, resp)
+}
+
+func (l *PerResourceRateLimiter) limiterFor(rc *ResourceContainer) RateLimiter {
+	keyFunc := l.Key
+	if keyFunc == nil {
+		keyFunc = RateLimitByAccount
+	}
+	key := keyFunc(rc)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limiter, ok := l.limiters[key]
+	if !ok {
+		limiter = l.newFunc()
+		l.limiters[key] = limiter
+	}
+
+	return limiter
+}
+
+// resourceContainerFromURI works out which account, zone or user a request
+// URI targets. It returns nil when the URI isn't scoped to any of them.
+func resourceContainerFromURI(uri string) *ResourceContainer {
+	path := uri
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case parts[0] == string(UserRouteLevel):
+		return UserIdentifier("")
+	case len(parts) < 2 || parts[1] == "":
+		return nil
+	case parts[0] == string(AccountRouteLevel):
+		return AccountIdentifier(parts[1])
+	case parts[0] == string(ZoneRouteLevel):
+		return ZoneIdentifier(parts[1])
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,118 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptiveRateLimiter(t *testing.T) {
+	limiter := NewAdaptiveRateLimiter(4, 1)
+	assert.Equal(t, 4.0, limiter.Limit())
+
+	limiter.Observe(nil, &http.Response{StatusCode: http.StatusTooManyRequests})
+	assert.Equal(t, 2.0, limiter.Limit())
+
+	limiter.Observe(nil, &http.Response{StatusCode: http.StatusTooManyRequests})
+	assert.Equal(t, 1.0, limiter.Limit())
+
+	for i := 0; i < 10; i++ {
+		limiter.Observe(nil, &http.Response{StatusCode: http.StatusTooManyRequests})
+	}
+	assert.Equal(t, 0.25, limiter.Limit(), "rate should not drop below the floor")
+
+	limiter.Observe(nil, &http.Response{StatusCode: http.StatusOK})
+	assert.InDelta(t, 0.45, limiter.Limit(), 0.0001)
+
+	limiter.Observe(nil, &http.Response{StatusCode: http.StatusBadRequest})
+	assert.InDelta(t, 0.45, limiter.Limit(), 0.0001, "client errors should not change the rate")
+
+	for i := 0; i < 100; i++ {
+		limiter.Observe(nil, &http.Response{StatusCode: http.StatusOK})
+	}
+	assert.Equal(t, 4.0, limiter.Limit(), "rate should recover up to the configured maximum")
+}
+
+func TestPerResourceRateLimiter(t *testing.T) {
+	limiters := map[*AdaptiveRateLimiter]bool{}
+	newLimiter := func() RateLimiter {
+		l := NewAdaptiveRateLimiter(4, 1)
+		limiters[l] = true
+		return l
+	}
+
+	// zones share the bucket of requests that don't target an account.
+	limiter := NewPerResourceRateLimiter(newLimiter)
+	account := AccountIdentifier(testAccountID)
+	limiter.Observe(account, &http.Response{StatusCode: http.StatusTooManyRequests})
+	limiter.Observe(ZoneIdentifier(testZoneID), &http.Response{StatusCode: http.StatusTooManyRequests})
+	assert.NoError(t, limiter.Wait(context.Background(), AccountIdentifier(testAccountID)))
+	assert.NoError(t, limiter.Wait(context.Background(), nil))
+
+	assert.Len(t, limiters, 2)
+	assert.Equal(t, 2.0, limiter.limiterFor(account).(*AdaptiveRateLimiter).Limit())
+	assert.Equal(t, 2.0, limiter.limiterFor(nil).(*AdaptiveRateLimiter).Limit())
+	assert.Equal(t, 4.0, limiter.limiterFor(AccountIdentifier("other")).(*AdaptiveRateLimiter).Limit())
+
+	limiters = map[*AdaptiveRateLimiter]bool{}
+	limiter = NewPerResourceRateLimiter(newLimiter)
+	limiter.Key = RateLimitByResource
+	limiter.Observe(account, &http.Response{StatusCode: http.StatusTooManyRequests})
+	limiter.Observe(ZoneIdentifier(testZoneID), &http.Response{StatusCode: http.StatusOK})
+	assert.NoError(t, limiter.Wait(context.Background(), nil))
+
+	assert.Len(t, limiters, 3)
+	assert.Equal(t, 2.0, limiter.limiterFor(account).(*AdaptiveRateLimiter).Limit())
+	assert.Equal(t, 4.0, limiter.limiterFor(ZoneIdentifier(testZoneID)).(*AdaptiveRateLimiter).Limit())
+}
+
+func TestResourceContainerFromURI(t *testing.T) {
+	for _, c := range [...]struct {
+		URI      string
+		Expected *ResourceContainer
+	}{
+		{"/accounts/" + testAccountID + "/storage/kv/namespaces", AccountIdentifier(testAccountID)},
+		{"/zones/" + testZoneID + "/dns_records?page=2", ZoneIdentifier(testZoneID)},
+		{"/zones?name=example.com", nil},
+		{"/user/tokens/verify", UserIdentifier("")},
+		{"/ips", nil},
+	} {
+		t.Run(c.URI, func(t *testing.T) {
+			assert.Equal(t, c.Expected, resourceContainerFromURI(c.URI))
+		})
+	}
+}
+
+type recordingRateLimiter struct {
+	waits    []*ResourceContainer
+	statuses []int
+}
+
+func (l *recordingRateLimiter) Wait(_ context.Context, rc *ResourceContainer) error {
+	l.waits = append(l.waits, rc)
+	return nil
+}
+
+func (l *recordingRateLimiter) Observe(_ *ResourceContainer, resp *http.Response) {
+	l.statuses = append(l.statuses, resp.StatusCode)
+}
+
+func TestClient_UsingRateLimiter(t *testing.T) {
+	limiter := &recordingRateLimiter{}
+	setup(UsingRateLimiter(limiter))
+	defer teardown()
+
+	mux.HandleFunc("/zones/"+testZoneID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "`+testZoneID+`"}}`)
+	})
+
+	_, err := client.ZoneDetails(context.Background(), testZoneID)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*ResourceContainer{ZoneIdentifier(testZoneID)}, limiter.waits)
+	assert.Equal(t, []int{http.StatusOK}, limiter.statuses)
+}
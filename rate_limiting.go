@@ -122,31 +122,33 @@ func (api *API) ListRateLimits(ctx context.Context, zoneID string, pageOpts Pagi
 //
 // API reference: https://api.cloudflare.com/#rate-limits-for-a-zone-list-rate-limits
 func (api *API) ListAllRateLimits(ctx context.Context, zoneID string) ([]RateLimit, error) {
-	pageOpts := PaginationOptions{
-		PerPage: 100, // this is the max page size allowed
-		Page:    1,
+	allRateLimits, err := api.ListRateLimitsIterator(ctx, zoneID).All()
+	if err != nil {
+		return []RateLimit{}, err
 	}
 
-	allRateLimits := make([]RateLimit, 0)
-	for {
-		rateLimits, resultInfo, err := api.ListRateLimits(ctx, zoneID, pageOpts)
-		if err != nil {
-			return []RateLimit{}, err
-		}
-		allRateLimits = append(allRateLimits, rateLimits...)
-		// total pages is not returned on this call
-		// if number of records is less than the max, this must be the last page
-		// in case TotalCount % PerPage = 0, the last request will return an empty list
-		if resultInfo.Count < resultInfo.PerPage {
-			break
-		}
-		// continue with the next page
-		pageOpts.Page = pageOpts.Page + 1
+	if allRateLimits == nil {
+		allRateLimits = make([]RateLimit, 0)
 	}
 
 	return allRateLimits, nil
 }
 
+// ListRateLimitsIterator returns an Iterator over all Rate Limits for a zone,
+// fetching each page of results as it is needed.
+//
+// API reference: https://api.cloudflare.com/#rate-limits-for-a-zone-list-rate-limits
+func (api *API) ListRateLimitsIterator(ctx context.Context, zoneID string) *Iterator[RateLimit] {
+	start := ResultInfo{
+		PerPage: 100, // this is the max page size allowed
+		Page:    1,
+	}
+
+	return newIterator(ctx, start, func(ctx context.Context, info ResultInfo) ([]RateLimit, ResultInfo, error) {
+		return api.ListRateLimits(ctx, zoneID, PaginationOptions{Page: info.Page, PerPage: info.PerPage})
+	})
+}
+
 // RateLimit fetches detail about one Rate Limit for a zone.
 //
 // API reference: https://api.cloudflare.com/#rate-limits-for-a-zone-rate-limit-details
@@ -0,0 +1,363 @@
+package cloudflare
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheSize is the number of responses kept by the LRUCacheStore a
+// ResponseCache uses when it isn't given a store.
+const DefaultCacheSize = 1000
+
+// CacheEntry is a successful GET response held in a CacheStore.
+type CacheEntry struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	Expires    time.Time
+}
+
+// CacheStore holds cached responses. Implementations must be safe for
+// concurrent use. Keys are opaque strings made up of the credentials, method
+// and URI of the request.
+type CacheStore interface {
+	Get(key string) (CacheEntry, bool)
+	Set(key string, entry CacheEntry)
+	Delete(key string)
+
+	// Keys returns every key in the store. It is used to find the entries
+	// to evict when a resource is changed.
+	Keys() []string
+}
+
+// CacheEndpoint sets the TTL of the responses of endpoints whose path (the
+// URI without the query string) matches Path. A `*` segment in Path matches
+// any single segment, e.g. "/zones/*/dns_records".
+type CacheEndpoint struct {
+	Path string
+	TTL  time.Duration
+}
+
+// ResponseCache caches successful GET responses of read heavy endpoints such
+// as zone details and DNS record lists. Entries are keyed on the request
+// method, URI and credentials, so clients using different credentials never
+// share responses.
+//
+// A mutating request evicts the cached responses of the resource it targets,
+// of everything beneath it and of the collection it belongs to, so a POST to
+// "/zones/{id}/dns_records" evicts that zone's DNS record lists and a PATCH
+// to "/zones/{id}/dns_records/{record_id}" evicts both the record and the
+// lists. Changes made by other clients aren't seen until the entry expires.
+//
+//	cache := &cloudflare.ResponseCache{
+//		Endpoints: []cloudflare.CacheEndpoint{
+//			{Path: "/zones", TTL: time.Hour},
+//			{Path: "/zones/*", TTL: 10 * time.Minute},
+//			{Path: "/zones/*/dns_records", TTL: time.Minute},
+//		},
+//	}
+//	api, err := cloudflare.NewWithAPIToken(token, cloudflare.UsingResponseCache(cache))
+//
+// Cache hits still pass through the client's rate limiter.
+type ResponseCache struct {
+	// Store holds the cached responses. It defaults to an LRUCacheStore of
+	// DefaultCacheSize entries.
+	Store CacheStore
+
+	// Endpoints sets the TTL of individual endpoints. The first matching
+	// endpoint is used.
+	Endpoints []CacheEndpoint
+
+	// DefaultTTL is the TTL of endpoints that don't match any of Endpoints.
+	// Responses of those endpoints aren't cached when it is zero.
+	DefaultTTL time.Duration
+
+	once sync.Once
+}
+
+type cacheBypassKey struct{}
+
+// WithCacheBypass returns a context whose requests skip any ResponseCache
+// lookups. The responses are still stored so later requests see them.
+func WithCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+func (c *ResponseCache) store() CacheStore {
+	c.once.Do(func() {
+		if c.Store == nil {
+			c.Store = NewLRUCacheStore(DefaultCacheSize)
+		}
+	})
+
+	return c.Store
+}
+
+// ttl returns how long responses from `path` are cached for.
+func (c *ResponseCache) ttl(path string) time.Duration {
+	for _, e := range c.Endpoints {
+		if matchCachePath(e.Path, path) {
+			return e.TTL
+		}
+	}
+
+	return c.DefaultTTL
+}
+
+// Invalidate evicts the cached responses of `path` in the same way a
+// mutating request to it would.
+func (c *ResponseCache) Invalidate(path string) {
+	path = strings.TrimSuffix(path, "/")
+	parent := path[:strings.LastIndex(path, "/")+1]
+	parent = strings.TrimSuffix(parent, "/")
+
+	store := c.store()
+	for _, key := range store.Keys() {
+		uri, ok := cacheKeyURI(key)
+		if !ok {
+			continue
+		}
+
+		p := uriPath(uri)
+		if p == path || p == parent || strings.HasPrefix(p, path+"/") {
+			store.Delete(key)
+		}
+	}
+}
+
+// Purge evicts every cached response.
+func (c *ResponseCache) Purge() {
+	store := c.store()
+	for _, key := range store.Keys() {
+		store.Delete(key)
+	}
+}
+
+// Middleware returns the middleware that serves and stores cached responses.
+// Use UsingResponseCache rather than adding it directly.
+func (c *ResponseCache) Middleware() Middleware {
+	return func(next RequestHandler) RequestHandler {
+		return func(req *MiddlewareRequest) (*MiddlewareResponse, error) {
+			if isMutating(req.Method) {
+				res, err := next(req)
+				// requests captured by a dry run never reached the API so
+				// nothing changed.
+				if !errors.Is(err, ErrDryRun) {
+					c.Invalidate(uriPath(req.URI))
+				}
+				return res, err
+			}
+
+			ttl := c.ttl(uriPath(req.URI))
+			if req.Method != http.MethodGet || ttl <= 0 {
+				return next(req)
+			}
+
+			store := c.store()
+			key := cacheKey(req)
+			if bypass, _ := req.Request.Context().Value(cacheBypassKey{}).(bool); !bypass {
+				if entry, ok := store.Get(key); ok {
+					if time.Now().Before(entry.Expires) {
+						return cachedResponse(req, entry), nil
+					}
+					store.Delete(key)
+				}
+			}
+
+			res, err := next(req)
+			if err != nil || res == nil || res.Response == nil {
+				return res, err
+			}
+
+			if res.Response.StatusCode == http.StatusOK && res.Envelope != nil && res.Envelope.Success {
+				body, err := ioutil.ReadAll(res.Response.Body)
+				if err != nil {
+					return nil, err
+				}
+				res.Response.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+				store.Set(key, CacheEntry{
+					StatusCode: res.Response.StatusCode,
+					Header:     res.Response.Header.Clone(),
+					Body:       body,
+					Expires:    time.Now().Add(ttl),
+				})
+			}
+
+			return res, nil
+		}
+	}
+}
+
+func cachedResponse(req *MiddlewareRequest, entry CacheEntry) *MiddlewareResponse {
+	var envelope *Response
+	if err := json.Unmarshal(entry.Body, &envelope); err != nil {
+		envelope = nil
+	}
+
+	return &MiddlewareResponse{
+		Response: &http.Response{
+			Status:     fmt.Sprintf("%d %s", entry.StatusCode, http.StatusText(entry.StatusCode)),
+			StatusCode: entry.StatusCode,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     entry.Header.Clone(),
+			Body:       ioutil.NopCloser(bytes.NewReader(entry.Body)),
+			Request:    req.Request,
+		},
+		Envelope: envelope,
+	}
+}
+
+// cacheKey identifies a request by a hash of its credentials, its method and
+// its URI.
+func cacheKey(req *MiddlewareRequest) string {
+	h := sha256.New()
+	for _, name := range []string{"Authorization", "X-Auth-Email", "X-Auth-Key", "X-Auth-User-Service-Key"} {
+		h.Write([]byte(req.Request.Header.Get(name)))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil)[:8]) + " " + req.Method + " " + req.URI
+}
+
+// cacheKeyURI returns the URI of the request identified by `key`.
+func cacheKeyURI(key string) (string, bool) {
+	parts := strings.SplitN(key, " ", 3)
+	if len(parts) != 3 {
+		return "", false
+	}
+
+	return parts[2], true
+}
+
+// uriPath strips the query string from `uri`.
+func uriPath(uri string) string {
+	if i := strings.IndexByte(uri, '?'); i >= 0 {
+		uri = uri[:i]
+	}
+
+	return strings.TrimSuffix(uri, "/")
+}
+
+// matchCachePath reports whether `path` matches `pattern`, where a `*`
+// segment in the pattern matches any single segment.
+func matchCachePath(pattern, path string) bool {
+	ps := strings.Split(strings.Trim(pattern, "/"), "/")
+	ss := strings.Split(strings.Trim(path, "/"), "/")
+	if len(ps) != len(ss) {
+		return false
+	}
+
+	for i := range ps {
+		if ps[i] != "*" && ps[i] != ss[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// LRUCacheStore is an in-memory CacheStore that evicts the least recently
+// used entry once it holds its maximum number of entries.
+type LRUCacheStore struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruCacheItem struct {
+	key   string
+	entry CacheEntry
+}
+
+// NewLRUCacheStore returns an LRUCacheStore holding up to `size` entries.
+func NewLRUCacheStore(size int) *LRUCacheStore {
+	if size < 1 {
+		size = 1
+	}
+
+	return &LRUCacheStore{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get implements CacheStore.
+func (s *LRUCacheStore) Get(key string) (CacheEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, ok := s.entries[key]
+	if !ok {
+		return CacheEntry{}, false
+	}
+	s.order.MoveToFront(el)
+
+	return el.Value.(*lruCacheItem).entry, true
+}
+
+// Set implements CacheStore.
+func (s *LRUCacheStore) Set(key string, entry CacheEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.entries[key]; ok {
+		el.Value.(*lruCacheItem).entry = entry
+		s.order.MoveToFront(el)
+		return
+	}
+
+	s.entries[key] = s.order.PushFront(&lruCacheItem{key: key, entry: entry})
+	for s.order.Len() > s.size {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*lruCacheItem).key)
+	}
+}
+
+// Delete implements CacheStore.
+func (s *LRUCacheStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.entries[key]; ok {
+		s.order.Remove(el)
+		delete(s.entries, key)
+	}
+}
+
+// Keys implements CacheStore.
+func (s *LRUCacheStore) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make([]string, 0, len(s.entries))
+	for key := range s.entries {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// Len returns the number of entries in the store.
+func (s *LRUCacheStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.order.Len()
+}
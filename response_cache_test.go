@@ -0,0 +1,146 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCache(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cache := &ResponseCache{
+		Endpoints: []CacheEndpoint{
+			{Path: "/zones/*/dns_records", TTL: time.Minute},
+		},
+	}
+	require.NoError(t, UsingResponseCache(cache)(client))
+
+	var lists, details int
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			lists++
+			fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": [{"id": "372e67954025e0ba6aaa6d586b9e0b59", "type": "A", "name": "www.example.com", "content": "198.51.100.%d"}], "result_info": {"page": 1, "per_page": 100, "count": 1, "total_count": 1, "total_pages": 1}}`, lists)
+		case http.MethodPost:
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "9a7806061c88ada191ed06f989cc3dac", "type": "A", "name": "api.example.com", "content": "198.51.100.9"}}`)
+		}
+	})
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records/372e67954025e0ba6aaa6d586b9e0b59", func(w http.ResponseWriter, r *http.Request) {
+		details++
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "372e67954025e0ba6aaa6d586b9e0b59", "type": "A", "name": "www.example.com", "content": "198.51.100.4"}}`)
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		records, err := client.DNSRecords(ctx, testZoneID, DNSRecord{})
+		require.NoError(t, err)
+		assert.Equal(t, "198.51.100.1", records[0].Content)
+	}
+	assert.Equal(t, 1, lists)
+
+	// endpoints without a TTL aren't cached.
+	for i := 0; i < 2; i++ {
+		_, err := client.DNSRecord(ctx, testZoneID, "372e67954025e0ba6aaa6d586b9e0b59")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, details)
+
+	records, err := client.DNSRecords(WithCacheBypass(ctx), testZoneID, DNSRecord{})
+	require.NoError(t, err)
+	assert.Equal(t, "198.51.100.2", records[0].Content)
+
+	// creating a record evicts the zone's record lists.
+	_, err = client.CreateDNSRecord(ctx, testZoneID, DNSRecord{Type: "A", Name: "api.example.com", Content: "198.51.100.9"})
+	require.NoError(t, err)
+
+	records, err = client.DNSRecords(ctx, testZoneID, DNSRecord{})
+	require.NoError(t, err)
+	assert.Equal(t, "198.51.100.3", records[0].Content)
+	assert.Equal(t, 3, lists)
+}
+
+func TestResponseCache_DryRun(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cache := &ResponseCache{
+		Endpoints: []CacheEndpoint{
+			{Path: "/zones/*/dns_records", TTL: time.Minute},
+		},
+	}
+	require.NoError(t, UsingResponseCache(cache)(client))
+	require.NoError(t, UsingDryRun(&DryRunPlan{})(client))
+
+	lists := 0
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "mutating request was sent")
+		lists++
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [], "result_info": {"page": 1, "per_page": 100, "count": 0, "total_count": 0, "total_pages": 1}}`)
+	})
+
+	ctx := context.Background()
+	_, err := client.DNSRecords(ctx, testZoneID, DNSRecord{})
+	require.NoError(t, err)
+
+	// the record isn't created so the cached lists are still valid.
+	_, err = client.CreateDNSRecord(ctx, testZoneID, DNSRecord{Type: "A", Name: "api.example.com", Content: "198.51.100.9"})
+	assert.ErrorIs(t, err, ErrDryRun)
+
+	_, err = client.DNSRecords(ctx, testZoneID, DNSRecord{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, lists)
+}
+
+func TestResponseCache_Invalidate(t *testing.T) {
+	store := NewLRUCacheStore(10)
+	cache := &ResponseCache{Store: store}
+
+	for _, uri := range []string{
+		"/zones?name=example.com",
+		"/zones/" + testZoneID,
+		"/zones/" + testZoneID + "/dns_records?page=1",
+		"/zones/" + testZoneID + "/dns_records/372e67954025e0ba6aaa6d586b9e0b59",
+		"/user/tokens/permission_groups",
+	} {
+		store.Set("0011223344556677 GET "+uri, CacheEntry{})
+	}
+
+	cache.Invalidate("/zones/" + testZoneID + "/dns_records/372e67954025e0ba6aaa6d586b9e0b59")
+	assert.ElementsMatch(t, []string{
+		"0011223344556677 GET /zones?name=example.com",
+		"0011223344556677 GET /zones/" + testZoneID,
+		"0011223344556677 GET /user/tokens/permission_groups",
+	}, store.Keys())
+
+	cache.Invalidate("/zones/" + testZoneID)
+	assert.Equal(t, []string{"0011223344556677 GET /user/tokens/permission_groups"}, store.Keys())
+}
+
+func TestLRUCacheStore(t *testing.T) {
+	store := NewLRUCacheStore(2)
+	store.Set("a", CacheEntry{Body: []byte("a")})
+	store.Set("b", CacheEntry{Body: []byte("b")})
+
+	_, ok := store.Get("a")
+	require.True(t, ok)
+
+	store.Set("c", CacheEntry{Body: []byte("c")})
+	assert.Equal(t, 2, store.Len())
+
+	_, ok = store.Get("b")
+	assert.False(t, ok, "least recently used entry wasn't evicted")
+
+	entry, ok := store.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, []byte("a"), entry.Body)
+}
@@ -0,0 +1,136 @@
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+// ResponseMetadata describes a response received from the API. It carries the
+// parts of the response envelope that aren't returned by the individual
+// methods such as warning and deprecation notices in `messages`.
+type ResponseMetadata struct {
+	Method     string
+	URI        string
+	StatusCode int
+
+	// RayID is the `cf-ray` header which identifies the request when
+	// contacting Cloudflare support.
+	RayID string
+
+	// Success, Errors and Messages are decoded from the response envelope.
+	// A successful response may still include errors when only part of the
+	// result could be returned.
+	Success  bool
+	Errors   []ResponseInfo
+	Messages []ResponseInfo
+
+	// RateLimit is the rate limit state reported in the response headers.
+	RateLimit RatelimitQuota
+
+	Header http.Header
+}
+
+// ResponseCollector records the metadata of every API response received by a
+// request whose context it has been attached to with WithResponseCollector.
+// It is safe for concurrent use.
+type ResponseCollector struct {
+	mu        sync.Mutex
+	responses []ResponseMetadata
+}
+
+type responseCollectorContextKey struct{}
+
+// WithResponseCollector returns a copy of `ctx` which records the metadata of
+// the API responses received by requests made with it into `collector`.
+//
+//	collector := &cloudflare.ResponseCollector{}
+//	records, _, err := api.ListDNSRecords(cloudflare.WithResponseCollector(ctx, collector), rc, params)
+//	for _, m := range collector.Messages() {
+//		log.Printf("warning: %d: %s", m.Code, m.Message)
+//	}
+func WithResponseCollector(ctx context.Context, collector *ResponseCollector) context.Context {
+	return context.WithValue(ctx, responseCollectorContextKey{}, collector)
+}
+
+// Responses returns the metadata of the responses collected so far in the
+// order they were received.
+func (c *ResponseCollector) Responses() []ResponseMetadata {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]ResponseMetadata(nil), c.responses...)
+}
+
+// Last returns the metadata of the most recently received response.
+func (c *ResponseCollector) Last() (ResponseMetadata, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.responses) == 0 {
+		return ResponseMetadata{}, false
+	}
+
+	return c.responses[len(c.responses)-1], true
+}
+
+// Messages returns the `messages` of every collected response.
+func (c *ResponseCollector) Messages() []ResponseInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var messages []ResponseInfo
+	for _, r := range c.responses {
+		messages = append(messages, r.Messages...)
+	}
+
+	return messages
+}
+
+// Reset discards the collected responses.
+func (c *ResponseCollector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.responses = nil
+}
+
+func (c *ResponseCollector) add(m ResponseMetadata) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.responses = append(c.responses, m)
+}
+
+// collectResponse records the response in the collector attached to `ctx`,
+// if any. The body is only decoded when there is somewhere to record it.
+func collectResponse(ctx context.Context, method, uri string, resp *http.Response, respBody []byte) {
+	collector, ok := ctx.Value(responseCollectorContextKey{}).(*ResponseCollector)
+	if !ok || collector == nil || resp == nil {
+		return
+	}
+
+	m := ResponseMetadata{
+		Method:     method,
+		URI:        uri,
+		StatusCode: resp.StatusCode,
+		RayID:      resp.Header.Get("cf-ray"),
+		RateLimit:  ratelimitQuotaFromHeaders(resp.Header),
+		Header:     resp.Header.Clone(),
+	}
+
+	// only the JSON envelope carries messages; raw bodies such as KV values
+	// and zone exports are left alone.
+	if bytes.HasPrefix(bytes.TrimSpace(respBody), []byte("{")) {
+		var envelope Response
+		if err := json.Unmarshal(respBody, &envelope); err == nil {
+			m.Success = envelope.Success
+			m.Errors = envelope.Errors
+			m.Messages = envelope.Messages
+		}
+	}
+
+	collector.add(m)
+}
@@ -0,0 +1,101 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCollector(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/zones/"+testZoneID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		w.Header().Set("cf-ray", "7d5a3a1b2c3d4e5f-LHR")
+		w.Header().Set("RateLimit-Limit", "1200")
+		w.Header().Set("RateLimit-Remaining", "1199")
+		fmt.Fprintf(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [{"code": 10300, "message": "This endpoint is deprecated and will be removed on 2024-01-01."}],
+			"result": {"id": %q, "name": "example.com"}
+		}`, testZoneID)
+	})
+
+	collector := &ResponseCollector{}
+	ctx := WithResponseCollector(context.Background(), collector)
+
+	_, err := client.ZoneDetails(ctx, testZoneID)
+	require.NoError(t, err)
+
+	last, ok := collector.Last()
+	require.True(t, ok)
+	assert.Equal(t, http.MethodGet, last.Method)
+	assert.Equal(t, "/zones/"+testZoneID, last.URI)
+	assert.Equal(t, http.StatusOK, last.StatusCode)
+	assert.Equal(t, "7d5a3a1b2c3d4e5f-LHR", last.RayID)
+	assert.True(t, last.Success)
+	assert.Equal(t, 1200, last.RateLimit.Limit)
+	assert.Equal(t, 1199, last.RateLimit.Remaining)
+	assert.Equal(t, []ResponseInfo{{Code: 10300, Message: "This endpoint is deprecated and will be removed on 2024-01-01."}}, collector.Messages())
+
+	collector.Reset()
+	assert.Empty(t, collector.Responses())
+
+	// requests made without the collector aren't recorded.
+	_, err = client.ZoneDetails(context.Background(), testZoneID)
+	require.NoError(t, err)
+	assert.Empty(t, collector.Responses())
+}
+
+func TestResponseCollector_ErrorResponse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/zones/"+testZoneID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		w.Header().Set("cf-ray", "7d5a3a1b2c3d4e5f-LHR")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"success": false, "errors": [{"code": 1003, "message": "Invalid or missing zone id."}], "messages": [], "result": null}`)
+	})
+
+	collector := &ResponseCollector{}
+	_, err := client.ZoneDetails(WithResponseCollector(context.Background(), collector), testZoneID)
+	require.Error(t, err)
+
+	responses := collector.Responses()
+	require.Len(t, responses, 1)
+	assert.Equal(t, http.StatusBadRequest, responses[0].StatusCode)
+	assert.False(t, responses[0].Success)
+	assert.Equal(t, []ResponseInfo{{Code: 1003, Message: "Invalid or missing zone id."}}, responses[0].Errors)
+}
+
+func TestResponseCollector_ExperimentalClient(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/zones/"+testZoneID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		w.Header().Set("cf-ray", "7d5a3a1b2c3d4e5f-LHR")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [{"code": 10300, "message": "deprecated"}], "result": {"id": %q}}`, testZoneID)
+	})
+
+	baseURL, _ := url.Parse(server.URL)
+	c, err := NewExperimental(&ClientParams{BaseURL: baseURL, Token: "deadbeef", RetryPolicy: noRetries{}})
+	require.NoError(t, err)
+
+	collector := &ResponseCollector{}
+	_, err = c.Zones.Get(WithResponseCollector(context.Background(), collector), ZoneIdentifier(testZoneID))
+	require.NoError(t, err)
+
+	last, ok := collector.Last()
+	require.True(t, ok)
+	assert.Equal(t, "7d5a3a1b2c3d4e5f-LHR", last.RayID)
+	assert.Equal(t, []ResponseInfo{{Code: 10300, Message: "deprecated"}}, last.Messages)
+}
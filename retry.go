@@ -0,0 +1,281 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// Retryer decides whether a failed request should be sent again and how long
+// to wait before doing so.
+type Retryer interface {
+	// ShouldRetry reports whether a request should be retried. `attempt` is
+	// the number of attempts made so far (starting at 1), `resp` is the
+	// response of the latest attempt (nil when the request never completed)
+	// and `err` is the transport error, if any.
+	ShouldRetry(attempt int, method string, resp *http.Response, err error) bool
+
+	// RetryDelay returns how long to wait before making retry number
+	// `attempt` (starting at 1). `resp` is the response that triggered the
+	// retry and may be nil.
+	RetryDelay(attempt int, resp *http.Response) time.Duration
+}
+
+// RetryPolicy specifies number of retries and min/max retry delays
+// This config is used when the client exponentially backs off after errored requests.
+//
+// RetryPolicy implements Retryer. Requests are retried when they fail to
+// complete, are rate limited or get a 5xx response. Non-idempotent requests
+// (POST and PATCH) are only replayed after a rate limit response, which
+// guarantees they weren't processed, unless RetryNonIdempotent is set.
+type RetryPolicy struct {
+	MaxRetries    int
+	MinRetryDelay time.Duration
+	MaxRetryDelay time.Duration
+
+	// RetryNonIdempotent allows POST and PATCH requests to be replayed after a
+	// transport error or 5xx response. The API may have already processed
+	// these so enabling it risks creating duplicate resources.
+	RetryNonIdempotent bool
+}
+
+// ShouldRetry implements Retryer.
+func (p RetryPolicy) ShouldRetry(attempt int, method string, resp *http.Response, err error) bool {
+	if attempt > p.MaxRetries {
+		return false
+	}
+
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrDryRun) {
+			return false
+		}
+
+		return p.RetryNonIdempotent || isIdempotent(method)
+	}
+
+	if resp == nil {
+		return false
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		// rate limited requests are rejected before being processed so they
+		// are always safe to replay.
+		return true
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return p.RetryNonIdempotent || isIdempotent(method)
+	default:
+		return false
+	}
+}
+
+// RetryDelay implements Retryer. When the response tells the client when to
+// come back (via `Retry-After` or the ratelimit headers) that is honoured,
+// with some jitter, otherwise the delay backs off exponentially between
+// MinRetryDelay and MaxRetryDelay. Either way the delay never exceeds
+// MaxRetryDelay.
+func (p RetryPolicy) RetryDelay(attempt int, resp *http.Response) time.Duration {
+	// the server knows better than our backoff when the rate limit will
+	// reset so honour it, adding jitter so that clients sharing the same
+	// limit don't all retry at once. A far-off (or bogus) value mustn't
+	// block the caller indefinitely though.
+	if resp != nil {
+		if retryAfter := ratelimitQuotaFromHeaders(resp.Header).RetryAfter; retryAfter > 0 {
+			delay := withJitter(retryAfter)
+			if delay > p.MaxRetryDelay {
+				delay = p.MaxRetryDelay
+			}
+
+			return delay
+		}
+	}
+
+	// expect the backoff introduced here on errored requests to dominate the effect of rate limiting
+	// don't need a random component here as the rate limiter should do something similar
+	// nb time duration could truncate an arbitrary float. Since our inputs are all ints, we should be ok
+	delay := time.Duration(math.Pow(2, float64(attempt-1)) * float64(p.MinRetryDelay))
+	if delay > p.MaxRetryDelay {
+		delay = p.MaxRetryDelay
+	}
+
+	return delay
+}
+
+// withDefaults returns a copy of the policy with its unset fields replaced by
+// the experimental client's defaults.
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxRetries <= 0 {
+		p.MaxRetries = 4
+	}
+	if p.MinRetryDelay <= 0 {
+		p.MinRetryDelay = time.Duration(1) * time.Second
+	}
+	if p.MaxRetryDelay <= 0 {
+		p.MaxRetryDelay = time.Duration(30) * time.Second
+	}
+
+	return p
+}
+
+// isIdempotent reports whether sending the request more than once has the
+// same effect as sending it once.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+type retryStateContextKey struct{}
+
+// retryState tracks the attempts made for a single request sent through the
+// experimental client's retryablehttp transport, whose hooks don't otherwise
+// know the request method or how many attempts have been made.
+type retryState struct {
+	method   string
+	attempts int
+}
+
+func contextWithRetryState(ctx context.Context, method string) context.Context {
+	return context.WithValue(ctx, retryStateContextKey{}, &retryState{method: method})
+}
+
+// retryableHTTPCheckRetry adapts a Retryer to retryablehttp.CheckRetry.
+// Requests that weren't sent by the client (and therefore carry no retry
+// state) are never retried.
+func retryableHTTPCheckRetry(retryer Retryer) retryablehttp.CheckRetry {
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+
+		state, ok := ctx.Value(retryStateContextKey{}).(*retryState)
+		if !ok {
+			return false, nil
+		}
+
+		state.attempts++
+		return retryer.ShouldRetry(state.attempts, state.method, resp, err), nil
+	}
+}
+
+// retryableHTTPBackoff adapts a Retryer to retryablehttp.Backoff.
+func retryableHTTPBackoff(retryer Retryer) retryablehttp.Backoff {
+	return func(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+		return retryer.RetryDelay(attemptNum+1, resp)
+	}
+}
+
+// RatelimitQuota holds the rate limiting state the API reported alongside a
+// response.
+type RatelimitQuota struct {
+	// Limit is the number of requests permitted in the current window.
+	Limit int
+
+	// Remaining is the number of requests left in the current window. It is
+	// -1 when the API didn't report it.
+	Remaining int
+
+	// Reset is how long until the current window resets.
+	Reset time.Duration
+
+	// RetryAfter is how long the API asked the client to wait before sending
+	// another request.
+	RetryAfter time.Duration
+}
+
+// ratelimitQuotaFromHeaders extracts the rate limit state from the response
+// headers. It understands `Retry-After` (in either delay-seconds or HTTP-date
+// form), the `RateLimit-Limit`, `RateLimit-Remaining` and `RateLimit-Reset`
+// headers (with or without the `X-` prefix) and the structured `Ratelimit`
+// header (`"default";r=0;t=30`).
+func ratelimitQuotaFromHeaders(h http.Header) RatelimitQuota {
+	q := RatelimitQuota{Remaining: -1}
+
+	if v, ok := headerInt(h, "RateLimit-Limit", "X-RateLimit-Limit"); ok {
+		q.Limit = v
+	}
+
+	if v, ok := headerInt(h, "RateLimit-Remaining", "X-RateLimit-Remaining"); ok {
+		q.Remaining = v
+	}
+
+	if v, ok := headerInt(h, "RateLimit-Reset", "X-RateLimit-Reset"); ok {
+		q.Reset = time.Duration(v) * time.Second
+	}
+
+	// Ratelimit: "default";r=0;t=30
+	if v := h.Get("Ratelimit"); v != "" {
+		for _, param := range strings.Split(v, ";") {
+			key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found {
+				continue
+			}
+
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				continue
+			}
+
+			switch key {
+			case "r":
+				q.Remaining = n
+			case "t":
+				q.Reset = time.Duration(n) * time.Second
+			}
+		}
+	}
+
+	if v := strings.TrimSpace(h.Get("Retry-After")); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+			q.RetryAfter = time.Duration(secs) * time.Second
+		} else if t, err := http.ParseTime(v); err == nil {
+			if d := time.Until(t); d > 0 {
+				q.RetryAfter = d
+			}
+		}
+	}
+
+	if q.RetryAfter == 0 && q.Remaining == 0 && q.Reset > 0 {
+		q.RetryAfter = q.Reset
+	}
+
+	return q
+}
+
+// headerInt returns the first non-negative integer value found in the named
+// headers.
+func headerInt(h http.Header, names ...string) (int, bool) {
+	for _, name := range names {
+		v := strings.TrimSpace(h.Get(name))
+		if v == "" {
+			continue
+		}
+
+		n, err := strconv.Atoi(v)
+		if err == nil && n >= 0 {
+			return n, true
+		}
+	}
+
+	return 0, false
+}
+
+// withJitter adds up to 25% of random delay to `d` so that clients sharing a
+// rate limit don't all retry at the same instant.
+func withJitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return d
+	}
+
+	return d + time.Duration(rand.Int63n(int64(d)/4+1)) //nolint:gosec
+}
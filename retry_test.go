@@ -0,0 +1,187 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noRetries is a Retryer that never retries, keeping tests of failing
+// requests fast.
+type noRetries struct{}
+
+func (noRetries) ShouldRetry(int, string, *http.Response, error) bool { return false }
+
+func (noRetries) RetryDelay(int, *http.Response) time.Duration { return 0 }
+
+func TestRatelimitQuotaFromHeaders(t *testing.T) {
+	for _, c := range [...]struct {
+		TestName string
+		Headers  map[string]string
+		Quota    RatelimitQuota
+	}{
+		{"no headers", map[string]string{}, RatelimitQuota{Remaining: -1}},
+		{"retry-after seconds", map[string]string{"Retry-After": "30"}, RatelimitQuota{Remaining: -1, RetryAfter: 30 * time.Second}},
+		{"retry-after invalid", map[string]string{"Retry-After": "soon"}, RatelimitQuota{Remaining: -1}},
+		{
+			"ratelimit headers",
+			map[string]string{"RateLimit-Limit": "1200", "RateLimit-Remaining": "10", "RateLimit-Reset": "60"},
+			RatelimitQuota{Limit: 1200, Remaining: 10, Reset: time.Minute},
+		},
+		{
+			"x-ratelimit headers with quota exhausted",
+			map[string]string{"X-RateLimit-Limit": "1200", "X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "45"},
+			RatelimitQuota{Limit: 1200, Remaining: 0, Reset: 45 * time.Second, RetryAfter: 45 * time.Second},
+		},
+		{
+			"structured ratelimit header",
+			map[string]string{"Ratelimit": `"default";r=0;t=30`},
+			RatelimitQuota{Remaining: 0, Reset: 30 * time.Second, RetryAfter: 30 * time.Second},
+		},
+		{
+			"retry-after takes precedence over reset",
+			map[string]string{"Retry-After": "5", "Ratelimit": `"default";r=0;t=30`},
+			RatelimitQuota{Remaining: 0, Reset: 30 * time.Second, RetryAfter: 5 * time.Second},
+		},
+	} {
+		t.Run(c.TestName, func(t *testing.T) {
+			h := make(http.Header)
+			for k, v := range c.Headers {
+				h.Set(k, v)
+			}
+			assert.Equal(t, c.Quota, ratelimitQuotaFromHeaders(h))
+		})
+	}
+}
+
+func TestRatelimitQuotaFromHeaders_RetryAfterDate(t *testing.T) {
+	h := make(http.Header)
+	h.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+
+	q := ratelimitQuotaFromHeaders(h)
+	assert.InDelta(t, time.Minute, q.RetryAfter, float64(2*time.Second))
+}
+
+func TestWithJitter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), withJitter(0))
+
+	for i := 0; i < 100; i++ {
+		d := withJitter(time.Second)
+		assert.GreaterOrEqual(t, d, time.Second)
+		assert.LessOrEqual(t, d, time.Second+time.Second/4)
+	}
+}
+
+func TestRetryPolicy_ShouldRetry(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 2}
+	optIn := RetryPolicy{MaxRetries: 2, RetryNonIdempotent: true}
+	transportErr := errors.New("connection reset")
+
+	for _, c := range [...]struct {
+		TestName string
+		Policy   RetryPolicy
+		Attempt  int
+		Method   string
+		Status   int
+		Err      error
+		Retry    bool
+	}{
+		{"GET on 5xx", policy, 1, http.MethodGet, http.StatusBadGateway, nil, true},
+		{"DELETE on 5xx", policy, 1, http.MethodDelete, http.StatusInternalServerError, nil, true},
+		{"POST on 5xx", policy, 1, http.MethodPost, http.StatusBadGateway, nil, false},
+		{"PATCH on 5xx", policy, 1, http.MethodPatch, http.StatusServiceUnavailable, nil, false},
+		{"POST on 5xx with opt in", optIn, 1, http.MethodPost, http.StatusBadGateway, nil, true},
+		{"POST on 429", policy, 1, http.MethodPost, http.StatusTooManyRequests, nil, true},
+		{"GET on 4xx", policy, 1, http.MethodGet, http.StatusBadRequest, nil, false},
+		{"GET on transport error", policy, 1, http.MethodGet, 0, transportErr, true},
+		{"POST on transport error", policy, 1, http.MethodPost, 0, transportErr, false},
+		{"POST on transport error with opt in", optIn, 1, http.MethodPost, 0, transportErr, true},
+		{"context cancelled", policy, 1, http.MethodGet, 0, context.Canceled, false},
+		{"retries exhausted", policy, 3, http.MethodGet, http.StatusBadGateway, nil, false},
+	} {
+		t.Run(c.TestName, func(t *testing.T) {
+			var resp *http.Response
+			if c.Status != 0 {
+				resp = &http.Response{StatusCode: c.Status, Header: make(http.Header)}
+			}
+			assert.Equal(t, c.Retry, c.Policy.ShouldRetry(c.Attempt, c.Method, resp, c.Err))
+		})
+	}
+}
+
+func TestRetryPolicy_RetryDelay(t *testing.T) {
+	policy := RetryPolicy{MinRetryDelay: time.Second, MaxRetryDelay: 5 * time.Second}
+
+	assert.Equal(t, time.Second, policy.RetryDelay(1, nil))
+	assert.Equal(t, 2*time.Second, policy.RetryDelay(2, nil))
+	assert.Equal(t, 4*time.Second, policy.RetryDelay(3, nil))
+	assert.Equal(t, 5*time.Second, policy.RetryDelay(4, nil))
+
+	resp := &http.Response{Header: make(http.Header)}
+	resp.Header.Set("Retry-After", "3")
+	delay := policy.RetryDelay(1, resp)
+	assert.GreaterOrEqual(t, delay, 3*time.Second)
+	assert.LessOrEqual(t, delay, 3*time.Second+3*time.Second/4)
+
+	// the server's delay is capped at MaxRetryDelay too.
+	resp.Header.Set("Retry-After", "60")
+	assert.Equal(t, 5*time.Second, policy.RetryDelay(1, resp))
+
+	resp.Header.Set("Retry-After", time.Now().AddDate(1, 0, 0).UTC().Format(http.TimeFormat))
+	assert.Equal(t, 5*time.Second, policy.RetryDelay(1, resp))
+}
+
+func TestExperimentalClient_RetryPolicyDefaults(t *testing.T) {
+	for _, c := range [...]struct {
+		TestName string
+		Policy   Retryer
+		Want     Retryer
+	}{
+		{"unset", nil, RetryPolicy{MaxRetries: 4, MinRetryDelay: time.Second, MaxRetryDelay: 30 * time.Second}},
+		{"zero value", RetryPolicy{}, RetryPolicy{MaxRetries: 4, MinRetryDelay: time.Second, MaxRetryDelay: 30 * time.Second}},
+		{"partial", RetryPolicy{MaxRetries: 2, RetryNonIdempotent: true}, RetryPolicy{MaxRetries: 2, MinRetryDelay: time.Second, MaxRetryDelay: 30 * time.Second, RetryNonIdempotent: true}},
+		{"custom retryer", noRetries{}, noRetries{}},
+	} {
+		c := c
+		t.Run(c.TestName, func(t *testing.T) {
+			client, err := NewExperimental(&ClientParams{Token: "deadbeef", RetryPolicy: c.Policy})
+			require.NoError(t, err)
+			assert.Equal(t, c.Want, client.RetryPolicy)
+		})
+	}
+}
+
+func TestExperimentalClient_Retryer(t *testing.T) {
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.Method]++
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	client, err := NewExperimental(&ClientParams{
+		Token:       "deadbeef",
+		BaseURL:     baseURL,
+		RetryPolicy: RetryPolicy{MaxRetries: 2},
+	})
+	require.NoError(t, err)
+
+	_, err = client.get(context.Background(), "/zones", nil)
+	assert.Error(t, err)
+	assert.Equal(t, 3, requests[http.MethodGet])
+
+	_, err = client.post(context.Background(), "/zones", ZoneCreateParams{Name: "example.com"})
+	assert.Error(t, err)
+	assert.Equal(t, 1, requests[http.MethodPost])
+}
@@ -0,0 +1,178 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrMissingRulesetID    = errors.New("required missing ruleset ID")
+	ErrMissingRulesetPhase = errors.New("required missing ruleset phase")
+)
+
+type RulesetsService service
+
+type RulesetCreateParams struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	Kind        string        `json:"kind"`
+	Phase       string        `json:"phase"`
+	Rules       []RulesetRule `json:"rules"`
+}
+
+type RulesetUpdateParams struct {
+	ID          string        `json:"-"`
+	Description string        `json:"description,omitempty"`
+	Rules       []RulesetRule `json:"rules"`
+}
+
+type RulesetEntrypointUpdateParams struct {
+	Phase       string        `json:"-"`
+	Description string        `json:"description,omitempty"`
+	Rules       []RulesetRule `json:"rules"`
+}
+
+// List returns the rulesets of a zone or account. Rules aren't included.
+//
+// API reference: https://developers.cloudflare.com/ruleset-engine/rulesets-api/view/#list-existing-rulesets
+func (s *RulesetsService) List(ctx context.Context, rc *ResourceContainer) ([]Ruleset, error) {
+	if err := requireLevel(rc, ZoneRouteLevel, AccountRouteLevel); err != nil {
+		return []Ruleset{}, err
+	}
+
+	res, err := s.client.get(ctx, fmt.Sprintf("/%s/%s/rulesets", rc.Level, rc.Identifier), nil)
+	if err != nil {
+		return []Ruleset{}, err
+	}
+
+	var r ListRulesetResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return []Ruleset{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+
+	return r.Result, nil
+}
+
+// Get fetches the latest version of a ruleset.
+//
+// API reference: https://developers.cloudflare.com/ruleset-engine/rulesets-api/view/#view-a-specific-ruleset
+func (s *RulesetsService) Get(ctx context.Context, rc *ResourceContainer, rulesetID string) (Ruleset, error) {
+	if err := requireLevel(rc, ZoneRouteLevel, AccountRouteLevel); err != nil {
+		return Ruleset{}, err
+	}
+
+	if rulesetID == "" {
+		return Ruleset{}, ErrMissingRulesetID
+	}
+
+	res, err := s.client.get(ctx, fmt.Sprintf("/%s/%s/rulesets/%s", rc.Level, rc.Identifier, rulesetID), nil)
+	if err != nil {
+		return Ruleset{}, err
+	}
+
+	return unmarshalRuleset(res)
+}
+
+// Create creates a ruleset.
+//
+// API reference: https://developers.cloudflare.com/ruleset-engine/rulesets-api/create/
+func (s *RulesetsService) Create(ctx context.Context, rc *ResourceContainer, params RulesetCreateParams) (Ruleset, error) {
+	if err := requireLevel(rc, ZoneRouteLevel, AccountRouteLevel); err != nil {
+		return Ruleset{}, err
+	}
+
+	res, err := s.client.post(ctx, fmt.Sprintf("/%s/%s/rulesets", rc.Level, rc.Identifier), params)
+	if err != nil {
+		return Ruleset{}, err
+	}
+
+	return unmarshalRuleset(res)
+}
+
+// Update replaces the rules of a ruleset, creating a new version.
+//
+// API reference: https://developers.cloudflare.com/ruleset-engine/rulesets-api/update/
+func (s *RulesetsService) Update(ctx context.Context, rc *ResourceContainer, params RulesetUpdateParams) (Ruleset, error) {
+	if err := requireLevel(rc, ZoneRouteLevel, AccountRouteLevel); err != nil {
+		return Ruleset{}, err
+	}
+
+	if params.ID == "" {
+		return Ruleset{}, ErrMissingRulesetID
+	}
+
+	res, err := s.client.put(ctx, fmt.Sprintf("/%s/%s/rulesets/%s", rc.Level, rc.Identifier, params.ID), params)
+	if err != nil {
+		return Ruleset{}, err
+	}
+
+	return unmarshalRuleset(res)
+}
+
+// Delete deletes a ruleset and all of its versions.
+//
+// API reference: https://developers.cloudflare.com/ruleset-engine/rulesets-api/delete/#delete-ruleset
+func (s *RulesetsService) Delete(ctx context.Context, rc *ResourceContainer, rulesetID string) error {
+	if err := requireLevel(rc, ZoneRouteLevel, AccountRouteLevel); err != nil {
+		return err
+	}
+
+	if rulesetID == "" {
+		return ErrMissingRulesetID
+	}
+
+	_, err := s.client.delete(ctx, fmt.Sprintf("/%s/%s/rulesets/%s", rc.Level, rc.Identifier, rulesetID), nil)
+	return err
+}
+
+// GetEntrypoint fetches the entry point ruleset of a phase.
+//
+// API reference: https://developers.cloudflare.com/ruleset-engine/rulesets-api/view/#view-a-specific-ruleset
+func (s *RulesetsService) GetEntrypoint(ctx context.Context, rc *ResourceContainer, phase string) (Ruleset, error) {
+	if err := requireLevel(rc, ZoneRouteLevel, AccountRouteLevel); err != nil {
+		return Ruleset{}, err
+	}
+
+	if phase == "" {
+		return Ruleset{}, ErrMissingRulesetPhase
+	}
+
+	res, err := s.client.get(ctx, fmt.Sprintf("/%s/%s/rulesets/phases/%s/entrypoint", rc.Level, rc.Identifier, phase), nil)
+	if err != nil {
+		return Ruleset{}, err
+	}
+
+	return unmarshalRuleset(res)
+}
+
+// UpdateEntrypoint replaces the rules of the entry point ruleset of a phase,
+// creating it if it doesn't exist.
+//
+// API reference: https://developers.cloudflare.com/ruleset-engine/rulesets-api/update/
+func (s *RulesetsService) UpdateEntrypoint(ctx context.Context, rc *ResourceContainer, params RulesetEntrypointUpdateParams) (Ruleset, error) {
+	if err := requireLevel(rc, ZoneRouteLevel, AccountRouteLevel); err != nil {
+		return Ruleset{}, err
+	}
+
+	if params.Phase == "" {
+		return Ruleset{}, ErrMissingRulesetPhase
+	}
+
+	res, err := s.client.put(ctx, fmt.Sprintf("/%s/%s/rulesets/phases/%s/entrypoint", rc.Level, rc.Identifier, params.Phase), params)
+	if err != nil {
+		return Ruleset{}, err
+	}
+
+	return unmarshalRuleset(res)
+}
+
+func unmarshalRuleset(res []byte) (Ruleset, error) {
+	var r GetRulesetResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return Ruleset{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+
+	return r.Result, nil
+}
@@ -0,0 +1,51 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRulesetsService(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/rulesets", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [{"id": "2c0fc9fa937b11eaa1b71c4d701ab86e", "name": "my ruleset", "kind": "custom", "phase": "http_request_firewall_custom"}]}`)
+	})
+	mux.HandleFunc("/zones/"+testZoneID+"/rulesets/phases/http_request_firewall_custom/entrypoint", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method, "Expected method 'PUT', got %s", r.Method)
+
+		var params RulesetEntrypointUpdateParams
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&params))
+		require.Len(t, params.Rules, 1)
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "70339d97bdb34195bbf054b1ebe81f76", "kind": "zone", "phase": "http_request_firewall_custom", "version": "2", "rules": [{"action": "block", "expression": %q}]}}`, params.Rules[0].Expression)
+	})
+
+	c := newExperimentalTestClient(t)
+
+	rulesets, err := c.Rulesets.List(context.Background(), AccountIdentifier(testAccountID))
+	require.NoError(t, err)
+	require.Len(t, rulesets, 1)
+	assert.Equal(t, "my ruleset", rulesets[0].Name)
+
+	ruleset, err := c.Rulesets.UpdateEntrypoint(context.Background(), ZoneIdentifier(testZoneID), RulesetEntrypointUpdateParams{
+		Phase: "http_request_firewall_custom",
+		Rules: []RulesetRule{{Action: "block", Expression: `ip.src eq 198.51.100.4`}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "2", ruleset.Version)
+	assert.Equal(t, `ip.src eq 198.51.100.4`, ruleset.Rules[0].Expression)
+
+	_, err = c.Rulesets.GetEntrypoint(context.Background(), ZoneIdentifier(testZoneID), "")
+	assert.ErrorIs(t, err, ErrMissingRulesetPhase)
+}
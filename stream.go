@@ -22,6 +22,8 @@ var (
 	ErrMissingVideoID = errors.New("required video id missing")
 	// ErrMissingFilePath is for when FilePath is required but missing.
 	ErrMissingFilePath = errors.New("required file path missing")
+	// ErrMissingVideo is for when a video to upload is required but missing.
+	ErrMissingVideo = errors.New("required video missing")
 )
 
 // StreamVideo represents a stream video.
@@ -142,6 +144,19 @@ type StreamUploadFileParameters struct {
 	FilePath  string
 }
 
+// StreamUploadReaderParameters represents parameters used when uploading a
+// video from an io.ReadSeeker.
+type StreamUploadReaderParameters struct {
+	AccountID string
+
+	// Name is the file name sent with the upload.
+	Name string
+
+	// Video is read from its current offset to the end. It is rewound if the
+	// upload has to be retried.
+	Video io.ReadSeeker
+}
+
 // StreamListParameters represents parameters used when listing stream videos.
 type StreamListParameters struct {
 	AccountID     string
@@ -230,7 +245,8 @@ func (api *API) StreamUploadFromURL(ctx context.Context, params StreamUploadFrom
 	return streamVideoResponse.Result, nil
 }
 
-// StreamUploadVideoFile uploads a video from a path to the file.
+// StreamUploadVideoFile uploads a video from a path to the file. The file is
+// streamed rather than read into memory.
 //
 // API Reference: https://api.cloudflare.com/#stream-videos-upload-a-video-using-a-single-http-request
 func (api *API) StreamUploadVideoFile(ctx context.Context, params StreamUploadFileParameters) (StreamVideo, error) {
@@ -242,36 +258,66 @@ func (api *API) StreamUploadVideoFile(ctx context.Context, params StreamUploadFi
 		return StreamVideo{}, ErrMissingFilePath
 	}
 
-	uri := fmt.Sprintf("/accounts/%s/stream", params.AccountID)
-
-	// Create new multipart writer
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-	formFile, err := writer.CreateFormFile("file", params.FilePath)
-	if err != nil {
-		return StreamVideo{}, err
-	}
 	file, err := os.Open(params.FilePath)
 	if err != nil {
 		return StreamVideo{}, err
 	}
-	if _, err := io.Copy(formFile, file); err != nil {
+	defer file.Close()
+
+	return api.StreamUploadVideoReader(ctx, StreamUploadReaderParameters{
+		AccountID: params.AccountID,
+		Name:      params.FilePath,
+		Video:     file,
+	})
+}
+
+// StreamUploadVideoReader uploads a video read from `params.Video` without
+// holding it in memory.
+//
+// API Reference: https://api.cloudflare.com/#stream-videos-upload-a-video-using-a-single-http-request
+func (api *API) StreamUploadVideoReader(ctx context.Context, params StreamUploadReaderParameters) (StreamVideo, error) {
+	if params.AccountID == "" {
+		return StreamVideo{}, ErrMissingAccountID
+	}
+
+	if params.Video == nil {
+		return StreamVideo{}, ErrMissingVideo
+	}
+
+	uri := fmt.Sprintf("/accounts/%s/stream", params.AccountID)
+
+	// the multipart framing is written up front so that the video can be
+	// streamed between the form file header and the closing boundary.
+	framing := &bytes.Buffer{}
+	writer := multipart.NewWriter(framing)
+	if _, err := writer.CreateFormFile("file", params.Name); err != nil {
 		return StreamVideo{}, err
 	}
+	headerLen := framing.Len()
 	if err := writer.Close(); err != nil {
 		return StreamVideo{}, err
 	}
 
-	res, err := api.makeRequestContextWithHeaders(ctx, http.MethodPost, uri, body, http.Header{
+	body, err := newRequestBody(
+		bytes.NewReader(framing.Bytes()[:headerLen]),
+		params.Video,
+		bytes.NewReader(framing.Bytes()[headerLen:]),
+	)
+	if err != nil {
+		return StreamVideo{}, err
+	}
+
+	stream, err := api.makeRequestStream(ctx, http.MethodPost, uri, body, http.Header{
 		"Accept":       []string{"application/json"},
 		"Content-Type": []string{writer.FormDataContentType()},
 	})
 	if err != nil {
 		return StreamVideo{}, err
 	}
+	defer stream.Close()
 
 	var streamVideoResponse StreamVideoResponse
-	if err := json.Unmarshal(res, &streamVideoResponse); err != nil {
+	if err := json.NewDecoder(stream).Decode(&streamVideoResponse); err != nil {
 		return StreamVideo{}, err
 	}
 	return streamVideoResponse.Result, nil
@@ -0,0 +1,74 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// requestBody is a request body that is streamed to the API rather than held
+// in memory. Its parts are rewound before each attempt so that, unlike a
+// plain io.Reader, it can be resent when a request is retried.
+type requestBody struct {
+	parts   []io.ReadSeeker
+	offsets []int64
+	length  int64
+}
+
+// newRequestBody creates a request body that sends each of `parts` in turn
+// from its current offset to its end.
+func newRequestBody(parts ...io.ReadSeeker) (*requestBody, error) {
+	b := &requestBody{parts: parts, offsets: make([]int64, len(parts))}
+	for i, p := range parts {
+		start, err := p.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, fmt.Errorf("could not determine request body offset: %w", err)
+		}
+
+		end, err := p.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, fmt.Errorf("could not determine request body length: %w", err)
+		}
+
+		b.offsets[i] = start
+		b.length += end - start
+	}
+
+	return b, nil
+}
+
+// reader rewinds the parts and returns a reader for a single attempt.
+func (b *requestBody) reader() (io.Reader, error) {
+	readers := make([]io.Reader, len(b.parts))
+	for i, p := range b.parts {
+		if _, err := p.Seek(b.offsets[i], io.SeekStart); err != nil {
+			return nil, fmt.Errorf("could not rewind request body: %w", err)
+		}
+		readers[i] = p
+	}
+
+	return &sizedReader{Reader: io.MultiReader(readers...), length: b.length}, nil
+}
+
+// sizedReader is a reader whose length is known up front so the request can
+// be sent with a `Content-Length` instead of being chunked.
+type sizedReader struct {
+	io.Reader
+	length int64
+}
+
+// makeRequestStream makes a request like makeRequestContextWithHeaders but
+// returns the body of a successful response without reading it. Error
+// responses are converted into the usual error types. The caller is
+// responsible for closing the returned body.
+//
+// `params` may be a *requestBody to stream the request body too.
+func (api *API) makeRequestStream(ctx context.Context, method, uri string, params interface{}, headers http.Header) (io.ReadCloser, error) {
+	res, err := api.sendRequest(ctx, method, uri, params, api.authType, headers, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.stream, nil
+}
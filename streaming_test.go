@@ -0,0 +1,155 @@
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestBody_RewoundOnRetry(t *testing.T) {
+	setup(UsingRetryer(RetryPolicy{MaxRetries: 2, MinRetryDelay: time.Millisecond, MaxRetryDelay: time.Millisecond}))
+	defer teardown()
+
+	var attempts int
+	mux.HandleFunc("/accounts/"+testAccountID+"/upload", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, "header|payload|trailer", string(body))
+		assert.Equal(t, int64(len(body)), r.ContentLength)
+		assert.Empty(t, r.TransferEncoding)
+
+		w.Header().Set("content-type", "application/json")
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": null}`)
+	})
+
+	payload := strings.NewReader("skipped|payload|")
+	_, _ = payload.Seek(int64(len("skipped|")), io.SeekStart)
+
+	body, err := newRequestBody(strings.NewReader("header|"), payload, strings.NewReader("trailer"))
+	require.NoError(t, err)
+
+	stream, err := client.makeRequestStream(context.Background(), http.MethodPut, "/accounts/"+testAccountID+"/upload", body, nil)
+	require.NoError(t, err)
+	defer stream.Close()
+
+	assert.Equal(t, 2, attempts)
+}
+
+func TestMakeRequestStream_ErrorResponse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/storage/kv/namespaces/f5f6a0ce3b9e4aa5b9e8a3f9d1a31c0a/values/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"success": false, "errors": [{"code": 10009, "message": "get: 'key not found'"}], "messages": [], "result": null}`)
+	})
+
+	client.AccountID = testAccountID
+	_, err := client.ReadWorkersKVStream(context.Background(), "f5f6a0ce3b9e4aa5b9e8a3f9d1a31c0a", "missing")
+
+	var notFound *NotFoundError
+	require.ErrorAs(t, err, &notFound)
+	assert.Equal(t, []int{10009}, notFound.ErrorCodes())
+}
+
+func TestReadWorkersKVStream(t *testing.T) {
+	setup()
+	defer teardown()
+
+	value := bytes.Repeat([]byte("0123456789"), 100000)
+	mux.HandleFunc("/accounts/"+testAccountID+"/storage/kv/namespaces/f5f6a0ce3b9e4aa5b9e8a3f9d1a31c0a/values/big", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		w.Header().Set("content-type", "application/octet-stream")
+		_, _ = w.Write(value)
+	})
+
+	client.AccountID = testAccountID
+	stream, err := client.ReadWorkersKVStream(context.Background(), "f5f6a0ce3b9e4aa5b9e8a3f9d1a31c0a", "big")
+	require.NoError(t, err)
+	defer stream.Close()
+
+	got, err := ioutil.ReadAll(stream)
+	require.NoError(t, err)
+	assert.Equal(t, value, got)
+}
+
+func TestBaseImageStream(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/images/v1/ZxR0pLaXRldlBtaFhXZ01FRzBYWlFoNHZVb1pycWd/blob", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "image/png")
+		fmt.Fprint(w, "\x89PNG")
+	})
+
+	stream, err := client.BaseImageStream(context.Background(), testAccountID, "ZxR0pLaXRldlBtaFhXZ01FRzBYWlFoNHZVb1pycWd")
+	require.NoError(t, err)
+	defer stream.Close()
+
+	got, _ := ioutil.ReadAll(stream)
+	assert.Equal(t, "\x89PNG", string(got))
+}
+
+func TestZoneExportStream(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records/export", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "text/plain")
+		fmt.Fprint(w, "www.example.com.\t1\tIN\tA\t198.51.100.4\n")
+	})
+
+	stream, err := client.ZoneExportStream(context.Background(), testZoneID)
+	require.NoError(t, err)
+	defer stream.Close()
+
+	got, _ := ioutil.ReadAll(stream)
+	assert.Equal(t, "www.example.com.\t1\tIN\tA\t198.51.100.4\n", string(got))
+}
+
+func TestStreamUploadVideoReader(t *testing.T) {
+	setup()
+	defer teardown()
+
+	video := bytes.Repeat([]byte{0x00, 0x00, 0x00, 0x18}, 1024)
+	mux.HandleFunc("/accounts/"+testAccountID+"/stream", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+		assert.Greater(t, r.ContentLength, int64(len(video)))
+
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		defer file.Close()
+		assert.Equal(t, "video.mp4", header.Filename)
+
+		got, _ := ioutil.ReadAll(file)
+		assert.Equal(t, video, got)
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"uid": %q}}`, testVideoID)
+	})
+
+	_, err := client.StreamUploadVideoReader(context.Background(), StreamUploadReaderParameters{AccountID: testAccountID, Name: "video.mp4"})
+	assert.Equal(t, ErrMissingVideo, err)
+
+	out, err := client.StreamUploadVideoReader(context.Background(), StreamUploadReaderParameters{
+		AccountID: testAccountID,
+		Name:      "video.mp4",
+		Video:     bytes.NewReader(video),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, testVideoID, out.UID)
+}
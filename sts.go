@@ -1,6 +1,7 @@
 package cloudflare
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -44,8 +45,9 @@ type securityTokenResponse struct {
 }
 
 // fetchSTSCredentials provides a way to authenticate with the security token
-// service and issue a usable token for the system.
-func fetchSTSCredentials(stsConfig *SecurityTokenConfiguration) (string, error) {
+// service and issue a usable token for the system. A retrying client is used
+// when `stsClient` is nil.
+func fetchSTSCredentials(ctx context.Context, stsClient *http.Client, stsConfig *SecurityTokenConfiguration) (string, error) {
 	if stsConfig.Secret == "" {
 		return "", ErrSTSMissingServiceSecret
 	}
@@ -54,7 +56,7 @@ func fetchSTSCredentials(stsConfig *SecurityTokenConfiguration) (string, error)
 		return "", ErrSTSMissingServiceTag
 	}
 
-	if stsConfig.Issuer.Hostname == "" {
+	if stsConfig.Issuer == nil || stsConfig.Issuer.Hostname == "" {
 		return "", ErrSTSMissingIssuerHostname
 	}
 
@@ -62,12 +64,15 @@ func fetchSTSCredentials(stsConfig *SecurityTokenConfiguration) (string, error)
 		return "", ErrSTSMissingServicePath
 	}
 
-	retryableClient := retryablehttp.NewClient()
-	retryableClient.RetryMax = 3
-	stsClient := retryableClient.StandardClient()
+	if stsClient == nil {
+		retryableClient := retryablehttp.NewClient()
+		retryableClient.RetryMax = 3
+		retryableClient.Logger = silentRetryLogger
+		stsClient = retryableClient.StandardClient()
+	}
 
 	uri := fmt.Sprintf("https://%s%s", stsConfig.Issuer.Hostname, stsConfig.Issuer.Path)
-	req, err := http.NewRequest(http.MethodGet, uri, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
 	if err != nil {
 		return "", fmt.Errorf("HTTP request creation failed: %w", err)
 	}
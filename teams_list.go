@@ -87,6 +87,28 @@ func (api *API) TeamsLists(ctx context.Context, accountID string) ([]TeamsList,
 	return teamsListListResponse.Result, teamsListListResponse.ResultInfo, nil
 }
 
+// TeamsListsIterator returns an Iterator over all lists within an account.
+//
+// API reference: https://api.cloudflare.com/#teams-lists-list-teams-lists
+func (api *API) TeamsListsIterator(ctx context.Context, accountID string) *Iterator[TeamsList] {
+	return newIterator(ctx, ResultInfo{Page: 1}, func(ctx context.Context, info ResultInfo) ([]TeamsList, ResultInfo, error) {
+		uri := buildURI(fmt.Sprintf("/%s/%s/gateway/lists", AccountRouteRoot, accountID), PaginationOptions{Page: info.Page, PerPage: info.PerPage})
+
+		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
+		if err != nil {
+			return nil, ResultInfo{}, err
+		}
+
+		var teamsListListResponse TeamsListListResponse
+		err = json.Unmarshal(res, &teamsListListResponse)
+		if err != nil {
+			return nil, ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+		}
+
+		return teamsListListResponse.Result, teamsListListResponse.ResultInfo, nil
+	})
+}
+
 // TeamsList returns a single list based on the list ID.
 //
 // API reference: https://api.cloudflare.com/#teams-lists-teams-list-details
@@ -143,6 +165,17 @@ func (api *API) TeamsListItems(ctx context.Context, params TeamsListItemsParams)
 	return teamsListItemsListResponse.Result, teamsListItemsListResponse.ResultInfo, nil
 }
 
+// TeamsListItemsIterator returns an Iterator over all list items for a list,
+// fetching each page of results as it is needed.
+//
+// API reference: https://api.cloudflare.com/#teams-lists-teams-list-items
+func (api *API) TeamsListItemsIterator(ctx context.Context, params TeamsListItemsParams) *Iterator[TeamsListItem] {
+	return newPaginationIterator(ctx, params.PaginationOptions, func(ctx context.Context, pageOpts PaginationOptions) ([]TeamsListItem, ResultInfo, error) {
+		params.PaginationOptions = pageOpts
+		return api.TeamsListItems(ctx, params)
+	})
+}
+
 // CreateTeamsList creates a new teams list.
 //
 // API reference: https://api.cloudflare.com/#teams-lists-create-teams-list
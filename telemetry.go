@@ -0,0 +1,243 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	// identifierPattern matches path segments that are resource identifiers
+	// (hex tags, UUIDs and numeric IDs) rather than resource names.
+	identifierPattern = regexp.MustCompile(`^([0-9a-fA-F]{16,}|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|[0-9]+)$`)
+
+	// namedCollections are path segments whose children are addressed by a
+	// user supplied name (e.g. a Worker script name) instead of an ID.
+	namedCollections = map[string]bool{
+		"buckets":      true,
+		"domains":      true,
+		"environments": true,
+		"metadata":     true,
+		"projects":     true,
+		"scripts":      true,
+		"secrets":      true,
+		"services":     true,
+		"values":       true,
+	}
+)
+
+// Telemetry instruments the requests made by the client, for example to
+// trace and measure them. See the cfotel package for an OpenTelemetry
+// implementation.
+type Telemetry interface {
+	// StartRequest is called before the first attempt of a request. The
+	// returned context is used to send the request and is passed to the other
+	// methods.
+	StartRequest(ctx context.Context, req TelemetryRequest) context.Context
+
+	// RateLimiterWait is called after every attempt has waited on the client
+	// side rate limiter.
+	RateLimiterWait(ctx context.Context, req TelemetryRequest, wait time.Duration)
+
+	// EndRequest is called once the request has completed, after all of its
+	// attempts.
+	EndRequest(ctx context.Context, req TelemetryRequest, res TelemetryResult)
+}
+
+// TelemetryRequest describes a request made by the client.
+type TelemetryRequest struct {
+	// Operation is a low cardinality name for the request such as
+	// `dns_records.list`.
+	Operation string
+	Method    string
+
+	// ZoneID or AccountID is set for requests to zone or account resources.
+	ZoneID    string
+	AccountID string
+}
+
+// TelemetryResult is the outcome of a request, across all of its attempts.
+type TelemetryResult struct {
+	// Duration includes retries and time spent rate limiting.
+	Duration        time.Duration
+	StatusCode      int
+	RayID           string
+	Retries         int
+	RateLimiterWait time.Duration
+
+	// Err is the error returned by the request and ErrorType classifies it
+	// (e.g. "rate_limit" or "timeout"). Neither is set for requests captured
+	// by a dry run, which set DryRun instead.
+	Err       error
+	ErrorType string
+	DryRun    bool
+}
+
+// requestTelemetry tracks a single logical request, across all of its
+// attempts, for the configured Telemetry. Every method is a no-op when no
+// Telemetry is configured.
+type requestTelemetry struct {
+	t      Telemetry
+	req    TelemetryRequest
+	start  time.Time
+	result TelemetryResult
+}
+
+// startTelemetry begins tracking a request and returns the context to send
+// it with.
+func startTelemetry(ctx context.Context, t Telemetry, method, uri string) (context.Context, *requestTelemetry) {
+	rt := &requestTelemetry{t: t, start: time.Now()}
+	if t == nil {
+		return ctx, rt
+	}
+
+	rt.req = TelemetryRequest{
+		Operation: operationName(method, uri),
+		Method:    method,
+	}
+	if rc := resourceContainerFromURI(uri); rc != nil {
+		switch rc.Level {
+		case ZoneRouteLevel:
+			rt.req.ZoneID = rc.Identifier
+		case AccountRouteLevel:
+			rt.req.AccountID = rc.Identifier
+		}
+	}
+
+	return t.StartRequest(ctx, rt.req), rt
+}
+
+// waited records time spent blocked on the rate limiter.
+func (r *requestTelemetry) waited(ctx context.Context, d time.Duration) {
+	if r.t == nil {
+		return
+	}
+
+	r.result.RateLimiterWait += d
+	r.t.RateLimiterWait(ctx, r.req, d)
+}
+
+// retried records that another attempt is being made.
+func (r *requestTelemetry) retried() {
+	r.result.Retries++
+}
+
+// observe records the response of an attempt.
+func (r *requestTelemetry) observe(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	r.result.StatusCode = resp.StatusCode
+	r.result.RayID = resp.Header.Get("cf-ray")
+}
+
+// end reports the outcome of the request.
+func (r *requestTelemetry) end(ctx context.Context, err error) {
+	if r.t == nil {
+		return
+	}
+
+	r.result.Duration = time.Since(r.start)
+
+	// requests captured by a dry run weren't sent so they aren't errors.
+	if errors.Is(err, ErrDryRun) {
+		r.result.DryRun = true
+	} else if err != nil {
+		r.result.Err = err
+		r.result.ErrorType = telemetryErrorType(err)
+	}
+
+	r.t.EndRequest(ctx, r.req, r.result)
+}
+
+// telemetryErrorType classifies an error returned by a request for the
+// `error.type` attribute.
+func telemetryErrorType(err error) string {
+	var (
+		requestErr        *RequestError
+		ratelimitErr      *RatelimitError
+		serviceErr        *ServiceError
+		authenticationErr *AuthenticationError
+		authorizationErr  *AuthorizationError
+		notFoundErr       *NotFoundError
+	)
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.As(err, &ratelimitErr):
+		return string(ErrorTypeRateLimit)
+	case errors.As(err, &serviceErr):
+		return string(ErrorTypeService)
+	case errors.As(err, &authenticationErr):
+		return string(ErrorTypeAuthentication)
+	case errors.As(err, &authorizationErr):
+		return string(ErrorTypeAuthorization)
+	case errors.As(err, &notFoundErr):
+		return string(ErrorTypeNotFound)
+	case errors.As(err, &requestErr):
+		return string(ErrorTypeRequest)
+	default:
+		return "client"
+	}
+}
+
+// operationName derives a logical operation name such as `dns_records.list`
+// from the request method and URI. Account, zone and user prefixes and
+// resource identifiers are dropped so that the name has a low cardinality.
+func operationName(method, uri string) string {
+	path := uri
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(parts) > 2 && (parts[0] == string(AccountRouteLevel) || parts[0] == string(ZoneRouteLevel)):
+		parts = parts[2:]
+	case len(parts) > 1 && parts[0] == string(UserRouteLevel):
+		parts = parts[1:]
+	}
+
+	var (
+		resources  []string
+		endsWithID bool
+	)
+	for i, part := range parts {
+		if part == "" {
+			continue
+		}
+
+		endsWithID = identifierPattern.MatchString(part) || (i > 0 && namedCollections[parts[i-1]])
+		if !endsWithID {
+			resources = append(resources, part)
+		}
+	}
+
+	var verb string
+	switch method {
+	case http.MethodGet:
+		verb = "list"
+		if endsWithID {
+			verb = "get"
+		}
+	case http.MethodPost:
+		verb = "create"
+	case http.MethodPut:
+		verb = "update"
+	case http.MethodPatch:
+		verb = "edit"
+	case http.MethodDelete:
+		verb = "delete"
+	default:
+		verb = strings.ToLower(method)
+	}
+
+	return strings.Join(append(resources, verb), ".")
+}
@@ -0,0 +1,177 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingTelemetry is a Telemetry that keeps every request it's given.
+type recordingTelemetry struct {
+	mu      sync.Mutex
+	started []TelemetryRequest
+	waits   []time.Duration
+	ended   []TelemetryResult
+
+	// endedCtx is the value StartRequest added to the context EndRequest was
+	// given.
+	endedCtx []interface{}
+}
+
+type recordingTelemetryKey struct{}
+
+func (t *recordingTelemetry) StartRequest(ctx context.Context, req TelemetryRequest) context.Context {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.started = append(t.started, req)
+	return context.WithValue(ctx, recordingTelemetryKey{}, req.Operation)
+}
+
+func (t *recordingTelemetry) RateLimiterWait(ctx context.Context, req TelemetryRequest, wait time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.waits = append(t.waits, wait)
+}
+
+func (t *recordingTelemetry) EndRequest(ctx context.Context, req TelemetryRequest, res TelemetryResult) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.ended = append(t.ended, res)
+	t.endedCtx = append(t.endedCtx, ctx.Value(recordingTelemetryKey{}))
+}
+
+func TestOperationName(t *testing.T) {
+	for _, c := range [...]struct {
+		Method string
+		URI    string
+		Name   string
+	}{
+		{http.MethodGet, "/zones/023e105f4ecef8ad9ca31a8372d0c353/dns_records?page=1", "dns_records.list"},
+		{http.MethodGet, "/zones/023e105f4ecef8ad9ca31a8372d0c353/dns_records/372e67954025e0ba6aaa6d586b9e0b59", "dns_records.get"},
+		{http.MethodPost, "/zones/023e105f4ecef8ad9ca31a8372d0c353/dns_records", "dns_records.create"},
+		{http.MethodPatch, "/zones/023e105f4ecef8ad9ca31a8372d0c353/dns_records/372e67954025e0ba6aaa6d586b9e0b59", "dns_records.edit"},
+		{http.MethodDelete, "/zones/023e105f4ecef8ad9ca31a8372d0c353/dns_records/372e67954025e0ba6aaa6d586b9e0b59", "dns_records.delete"},
+		{http.MethodGet, "/zones", "zones.list"},
+		{http.MethodGet, "/zones/023e105f4ecef8ad9ca31a8372d0c353", "zones.get"},
+		{http.MethodPut, "/accounts/01a7362d577a6c3019a474fd6f485823/workers/scripts/my-script", "workers.scripts.update"},
+		{http.MethodGet, "/accounts/01a7362d577a6c3019a474fd6f485823/storage/kv/namespaces/0f2ac74b498b48028cb68387c421e279/values/my-key", "storage.kv.namespaces.values.get"},
+		{http.MethodGet, "/user/tokens/verify", "tokens.verify.list"},
+		{http.MethodGet, "/accounts/01a7362d577a6c3019a474fd6f485823/rules/lists/2c0fc9fa937b11eaa1b71c4d701ab86e/items/7c5dae5552338874e5053f2534d2767a", "rules.lists.items.get"},
+		{http.MethodGet, "/ips", "ips.list"},
+	} {
+		t.Run(c.Method+" "+c.URI, func(t *testing.T) {
+			assert.Equal(t, c.Name, operationName(c.Method, c.URI))
+		})
+	}
+}
+
+func TestTelemetryErrorType(t *testing.T) {
+	assert.Equal(t, "canceled", telemetryErrorType(fmt.Errorf("wrapped: %w", context.Canceled)))
+	assert.Equal(t, "timeout", telemetryErrorType(context.DeadlineExceeded))
+	assert.Equal(t, "rate_limit", telemetryErrorType(&RatelimitError{cloudflareError: &Error{}}))
+	assert.Equal(t, "not_found", telemetryErrorType(&NotFoundError{cloudflareError: &Error{}}))
+	assert.Equal(t, "client", telemetryErrorType(fmt.Errorf("boom")))
+}
+
+func TestTelemetry_Request(t *testing.T) {
+	setup()
+	defer teardown()
+
+	telemetry := &recordingTelemetry{}
+	require.NoError(t, UsingTelemetry(telemetry)(client))
+	require.NoError(t, UsingRetryer(RetryPolicy{MaxRetries: 2})(client))
+
+	requests := 0
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records/372e67954025e0ba6aaa6d586b9e0b59", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("cf-ray", fmt.Sprintf("ray-%d", requests))
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "372e67954025e0ba6aaa6d586b9e0b59"}}`)
+	})
+
+	_, err := client.DNSRecord(context.Background(), testZoneID, "372e67954025e0ba6aaa6d586b9e0b59")
+	require.NoError(t, err)
+
+	assert.Equal(t, []TelemetryRequest{{
+		Operation: "dns_records.get",
+		Method:    http.MethodGet,
+		ZoneID:    testZoneID,
+	}}, telemetry.started)
+	assert.Len(t, telemetry.waits, 2)
+
+	require.Len(t, telemetry.ended, 1)
+	assert.Equal(t, []interface{}{"dns_records.get"}, telemetry.endedCtx)
+	res := telemetry.ended[0]
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "ray-2", res.RayID)
+	assert.Equal(t, 1, res.Retries)
+	assert.Positive(t, res.Duration)
+	assert.NoError(t, res.Err)
+	assert.Empty(t, res.ErrorType)
+	assert.False(t, res.DryRun)
+}
+
+func TestTelemetry_RequestError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	telemetry := &recordingTelemetry{}
+	require.NoError(t, UsingTelemetry(telemetry)(client))
+
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records/372e67954025e0ba6aaa6d586b9e0b59", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"success": false, "errors": [{"code": 81044, "message": "Record does not exist."}], "messages": [], "result": null}`)
+	})
+
+	_, err := client.DNSRecord(context.Background(), testZoneID, "372e67954025e0ba6aaa6d586b9e0b59")
+	require.Error(t, err)
+
+	require.Len(t, telemetry.ended, 1)
+	res := telemetry.ended[0]
+	assert.Equal(t, err, res.Err)
+	assert.Equal(t, "not_found", res.ErrorType)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func TestTelemetry_DryRun(t *testing.T) {
+	setup()
+	defer teardown()
+
+	telemetry := &recordingTelemetry{}
+	require.NoError(t, UsingTelemetry(telemetry)(client))
+	require.NoError(t, UsingDryRun(&DryRunPlan{})(client))
+
+	err := client.DeleteDNSRecord(context.Background(), testZoneID, "372e67954025e0ba6aaa6d586b9e0b59")
+	require.ErrorIs(t, err, ErrDryRun)
+
+	require.Len(t, telemetry.ended, 1)
+	res := telemetry.ended[0]
+	assert.True(t, res.DryRun)
+	assert.NoError(t, res.Err)
+	assert.Empty(t, res.ErrorType)
+}
+
+func TestTelemetry_NoopByDefault(t *testing.T) {
+	api, err := NewWithAPIToken("token")
+	require.NoError(t, err)
+	assert.Nil(t, api.telemetry)
+
+	ctx := context.Background()
+	tctx, rt := startTelemetry(ctx, api.telemetry, http.MethodGet, "/zones")
+	rt.waited(tctx, time.Millisecond)
+	rt.end(tctx, nil)
+
+	assert.Equal(t, ctx, tctx)
+}
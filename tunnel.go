@@ -93,6 +93,7 @@ type TunnelCreateParams struct {
 }
 
 type TunnelUpdateParams struct {
+	ID     string `json:"-"`
 	Name   string `json:"name,omitempty"`
 	Secret string `json:"tunnel_secret,omitempty"`
 }
@@ -172,6 +173,8 @@ type TunnelListParams struct {
 	UUID      string     `url:"uuid,omitempty"` // the tunnel ID
 	IsDeleted *bool      `url:"is_deleted,omitempty"`
 	ExistedAt *time.Time `url:"existed_at,omitempty"`
+
+	PaginationOptions
 }
 
 // Tunnels lists all tunnels.
@@ -197,6 +200,17 @@ func (api *API) Tunnels(ctx context.Context, rc *ResourceContainer, params Tunne
 	return argoDetailsResponse.Result, nil
 }
 
+// TunnelsIterator returns an Iterator over all tunnels, fetching each page of
+// results as it is needed.
+//
+// API reference: https://api.cloudflare.com/#cloudflare-tunnel-list-cloudflare-tunnels
+func (api *API) TunnelsIterator(ctx context.Context, rc *ResourceContainer, params TunnelListParams) *Iterator[Tunnel] {
+	return newPageSizeIterator(ctx, params.PaginationOptions, 50, func(ctx context.Context, pageOpts PaginationOptions) ([]Tunnel, error) {
+		params.PaginationOptions = pageOpts
+		return api.Tunnels(ctx, rc, params)
+	})
+}
+
 // Tunnel returns a single Argo tunnel.
 //
 // API reference: https://api.cloudflare.com/#cloudflare-tunnel-get-cloudflare-tunnel
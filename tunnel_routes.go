@@ -97,6 +97,17 @@ func (api *API) ListTunnelRoutes(ctx context.Context, rc *ResourceContainer, par
 	return resp.Result, nil
 }
 
+// ListTunnelRoutesIterator returns an Iterator over all defined routes for
+// tunnels in the account, fetching each page of results as it is needed.
+//
+// See: https://api.cloudflare.com/#tunnel-route-list-tunnel-routes
+func (api *API) ListTunnelRoutesIterator(ctx context.Context, rc *ResourceContainer, params TunnelRoutesListParams) *Iterator[TunnelRoute] {
+	return newPageSizeIterator(ctx, params.PaginationOptions, 50, func(ctx context.Context, pageOpts PaginationOptions) ([]TunnelRoute, error) {
+		params.PaginationOptions = pageOpts
+		return api.ListTunnelRoutes(ctx, rc, params)
+	})
+}
+
 // GetTunnelRouteForIP finds the Tunnel Route that encompasses the given IP.
 //
 // See: https://api.cloudflare.com/#tunnel-route-get-tunnel-route-by-ip
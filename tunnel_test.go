@@ -47,6 +47,40 @@ func TestTunnels(t *testing.T) {
 	}
 }
 
+func TestTunnelsIterator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var pages []string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "blog", r.URL.Query().Get("name"))
+		assert.Equal(t, "2", r.URL.Query().Get("per_page"))
+		pages = append(pages, r.URL.Query().Get("page"))
+
+		w.Header().Set("content-type", "application/json")
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [{"id": "a"}, {"id": "b"}]}`)
+		case "2":
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [{"id": "c"}]}`)
+		default:
+			t.Errorf("unexpected page %q", r.URL.Query().Get("page"))
+		}
+	}
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/cfd_tunnel", handler)
+
+	tunnels, err := client.TunnelsIterator(context.Background(), AccountIdentifier(testAccountID), TunnelListParams{
+		Name:              "blog",
+		PaginationOptions: PaginationOptions{PerPage: 2},
+	}).All()
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, []Tunnel{{ID: "a"}, {ID: "b"}, {ID: "c"}}, tunnels)
+		assert.Equal(t, []string{"1", "2"}, pages)
+	}
+}
+
 func TestTunnel(t *testing.T) {
 	setup()
 	defer teardown()
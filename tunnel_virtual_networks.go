@@ -81,6 +81,18 @@ func (api *API) ListTunnelVirtualNetworks(ctx context.Context, rc *ResourceConta
 	return resp.Result, nil
 }
 
+// ListTunnelVirtualNetworksIterator returns an Iterator over all defined
+// virtual networks for tunnels in the account, fetching each page of results
+// as it is needed.
+//
+// API reference: https://api.cloudflare.com/#tunnel-virtual-network-list-virtual-networks
+func (api *API) ListTunnelVirtualNetworksIterator(ctx context.Context, rc *ResourceContainer, params TunnelVirtualNetworksListParams) *Iterator[TunnelVirtualNetwork] {
+	return newPageSizeIterator(ctx, params.PaginationOptions, 50, func(ctx context.Context, pageOpts PaginationOptions) ([]TunnelVirtualNetwork, error) {
+		params.PaginationOptions = pageOpts
+		return api.ListTunnelVirtualNetworks(ctx, rc, params)
+	})
+}
+
 // CreateTunnelVirtualNetwork adds a new virtual network to the account.
 //
 // API reference: https://api.cloudflare.com/#tunnel-virtual-network-create-virtual-network
@@ -0,0 +1,158 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type TunnelsService service
+
+// List returns the tunnels of an account that match `params`.
+//
+// API reference: https://api.cloudflare.com/#cloudflare-tunnel-list-cloudflare-tunnels
+func (s *TunnelsService) List(ctx context.Context, rc *ResourceContainer, params TunnelListParams) ([]Tunnel, *ResultInfo, error) {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return []Tunnel{}, &ResultInfo{}, err
+	}
+
+	uri := fmt.Sprintf("/accounts/%s/cfd_tunnel", rc.Identifier)
+	return listAll(ctx, ResultInfo{}, func(ctx context.Context, info ResultInfo) ([]Tunnel, ResultInfo, error) {
+		query := struct {
+			TunnelListParams
+			ResultInfo
+		}{params, info}
+		return getPage[Tunnel](ctx, s.client, buildURI(uri, query))
+	})
+}
+
+// Get fetches a single tunnel.
+//
+// API reference: https://api.cloudflare.com/#cloudflare-tunnel-get-cloudflare-tunnel
+func (s *TunnelsService) Get(ctx context.Context, rc *ResourceContainer, tunnelID string) (Tunnel, error) {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return Tunnel{}, err
+	}
+
+	if tunnelID == "" {
+		return Tunnel{}, ErrMissingTunnelID
+	}
+
+	res, err := s.client.get(ctx, fmt.Sprintf("/accounts/%s/cfd_tunnel/%s", rc.Identifier, tunnelID), nil)
+	if err != nil {
+		return Tunnel{}, err
+	}
+
+	return unmarshalTunnel(res)
+}
+
+// Create creates a tunnel.
+//
+// API reference: https://api.cloudflare.com/#cloudflare-tunnel-create-cloudflare-tunnel
+func (s *TunnelsService) Create(ctx context.Context, rc *ResourceContainer, params TunnelCreateParams) (Tunnel, error) {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return Tunnel{}, err
+	}
+
+	res, err := s.client.post(ctx, fmt.Sprintf("/accounts/%s/cfd_tunnel", rc.Identifier), params)
+	if err != nil {
+		return Tunnel{}, err
+	}
+
+	return unmarshalTunnel(res)
+}
+
+// Update renames a tunnel or rotates its secret.
+//
+// API reference: https://api.cloudflare.com/#cloudflare-tunnel-update-cloudflare-tunnel
+func (s *TunnelsService) Update(ctx context.Context, rc *ResourceContainer, params TunnelUpdateParams) (Tunnel, error) {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return Tunnel{}, err
+	}
+
+	if params.ID == "" {
+		return Tunnel{}, ErrMissingTunnelID
+	}
+
+	res, err := s.client.patch(ctx, fmt.Sprintf("/accounts/%s/cfd_tunnel/%s", rc.Identifier, params.ID), params)
+	if err != nil {
+		return Tunnel{}, err
+	}
+
+	return unmarshalTunnel(res)
+}
+
+// Delete deletes a tunnel.
+//
+// API reference: https://api.cloudflare.com/#cloudflare-tunnel-delete-cloudflare-tunnel
+func (s *TunnelsService) Delete(ctx context.Context, rc *ResourceContainer, tunnelID string) error {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return err
+	}
+
+	if tunnelID == "" {
+		return ErrMissingTunnelID
+	}
+
+	_, err := s.client.delete(ctx, fmt.Sprintf("/accounts/%s/cfd_tunnel/%s", rc.Identifier, tunnelID), nil)
+	return err
+}
+
+// Connections returns the connections of a tunnel.
+//
+// API reference: https://api.cloudflare.com/#cloudflare-tunnel-list-cloudflare-tunnel-connections
+func (s *TunnelsService) Connections(ctx context.Context, rc *ResourceContainer, tunnelID string) ([]Connection, error) {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return []Connection{}, err
+	}
+
+	if tunnelID == "" {
+		return []Connection{}, ErrMissingTunnelID
+	}
+
+	res, err := s.client.get(ctx, fmt.Sprintf("/accounts/%s/cfd_tunnel/%s/connections", rc.Identifier, tunnelID), nil)
+	if err != nil {
+		return []Connection{}, err
+	}
+
+	var r TunnelConnectionResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return []Connection{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+
+	return r.Result, nil
+}
+
+// Token returns the token used to run a tunnel.
+//
+// API reference: https://api.cloudflare.com/#cloudflare-tunnel-get-cloudflare-tunnel-token
+func (s *TunnelsService) Token(ctx context.Context, rc *ResourceContainer, tunnelID string) (string, error) {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return "", err
+	}
+
+	if tunnelID == "" {
+		return "", ErrMissingTunnelID
+	}
+
+	res, err := s.client.get(ctx, fmt.Sprintf("/accounts/%s/cfd_tunnel/%s/token", rc.Identifier, tunnelID), nil)
+	if err != nil {
+		return "", err
+	}
+
+	var r TunnelTokenResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return "", fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+
+	return r.Result, nil
+}
+
+func unmarshalTunnel(res []byte) (Tunnel, error) {
+	var r TunnelDetailResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return Tunnel{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+
+	return r.Result, nil
+}
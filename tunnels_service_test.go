@@ -0,0 +1,46 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTunnelsService(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/cfd_tunnel", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "Expected method 'GET', got %s", r.Method)
+		assert.Equal(t, "blog", r.URL.Query().Get("name"))
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [{"id": "f174e90a-fafe-4643-bbbc-4a0ed4fc8415", "name": "blog"}], "result_info": {"page": 1, "per_page": 20, "total_pages": 1}}`)
+	})
+	mux.HandleFunc("/accounts/"+testAccountID+"/cfd_tunnel/f174e90a-fafe-4643-bbbc-4a0ed4fc8415", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method, "Expected method 'PATCH', got %s", r.Method)
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"name": "blog-v2"}`, string(body))
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "f174e90a-fafe-4643-bbbc-4a0ed4fc8415", "name": "blog-v2"}}`)
+	})
+
+	c := newExperimentalTestClient(t)
+	rc := AccountIdentifier(testAccountID)
+
+	tunnels, info, err := c.Tunnels.List(context.Background(), rc, TunnelListParams{Name: "blog"})
+	require.NoError(t, err)
+	require.Len(t, tunnels, 1)
+	assert.Equal(t, 1, info.TotalPages)
+
+	tunnel, err := c.Tunnels.Update(context.Background(), rc, TunnelUpdateParams{ID: tunnels[0].ID, Name: "blog-v2"})
+	require.NoError(t, err)
+	assert.Equal(t, "blog-v2", tunnel.Name)
+
+	_, err = c.Tunnels.Token(context.Background(), rc, "")
+	assert.ErrorIs(t, err, ErrMissingTunnelID)
+}
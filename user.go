@@ -158,3 +158,14 @@ func (api *API) UserBillingHistory(ctx context.Context, pageOpts UserBillingOpti
 	}
 	return r.Result, nil
 }
+
+// UserBillingHistoryIterator returns an Iterator over the billing history of
+// the user, fetching each page of results as it is needed.
+//
+// API reference: https://api.cloudflare.com/#user-billing-history-billing-history-details
+func (api *API) UserBillingHistoryIterator(ctx context.Context, pageOpts UserBillingOptions) *Iterator[UserBillingHistory] {
+	return newPageSizeIterator(ctx, pageOpts.PaginationOptions, 20, func(ctx context.Context, opts PaginationOptions) ([]UserBillingHistory, error) {
+		pageOpts.PaginationOptions = opts
+		return api.UserBillingHistory(ctx, pageOpts)
+	})
+}
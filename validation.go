@@ -0,0 +1,75 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Validator is implemented by request parameters that can be checked for
+// mistakes before they are sent to the API.
+type Validator interface {
+	Validate() error
+}
+
+// FieldError describes a single invalid field of a request parameter.
+type FieldError struct {
+	// Field is the path to the field, e.g. "Origins[1].Address".
+	Field string
+
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError is returned by Validate methods when one or more fields are
+// invalid.
+type ValidationError struct {
+	// Type is the name of the validated type, e.g. "DNSRecord".
+	Type string
+
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, fe := range e.Errors {
+		msgs = append(msgs, fe.Error())
+	}
+
+	return fmt.Sprintf("invalid %s: %s", e.Type, strings.Join(msgs, "; "))
+}
+
+// fieldErrors accumulates the FieldErrors of a Validate method.
+type fieldErrors []FieldError
+
+func (e *fieldErrors) add(field, format string, v ...interface{}) {
+	*e = append(*e, FieldError{Field: field, Message: fmt.Sprintf(format, v...)})
+}
+
+// err returns a *ValidationError for `typ` or nil if no field is invalid.
+func (e fieldErrors) err(typ string) error {
+	if len(e) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Type: typ, Errors: e}
+}
+
+// validateParams runs the parameters' Validate method when they have one.
+// PATCH requests aren't validated as their bodies only contain the fields
+// being changed.
+func validateParams(method string, params interface{}) error {
+	if method != http.MethodPost && method != http.MethodPut {
+		return nil
+	}
+
+	v, ok := params.(Validator)
+	if !ok {
+		return nil
+	}
+
+	return v.Validate()
+}
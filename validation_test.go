@@ -0,0 +1,133 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	lat := float32(91)
+	proxied := true
+
+	tests := map[string]struct {
+		params Validator
+		errors []FieldError
+	}{
+		"valid DNS record": {
+			params: DNSRecord{Type: "A", Name: "www", Content: "198.51.100.4", TTL: 1},
+		},
+		"DNS record from data": {
+			params: DNSRecord{Type: "SRV", Name: "_sip._tcp", Data: map[string]interface{}{"port": 5060}},
+		},
+		"DNS record missing type and content": {
+			params: DNSRecord{Name: "www", TTL: 10},
+			errors: []FieldError{
+				{Field: "Type", Message: "is required"},
+				{Field: "Content", Message: "is required unless Data is set"},
+				{Field: "TTL", Message: "must be 1 (automatic) or between 30 and 86400, got 10"},
+			},
+		},
+		"DNS record proxied TXT": {
+			params: DNSRecord{Type: "txt", Name: "www", Content: "hello", Proxied: &proxied, TTL: 300},
+			errors: []FieldError{
+				{Field: "Proxied", Message: "TXT records can't be proxied"},
+				{Field: "TTL", Message: "must be 1 (automatic) for proxied records, got 300"},
+			},
+		},
+		"valid worker route": {
+			params: WorkerRoute{Pattern: "*.example.com/api/*", Script: "api"},
+		},
+		"worker route with inner wildcard": {
+			params: WorkerRoute{Pattern: "example.com/*/users"},
+			errors: []FieldError{{Field: "Pattern", Message: "wildcards are only allowed at the end of the path"}},
+		},
+		"worker route without hostname": {
+			params: WorkerRoute{Pattern: "/api/*"},
+			errors: []FieldError{{Field: "Pattern", Message: "must start with a hostname"}},
+		},
+		"load balancer": {
+			params: LoadBalancer{Name: "lb.example.com", DefaultPools: []string{"a"}, SteeringPolicy: "fastest", RandomSteering: &RandomSteering{DefaultWeight: 0.5}},
+			errors: []FieldError{
+				{Field: "FallbackPool", Message: "is required"},
+				{Field: "SteeringPolicy", Message: `must be one of off, geo, dynamic_latency, random or proximity, got "fastest"`},
+				{Field: "RandomSteering", Message: `is only used with the "random" steering policy`},
+			},
+		},
+		"load balancer pool": {
+			params: LoadBalancerPool{Name: "primary", MinimumOrigins: 2, Latitude: &lat, Origins: []LoadBalancerOrigin{{Name: "app-1", Weight: 1.5}}},
+			errors: []FieldError{
+				{Field: "Origins[0].Address", Message: "is required"},
+				{Field: "Origins[0].Weight", Message: "must be between 0 and 1, got 1.5"},
+				{Field: "MinimumOrigins", Message: "is greater than the number of origins (1)"},
+				{Field: "Latitude", Message: "must be between -90 and 90, got 91"},
+			},
+		},
+		"load balancer monitor": {
+			params: LoadBalancerMonitor{Type: "https", Timeout: 60, Interval: 60},
+			errors: []FieldError{{Field: "Timeout", Message: "must be less than Interval (60), got 60"}},
+		},
+		"logpush job": {
+			params: LogpushJob{DestinationConf: "bucket/path", Frequency: "daily", Filter: &LogpushJobFilters{Where: LogpushJobFilter{Key: "ClientRequestHost"}}},
+			errors: []FieldError{
+				{Field: "DestinationConf", Message: `must be a URI such as s3://bucket/path, got "bucket/path"`},
+				{Field: "Frequency", Message: `must be "high" or "low", got "daily"`},
+				{Field: "Filter.Where", Message: "Operator is missing"},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tc.params.Validate()
+			if tc.errors == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var verr *ValidationError
+			require.True(t, errors.As(err, &verr), "expected a *ValidationError, got %v", err)
+			assert.Equal(t, tc.errors, verr.Errors)
+		})
+	}
+}
+
+func TestLoadBalancer_ValidatePools(t *testing.T) {
+	lb := LoadBalancer{
+		Name:           "lb.example.com",
+		FallbackPool:   "17b5962d775c646f3f9725cbc7a53df4",
+		DefaultPools:   []string{"de90f38ced07c2e2f4df50b1f61d4194", "9290f38c5d07c2e2f4df57b1f61d4196"},
+		SteeringPolicy: "dynamic_latency",
+	}
+	pools := []LoadBalancerPool{
+		{ID: "17b5962d775c646f3f9725cbc7a53df4", Monitor: "f1aba936b94213e5b8dca0c0dbf1f9cc"},
+		{ID: "de90f38ced07c2e2f4df50b1f61d4194"},
+	}
+
+	var verr *ValidationError
+	require.True(t, errors.As(lb.ValidatePools(pools), &verr))
+	assert.Equal(t, []FieldError{
+		{Field: "DefaultPools[0]", Message: `pool "de90f38ced07c2e2f4df50b1f61d4194" has no monitor, which dynamic_latency steering requires`},
+		{Field: "DefaultPools[1]", Message: `pool "9290f38c5d07c2e2f4df57b1f61d4196" doesn't exist`},
+	}, verr.Errors)
+
+	lb.SteeringPolicy = "geo"
+	lb.DefaultPools = lb.DefaultPools[:1]
+	assert.NoError(t, lb.ValidatePools(pools))
+}
+
+func TestUsingParamValidation(t *testing.T) {
+	setup(UsingParamValidation())
+	defer teardown()
+
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid record was sent")
+	})
+
+	_, err := client.CreateDNSRecord(context.Background(), testZoneID, DNSRecord{Name: "www.example.com", Content: "198.51.100.4"})
+	assert.EqualError(t, err, "invalid DNSRecord: Type: is required")
+}
@@ -146,6 +146,14 @@ func (api *API) ListWAFPackages(ctx context.Context, zoneID string) ([]WAFPackag
 	return packages, nil
 }
 
+// ListWAFPackagesIterator returns an Iterator over the WAF packages for the
+// given zone, fetching each page of results as it is needed.
+//
+// API Reference: https://api.cloudflare.com/#waf-rule-packages-list-firewall-packages
+func (api *API) ListWAFPackagesIterator(ctx context.Context, zoneID string) *Iterator[WAFPackage] {
+	return newWAFIterator[WAFPackage](ctx, api, fmt.Sprintf("/zones/%s/firewall/waf/packages", zoneID))
+}
+
 // WAFPackage returns a WAF package for the given zone.
 //
 // API Reference: https://api.cloudflare.com/#waf-rule-packages-firewall-package-details
@@ -228,6 +236,14 @@ func (api *API) ListWAFGroups(ctx context.Context, zoneID, packageID string) ([]
 	return groups, nil
 }
 
+// ListWAFGroupsIterator returns an Iterator over the WAF groups for the given
+// WAF package, fetching each page of results as it is needed.
+//
+// API Reference: https://api.cloudflare.com/#waf-rule-groups-list-rule-groups
+func (api *API) ListWAFGroupsIterator(ctx context.Context, zoneID, packageID string) *Iterator[WAFGroup] {
+	return newWAFIterator[WAFGroup](ctx, api, fmt.Sprintf("/zones/%s/firewall/waf/packages/%s/groups", zoneID, packageID))
+}
+
 // WAFGroup returns a WAF rule group from the given WAF package.
 //
 // API Reference: https://api.cloudflare.com/#waf-rule-groups-rule-group-details
@@ -312,6 +328,43 @@ func (api *API) ListWAFRules(ctx context.Context, zoneID, packageID string) ([]W
 	return rules, nil
 }
 
+// ListWAFRulesIterator returns an Iterator over the WAF rules for the given
+// WAF package, fetching each page of results as it is needed.
+//
+// API Reference: https://api.cloudflare.com/#waf-rules-list-rules
+func (api *API) ListWAFRulesIterator(ctx context.Context, zoneID, packageID string) *Iterator[WAFRule] {
+	return newWAFIterator[WAFRule](ctx, api, fmt.Sprintf("/zones/%s/firewall/waf/packages/%s/rules", zoneID, packageID))
+}
+
+// newWAFIterator returns an Iterator over the paginated WAF list endpoint at
+// `uri`, requesting the largest page size the API allows.
+func newWAFIterator[T any](ctx context.Context, api *API, uri string) *Iterator[T] {
+	// Request as many results as possible per page - API max is 100
+	start := ResultInfo{Page: 1, PerPage: 100}
+
+	return newIterator(ctx, start, func(ctx context.Context, info ResultInfo) ([]T, ResultInfo, error) {
+		v := url.Values{}
+		v.Set("per_page", strconv.Itoa(info.PerPage))
+		v.Set("page", strconv.Itoa(info.Page))
+		res, err := api.makeRequestContext(ctx, http.MethodGet, uri+"?"+v.Encode(), nil)
+		if err != nil {
+			return nil, ResultInfo{}, err
+		}
+
+		var r struct {
+			Response
+			Result     []T        `json:"result"`
+			ResultInfo ResultInfo `json:"result_info"`
+		}
+		err = json.Unmarshal(res, &r)
+		if err != nil {
+			return nil, ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+		}
+
+		return r.Result, r.ResultInfo, nil
+	})
+}
+
 // WAFRule returns a WAF rule from the given WAF package.
 //
 // API Reference: https://api.cloudflare.com/#waf-rules-rule-details
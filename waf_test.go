@@ -140,6 +140,13 @@ func TestListWAFPackagesMultiplePages(t *testing.T) {
 		assert.Equal(t, want, d)
 	}
 
+	page = 1
+	d, err = client.ListWAFPackagesIterator(context.Background(), testZoneID).All()
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, want, d)
+	}
+
 	_, err = client.ListWAFRules(context.Background(), testZoneID, "123")
 	assert.Error(t, err)
 }
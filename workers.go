@@ -48,6 +48,39 @@ type WorkerRoute struct {
 	Script  string `json:"script,omitempty"`
 }
 
+// Validate checks the route pattern is a hostname and optional path with
+// wildcards only at the start of the hostname and the end of the path.
+//
+// API reference: https://developers.cloudflare.com/workers/platform/triggers/routes/#matching-behavior
+func (route WorkerRoute) Validate() error {
+	var errs fieldErrors
+
+	pattern := route.Pattern
+	switch {
+	case pattern == "":
+		errs.add("Pattern", "is required")
+	case strings.ContainsAny(pattern, " \t\n"):
+		errs.add("Pattern", "must not contain whitespace")
+	default:
+		pattern = strings.TrimPrefix(strings.TrimPrefix(pattern, "http://"), "https://")
+		host, path := pattern, ""
+		if i := strings.IndexByte(pattern, '/'); i >= 0 {
+			host, path = pattern[:i], pattern[i:]
+		}
+
+		switch {
+		case host == "":
+			errs.add("Pattern", "must start with a hostname")
+		case strings.Contains(strings.TrimPrefix(host, "*"), "*"):
+			errs.add("Pattern", "wildcards are only allowed at the start of the hostname")
+		case strings.Contains(strings.TrimSuffix(path, "*"), "*"):
+			errs.add("Pattern", "wildcards are only allowed at the end of the path")
+		}
+	}
+
+	return errs.err("WorkerRoute")
+}
+
 // WorkerRoutesResponse embeds Response struct and slice of WorkerRoutes.
 type WorkerRoutesResponse struct {
 	Response
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -95,38 +96,41 @@ func (api *API) CreateWorkersKVNamespace(ctx context.Context, req *WorkersKVName
 //
 // API reference: https://api.cloudflare.com/#workers-kv-namespace-list-namespaces
 func (api *API) ListWorkersKVNamespaces(ctx context.Context) ([]WorkersKVNamespace, error) {
+	namespaces, err := api.ListWorkersKVNamespacesIterator(ctx).All()
+	if err != nil {
+		return []WorkersKVNamespace{}, err
+	}
+
+	return namespaces, nil
+}
+
+// ListWorkersKVNamespacesIterator returns an Iterator over the storage
+// namespaces, fetching each page of results as it is needed.
+//
+// API reference: https://api.cloudflare.com/#workers-kv-namespace-list-namespaces
+func (api *API) ListWorkersKVNamespacesIterator(ctx context.Context) *Iterator[WorkersKVNamespace] {
 	v := url.Values{}
 	v.Set("per_page", "100")
 
-	var namespaces []WorkersKVNamespace
-	page := 1
-
-	for {
-		v.Set("page", strconv.Itoa(page))
+	return newIterator(ctx, ResultInfo{Page: 1}, func(ctx context.Context, info ResultInfo) ([]WorkersKVNamespace, ResultInfo, error) {
+		v.Set("page", strconv.Itoa(info.Page))
 		uri := fmt.Sprintf("/accounts/%s/storage/kv/namespaces?%s", api.AccountID, v.Encode())
 		res, err := api.makeRequestContext(ctx, http.MethodGet, uri, nil)
 		if err != nil {
-			return []WorkersKVNamespace{}, err
+			return nil, ResultInfo{}, err
 		}
 
 		var p ListWorkersKVNamespacesResponse
 		if err := json.Unmarshal(res, &p); err != nil {
-			return []WorkersKVNamespace{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+			return nil, ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
 		}
 
 		if !p.Success {
-			return []WorkersKVNamespace{}, errors.New(errRequestNotSuccessful)
-		}
-
-		namespaces = append(namespaces, p.Result...)
-		if p.ResultInfo.Page >= p.ResultInfo.TotalPages {
-			break
+			return nil, ResultInfo{}, errors.New(errRequestNotSuccessful)
 		}
 
-		page++
-	}
-
-	return namespaces, nil
+		return p.Result, p.ResultInfo, nil
+	})
 }
 
 // DeleteWorkersKVNamespace deletes the namespace corresponding to the given ID
@@ -219,6 +223,17 @@ func (api API) ReadWorkersKV(ctx context.Context, namespaceID, key string) ([]by
 	return res, nil
 }
 
+// ReadWorkersKVStream returns the value associated with the given key in the
+// given namespace like ReadWorkersKV but without reading it into memory. The
+// caller is responsible for closing the returned body.
+//
+// API reference: https://api.cloudflare.com/#workers-kv-namespace-read-key-value-pair
+func (api API) ReadWorkersKVStream(ctx context.Context, namespaceID, key string) (io.ReadCloser, error) {
+	key = url.PathEscape(key)
+	uri := fmt.Sprintf("/accounts/%s/storage/kv/namespaces/%s/values/%s", api.AccountID, namespaceID, key)
+	return api.makeRequestStream(ctx, http.MethodGet, uri, nil, nil)
+}
+
 // DeleteWorkersKV deletes a key and value for a provided storage namespace
 //
 // API reference: https://api.cloudflare.com/#workers-kv-namespace-delete-key-value-pair
@@ -305,3 +320,29 @@ func (api API) ListWorkersKVsWithOptions(ctx context.Context, namespaceID string
 	}
 	return result, err
 }
+
+// ListWorkersKVsIterator returns an Iterator over all of a namespace's keys,
+// following the cursor returned by each page. The `Cursor` field of the
+// provided options is used as the starting point.
+//
+// API Reference: https://api.cloudflare.com/#workers-kv-namespace-list-a-namespace-s-keys
+func (api API) ListWorkersKVsIterator(ctx context.Context, namespaceID string, o ListWorkersKVsOptions) *Iterator[StorageKey] {
+	start := ResultInfo{}
+	if o.Cursor != nil {
+		start.Cursor = *o.Cursor
+	}
+
+	return newIterator(ctx, start, func(ctx context.Context, info ResultInfo) ([]StorageKey, ResultInfo, error) {
+		o.Cursor = nil
+		if info.Cursor != "" {
+			o.Cursor = &info.Cursor
+		}
+
+		r, err := api.ListWorkersKVsWithOptions(ctx, namespaceID, o)
+		if err != nil {
+			return nil, ResultInfo{}, err
+		}
+
+		return r.Result, r.ResultInfo, nil
+	})
+}
@@ -0,0 +1,210 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/google/go-querystring/query"
+)
+
+var (
+	ErrMissingKVNamespaceID = errors.New("required missing Workers KV namespace ID")
+	ErrMissingKVKey         = errors.New("required missing Workers KV key")
+)
+
+type WorkersKVService service
+
+type WorkersKVNamespaceListParams struct {
+	ResultInfo
+}
+
+type WorkersKVNamespaceCreateParams struct {
+	Title string `json:"title"`
+}
+
+type WorkersKVNamespaceUpdateParams struct {
+	ID    string `json:"-"`
+	Title string `json:"title"`
+}
+
+type WorkersKVListKeysParams struct {
+	NamespaceID string `url:"-"`
+	Prefix      string `url:"prefix,omitempty"`
+	Limit       int    `url:"limit,omitempty"`
+	Cursor      string `url:"cursor,omitempty"`
+}
+
+type WorkersKVGetParams struct {
+	NamespaceID string
+	Key         string
+}
+
+type WorkersKVPutParams struct {
+	NamespaceID   string `url:"-"`
+	Key           string `url:"-"`
+	Value         []byte `url:"-"`
+	Expiration    int    `url:"expiration,omitempty"`
+	ExpirationTTL int    `url:"expiration_ttl,omitempty"`
+}
+
+type WorkersKVDeleteParams struct {
+	NamespaceID string
+	Key         string
+}
+
+// ListNamespaces returns the Workers KV namespaces of an account.
+//
+// Pagination is automatically handled unless `params.Page` is supplied.
+//
+// API reference: https://api.cloudflare.com/#workers-kv-namespace-list-namespaces
+func (s *WorkersKVService) ListNamespaces(ctx context.Context, rc *ResourceContainer, params WorkersKVNamespaceListParams) ([]WorkersKVNamespace, *ResultInfo, error) {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return []WorkersKVNamespace{}, &ResultInfo{}, err
+	}
+
+	uri := fmt.Sprintf("/accounts/%s/storage/kv/namespaces", rc.Identifier)
+	return listAll(ctx, params.ResultInfo, func(ctx context.Context, info ResultInfo) ([]WorkersKVNamespace, ResultInfo, error) {
+		params.ResultInfo = info
+		return getPage[WorkersKVNamespace](ctx, s.client, buildURI(uri, params))
+	})
+}
+
+// CreateNamespace creates a Workers KV namespace.
+//
+// API reference: https://api.cloudflare.com/#workers-kv-namespace-create-a-namespace
+func (s *WorkersKVService) CreateNamespace(ctx context.Context, rc *ResourceContainer, params WorkersKVNamespaceCreateParams) (WorkersKVNamespace, error) {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return WorkersKVNamespace{}, err
+	}
+
+	res, err := s.client.post(ctx, fmt.Sprintf("/accounts/%s/storage/kv/namespaces", rc.Identifier), params)
+	if err != nil {
+		return WorkersKVNamespace{}, err
+	}
+
+	var r WorkersKVNamespaceResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return WorkersKVNamespace{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+
+	return r.Result, nil
+}
+
+// UpdateNamespace renames a Workers KV namespace.
+//
+// API reference: https://api.cloudflare.com/#workers-kv-namespace-rename-a-namespace
+func (s *WorkersKVService) UpdateNamespace(ctx context.Context, rc *ResourceContainer, params WorkersKVNamespaceUpdateParams) error {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return err
+	}
+
+	if params.ID == "" {
+		return ErrMissingKVNamespaceID
+	}
+
+	_, err := s.client.put(ctx, fmt.Sprintf("/accounts/%s/storage/kv/namespaces/%s", rc.Identifier, params.ID), params)
+	return err
+}
+
+// DeleteNamespace deletes a Workers KV namespace and every key in it.
+//
+// API reference: https://api.cloudflare.com/#workers-kv-namespace-remove-a-namespace
+func (s *WorkersKVService) DeleteNamespace(ctx context.Context, rc *ResourceContainer, namespaceID string) error {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return err
+	}
+
+	if namespaceID == "" {
+		return ErrMissingKVNamespaceID
+	}
+
+	_, err := s.client.delete(ctx, fmt.Sprintf("/accounts/%s/storage/kv/namespaces/%s", rc.Identifier, namespaceID), nil)
+	return err
+}
+
+// ListKeys returns the keys in a namespace that match `params`.
+//
+// Pagination is automatically handled unless `params.Cursor` is supplied.
+//
+// API reference: https://api.cloudflare.com/#workers-kv-namespace-list-a-namespace-s-keys
+func (s *WorkersKVService) ListKeys(ctx context.Context, rc *ResourceContainer, params WorkersKVListKeysParams) ([]StorageKey, *ResultInfo, error) {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return []StorageKey{}, &ResultInfo{}, err
+	}
+
+	if params.NamespaceID == "" {
+		return []StorageKey{}, &ResultInfo{}, ErrMissingKVNamespaceID
+	}
+
+	uri := fmt.Sprintf("/accounts/%s/storage/kv/namespaces/%s/keys", rc.Identifier, params.NamespaceID)
+	return listAll(ctx, ResultInfo{Cursor: params.Cursor}, func(ctx context.Context, info ResultInfo) ([]StorageKey, ResultInfo, error) {
+		params.Cursor = info.Cursor
+		return getPage[StorageKey](ctx, s.client, buildURI(uri, params))
+	})
+}
+
+// Get returns the value of a key.
+//
+// API reference: https://api.cloudflare.com/#workers-kv-namespace-read-key-value-pair
+func (s *WorkersKVService) Get(ctx context.Context, rc *ResourceContainer, params WorkersKVGetParams) ([]byte, error) {
+	uri, err := workersKVValueURI(rc, params.NamespaceID, params.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.get(ctx, uri, nil)
+}
+
+// Put writes the value of a key.
+//
+// API reference: https://api.cloudflare.com/#workers-kv-namespace-write-key-value-pair
+func (s *WorkersKVService) Put(ctx context.Context, rc *ResourceContainer, params WorkersKVPutParams) error {
+	uri, err := workersKVValueURI(rc, params.NamespaceID, params.Key)
+	if err != nil {
+		return err
+	}
+
+	// the key is already escaped so the query is appended rather than using
+	// buildURI which would escape it again.
+	if v, _ := query.Values(params); len(v) > 0 {
+		uri += "?" + v.Encode()
+	}
+
+	_, err = s.client.makeRequest(ctx, http.MethodPut, uri, params.Value, http.Header{
+		"Content-Type": []string{"application/octet-stream"},
+	})
+	return err
+}
+
+// Delete removes a key and its value.
+//
+// API reference: https://api.cloudflare.com/#workers-kv-namespace-delete-key-value-pair
+func (s *WorkersKVService) Delete(ctx context.Context, rc *ResourceContainer, params WorkersKVDeleteParams) error {
+	uri, err := workersKVValueURI(rc, params.NamespaceID, params.Key)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.delete(ctx, uri, nil)
+	return err
+}
+
+func workersKVValueURI(rc *ResourceContainer, namespaceID, key string) (string, error) {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return "", err
+	}
+
+	if namespaceID == "" {
+		return "", ErrMissingKVNamespaceID
+	}
+
+	if key == "" {
+		return "", ErrMissingKVKey
+	}
+
+	return fmt.Sprintf("/accounts/%s/storage/kv/namespaces/%s/values/%s", rc.Identifier, namespaceID, url.PathEscape(key)), nil
+}
@@ -0,0 +1,62 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkersKVService_Values(t *testing.T) {
+	setup()
+	defer teardown()
+
+	namespaceID := "0f2ac74b498b48028cb68387c421e279"
+	mux.HandleFunc("/accounts/"+testAccountID+"/storage/kv/namespaces/"+namespaceID+"/values/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/"+testAccountID+"/storage/kv/namespaces/"+namespaceID+"/values/config%2Fprod", r.URL.EscapedPath())
+		switch r.Method {
+		case http.MethodPut:
+			assert.Equal(t, "application/octet-stream", r.Header.Get("Content-Type"))
+			assert.Equal(t, "3600", r.URL.Query().Get("expiration_ttl"))
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Equal(t, `{"debug": false}`, string(body))
+			w.Header().Set("content-type", "application/json")
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": null}`)
+		case http.MethodGet:
+			w.Header().Set("content-type", "application/octet-stream")
+			fmt.Fprint(w, `{"debug": false}`)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+	mux.HandleFunc("/accounts/"+testAccountID+"/storage/kv/namespaces/"+namespaceID+"/keys", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "config/", r.URL.Query().Get("prefix"))
+		w.Header().Set("content-type", "application/json")
+		if r.URL.Query().Get("cursor") == "" {
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [{"name": "config/prod"}], "result_info": {"count": 1, "cursor": "6Ck1la0VxJ0djhidm1MdX2FyDGxLKVeeHZZmORS_8XeSuhz9SjIJRaSa2lnsF01tQOHrfTGAP3R5X1Kv5iVUuMbNKhWNAXHOl6ePB0TUL8nw"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": [{"name": "config/staging"}], "result_info": {"count": 1, "cursor": ""}}`)
+	})
+
+	c := newExperimentalTestClient(t)
+	rc := AccountIdentifier(testAccountID)
+
+	err := c.WorkersKV.Put(context.Background(), rc, WorkersKVPutParams{NamespaceID: namespaceID, Key: "config/prod", Value: []byte(`{"debug": false}`), ExpirationTTL: 3600})
+	require.NoError(t, err)
+
+	value, err := c.WorkersKV.Get(context.Background(), rc, WorkersKVGetParams{NamespaceID: namespaceID, Key: "config/prod"})
+	require.NoError(t, err)
+	assert.Equal(t, `{"debug": false}`, string(value))
+
+	keys, _, err := c.WorkersKV.ListKeys(context.Background(), rc, WorkersKVListKeysParams{NamespaceID: namespaceID, Prefix: "config/"})
+	require.NoError(t, err)
+	assert.Equal(t, []StorageKey{{Name: "config/prod"}, {Name: "config/staging"}}, keys)
+
+	_, err = c.WorkersKV.Get(context.Background(), rc, WorkersKVGetParams{NamespaceID: namespaceID})
+	assert.ErrorIs(t, err, ErrMissingKVKey)
+}
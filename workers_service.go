@@ -0,0 +1,86 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type WorkersService service
+
+type WorkerUploadParams struct {
+	ScriptName string
+
+	WorkerScriptParams
+}
+
+// List returns the Worker scripts of an account.
+//
+// API reference: https://api.cloudflare.com/#worker-script-list-workers
+func (s *WorkersService) List(ctx context.Context, rc *ResourceContainer) ([]WorkerMetaData, error) {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return []WorkerMetaData{}, err
+	}
+
+	res, err := s.client.get(ctx, fmt.Sprintf("/accounts/%s/workers/scripts", rc.Identifier), nil)
+	if err != nil {
+		return []WorkerMetaData{}, err
+	}
+
+	var r WorkerListResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return []WorkerMetaData{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+
+	return r.WorkerList, nil
+}
+
+// Upload creates or replaces a Worker script along with its bindings.
+//
+// API reference: https://api.cloudflare.com/#worker-script-upload-worker
+func (s *WorkersService) Upload(ctx context.Context, rc *ResourceContainer, params WorkerUploadParams) (WorkerScript, error) {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return WorkerScript{}, err
+	}
+
+	if params.ScriptName == "" {
+		return WorkerScript{}, ErrMissingScriptName
+	}
+
+	contentType, body, err := formatMultipartBody(&params.WorkerScriptParams)
+	if err != nil {
+		return WorkerScript{}, err
+	}
+
+	uri := fmt.Sprintf("/accounts/%s/workers/scripts/%s", rc.Identifier, params.ScriptName)
+	res, err := s.client.makeRequest(ctx, http.MethodPut, uri, body, http.Header{
+		"Content-Type": []string{contentType},
+	})
+	if err != nil {
+		return WorkerScript{}, err
+	}
+
+	var r WorkerScriptResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return WorkerScript{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+
+	return r.WorkerScript, nil
+}
+
+// Delete deletes a Worker script.
+//
+// API reference: https://api.cloudflare.com/#worker-script-delete-worker
+func (s *WorkersService) Delete(ctx context.Context, rc *ResourceContainer, scriptName string) error {
+	if err := requireLevel(rc, AccountRouteLevel); err != nil {
+		return err
+	}
+
+	if scriptName == "" {
+		return ErrMissingScriptName
+	}
+
+	_, err := s.client.delete(ctx, fmt.Sprintf("/accounts/%s/workers/scripts/%s", rc.Identifier, scriptName), nil)
+	return err
+}
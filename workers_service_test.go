@@ -0,0 +1,42 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkersService_Upload(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/"+testAccountID+"/workers/scripts/hello", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method, "Expected method 'PUT', got %s", r.Method)
+		assert.True(t, strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data"))
+
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		assert.Contains(t, r.MultipartForm.Value["metadata"][0], `"main_module":"worker.mjs"`)
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "hello", "etag": "279cf40d86d70b82f6cd3ba90a646b3ad995912da446836d7371c21c6a43977a", "size": 51}}`)
+	})
+
+	c := newExperimentalTestClient(t)
+	script, err := c.Workers.Upload(context.Background(), AccountIdentifier(testAccountID), WorkerUploadParams{
+		ScriptName: "hello",
+		WorkerScriptParams: WorkerScriptParams{
+			Script: `export default { fetch() { return new Response("hello") } }`,
+			Module: true,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "hello", script.ID)
+
+	_, err = c.Workers.Upload(context.Background(), AccountIdentifier(testAccountID), WorkerUploadParams{})
+	assert.ErrorIs(t, err, ErrMissingScriptName)
+}
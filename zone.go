@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -503,6 +504,46 @@ func (api *API) ListZonesContext(ctx context.Context, opts ...ReqOption) (r Zone
 	}
 }
 
+// ListZonesIterator returns an Iterator over all zones on an account,
+// fetching each page of results sequentially as it is needed. Optionally
+// takes a list of ReqOptions.
+//
+// API reference: https://api.cloudflare.com/#zone-list-zones
+func (api *API) ListZonesIterator(ctx context.Context, opts ...ReqOption) *Iterator[Zone] {
+	opt := reqOption{
+		params: url.Values{},
+	}
+	for _, of := range opts {
+		of(&opt)
+	}
+
+	start := ResultInfo{Page: 1, PerPage: listZonesPerPage}
+	if page, err := strconv.Atoi(opt.params.Get("page")); err == nil && page > 0 {
+		start.Page = page
+	}
+	if perPage, err := strconv.Atoi(opt.params.Get("per_page")); err == nil && perPage > 0 {
+		start.PerPage = perPage
+	}
+
+	return newIterator(ctx, start, func(ctx context.Context, info ResultInfo) ([]Zone, ResultInfo, error) {
+		opt.params.Set("page", strconv.Itoa(info.Page))
+		opt.params.Set("per_page", strconv.Itoa(info.PerPage))
+
+		res, err := api.makeRequestContext(ctx, http.MethodGet, "/zones?"+opt.params.Encode(), nil)
+		if err != nil {
+			return nil, ResultInfo{}, err
+		}
+
+		var r ZonesResponse
+		err = json.Unmarshal(res, &r)
+		if err != nil {
+			return nil, ResultInfo{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+		}
+
+		return r.Result, r.ResultInfo, nil
+	})
+}
+
 // ZoneDetails fetches information about a zone.
 //
 // API reference: https://api.cloudflare.com/#zone-zone-details
@@ -945,6 +986,15 @@ func (api *API) ZoneExport(ctx context.Context, zoneID string) (string, error) {
 	return string(res), nil
 }
 
+// ZoneExportStream returns the text BIND config for the given zone like
+// ZoneExport but without reading it into memory. The caller is responsible
+// for closing the returned body.
+//
+// API reference: https://api.cloudflare.com/#dns-records-for-a-zone-export-dns-records
+func (api *API) ZoneExportStream(ctx context.Context, zoneID string) (io.ReadCloser, error) {
+	return api.makeRequestStream(ctx, http.MethodGet, "/zones/"+zoneID+"/dns_records/export", nil, nil)
+}
+
 // ZoneDNSSECResponse represents the response from the Zone DNSSEC Setting.
 type ZoneDNSSECResponse struct {
 	Response
@@ -0,0 +1,578 @@
+package cloudflare
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ErrMissingZoneFile is returned by ImportZoneFile when there isn't a file to
+// upload.
+var ErrMissingZoneFile = errors.New("required missing zone file")
+
+// ZoneFileError is returned by ParseZoneFile for a zone file that can't be
+// parsed.
+type ZoneFileError struct {
+	// Line is the line number the offending entry starts on.
+	Line int
+	Err  error
+}
+
+func (e *ZoneFileError) Error() string {
+	return fmt.Sprintf("zone file line %d: %s", e.Line, e.Err)
+}
+
+func (e *ZoneFileError) Unwrap() error {
+	return e.Err
+}
+
+// ParseZoneFile parses a RFC 1035 (BIND) zone file such as the one returned by
+// ZoneExport into DNS records.
+//
+// Relative names are qualified with `origin` until a $ORIGIN directive
+// changes it, and records without a TTL use the $TTL directive or the TTL of
+// the previous record. Entries may span several lines using parentheses.
+// Records with structured data (SRV, CAA, TLSA, LOC, SSHFP, DS, DNSKEY,
+// NAPTR, HTTPS, SVCB and URI) have their DNSRecordData set as DNSRecord.Data.
+//
+// SOA records and the NS records of the origin are managed by Cloudflare and
+// are skipped. $INCLUDE and $GENERATE directives aren't supported.
+func ParseZoneFile(r io.Reader, origin string) ([]DNSRecord, error) {
+	entries, err := lexZoneFile(r)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &zoneFileParser{origin: strings.TrimSuffix(origin, "."), defaultTTL: -1}
+
+	var records []DNSRecord
+	for _, e := range entries {
+		rr, ok, err := p.parse(e)
+		if err != nil {
+			return nil, &ZoneFileError{Line: e.line, Err: err}
+		}
+		if ok {
+			records = append(records, rr)
+		}
+	}
+
+	return records, nil
+}
+
+type zoneFileToken struct {
+	text   string
+	quoted bool
+}
+
+// zoneFileEntry is a directive or resource record, joined across lines when
+// it uses parentheses.
+type zoneFileEntry struct {
+	line     int
+	indented bool
+	tokens   []zoneFileToken
+}
+
+// lexZoneFile splits a zone file into entries, dropping comments.
+func lexZoneFile(r io.Reader) ([]zoneFileEntry, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var (
+		entries []zoneFileEntry
+		entry   zoneFileEntry
+		depth   int
+		lineNo  int
+	)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		lineNo++
+
+		if depth == 0 {
+			entry = zoneFileEntry{line: lineNo, indented: line != "" && (line[0] == ' ' || line[0] == '\t')}
+		}
+
+		var err error
+		if entry.tokens, err = splitZoneFileLine(line, entry.tokens, &depth); err != nil {
+			return nil, &ZoneFileError{Line: lineNo, Err: err}
+		}
+
+		if depth == 0 && len(entry.tokens) > 0 {
+			entries = append(entries, entry)
+			entry = zoneFileEntry{}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if depth > 0 {
+		return nil, &ZoneFileError{Line: entry.line, Err: errors.New("unclosed parenthesis")}
+	}
+
+	return entries, nil
+}
+
+// splitZoneFileLine appends the tokens of a line of a zone file to `tokens`,
+// dropping its comment and tracking the depth of parentheses. It is also used
+// to split the presentation format of record data.
+func splitZoneFileLine(line string, tokens []zoneFileToken, depth *int) ([]zoneFileToken, error) {
+	var tok strings.Builder
+	flush := func() {
+		if tok.Len() > 0 {
+			tokens = append(tokens, zoneFileToken{text: tok.String()})
+			tok.Reset()
+		}
+	}
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == ';':
+			flush()
+			return tokens, nil
+		case c == '"':
+			var s strings.Builder
+			closed := false
+			for i++; i < len(line); i++ {
+				if line[i] == '\\' && i+1 < len(line) && (line[i+1] == '"' || line[i+1] == '\\') {
+					i++
+					s.WriteByte(line[i])
+					continue
+				}
+				if line[i] == '"' {
+					closed = true
+					break
+				}
+				s.WriteByte(line[i])
+			}
+			if !closed {
+				return nil, errors.New("unterminated quoted string")
+			}
+
+			// Quotes within a token, as in alpn="h2,h3", are kept.
+			if tok.Len() > 0 {
+				tok.WriteString(strconv.Quote(s.String()))
+				continue
+			}
+			tokens = append(tokens, zoneFileToken{text: s.String(), quoted: true})
+		case c == '(':
+			flush()
+			*depth++
+		case c == ')':
+			flush()
+			*depth--
+			if *depth < 0 {
+				return nil, errors.New("unbalanced closing parenthesis")
+			}
+		case c == ' ' || c == '\t' || c == '\r':
+			flush()
+		case c == '\\' && i+1 < len(line):
+			tok.WriteByte(c)
+			i++
+			tok.WriteByte(line[i])
+		default:
+			tok.WriteByte(c)
+		}
+	}
+	flush()
+
+	return tokens, nil
+}
+
+type zoneFileParser struct {
+	origin     string
+	defaultTTL int
+	lastTTL    int
+	lastOwner  string
+}
+
+// parse returns the record of `e`. It returns false for directives and
+// skipped records.
+func (p *zoneFileParser) parse(e zoneFileEntry) (DNSRecord, bool, error) {
+	tokens := e.tokens
+
+	if !e.indented && !tokens[0].quoted && strings.HasPrefix(tokens[0].text, "$") {
+		return DNSRecord{}, false, p.directive(tokens)
+	}
+
+	owner := p.lastOwner
+	if !e.indented {
+		var err error
+		owner, err = p.qualify(tokens[0].text)
+		if err != nil {
+			return DNSRecord{}, false, err
+		}
+		tokens = tokens[1:]
+	}
+	if owner == "" {
+		return DNSRecord{}, false, errors.New("record has no owner name")
+	}
+	p.lastOwner = owner
+
+	ttl := -1
+	for i := 0; i < 2 && len(tokens) > 0; i++ {
+		t := strings.ToUpper(tokens[0].text)
+		switch {
+		case t == "IN":
+		case t == "CH" || t == "HS" || t == "CS":
+			return DNSRecord{}, false, fmt.Errorf("unsupported class %s", t)
+		case t != "" && t[0] >= '0' && t[0] <= '9':
+			var err error
+			if ttl, err = parseZoneFileTTL(t); err != nil {
+				return DNSRecord{}, false, err
+			}
+		default:
+			i = 2
+			continue
+		}
+		tokens = tokens[1:]
+	}
+
+	switch {
+	case ttl >= 0:
+		p.lastTTL = ttl
+	case p.defaultTTL >= 0:
+		ttl = p.defaultTTL
+	default:
+		ttl = p.lastTTL
+	}
+
+	if len(tokens) == 0 {
+		return DNSRecord{}, false, errors.New("record has no type")
+	}
+
+	rrType := strings.ToUpper(tokens[0].text)
+	if rrType == "SOA" || (rrType == "NS" && owner == p.origin) {
+		return DNSRecord{}, false, nil
+	}
+
+	rr, err := p.record(rrType, owner, tokens[1:])
+	if err != nil {
+		return DNSRecord{}, false, fmt.Errorf("%s record: %w", rrType, err)
+	}
+	rr.Type = rrType
+	rr.Name = owner
+	rr.TTL = ttl
+
+	return rr, true, nil
+}
+
+func (p *zoneFileParser) directive(tokens []zoneFileToken) error {
+	name := strings.ToUpper(tokens[0].text)
+	switch name {
+	case "$ORIGIN":
+		if len(tokens) != 2 {
+			return errors.New("$ORIGIN takes a single domain name")
+		}
+		origin, err := p.qualify(tokens[1].text)
+		if err != nil {
+			return err
+		}
+		p.origin = origin
+	case "$TTL":
+		if len(tokens) != 2 {
+			return errors.New("$TTL takes a single TTL")
+		}
+		ttl, err := parseZoneFileTTL(tokens[1].text)
+		if err != nil {
+			return err
+		}
+		p.defaultTTL = ttl
+	default:
+		return fmt.Errorf("unsupported directive %s", name)
+	}
+
+	return nil
+}
+
+// qualify returns the fully qualified form of `name`, without the trailing
+// dot.
+func (p *zoneFileParser) qualify(name string) (string, error) {
+	switch {
+	case name == "@":
+		if p.origin == "" {
+			return "", errors.New("@ used without an origin")
+		}
+		return p.origin, nil
+	case strings.HasSuffix(name, ".") && !strings.HasSuffix(name, "\\."):
+		return strings.TrimSuffix(name, "."), nil
+	case p.origin == "":
+		return "", fmt.Errorf("relative name %q used without an origin", name)
+	default:
+		return name + "." + p.origin, nil
+	}
+}
+
+// record builds the type specific parts of a record from its rdata.
+func (p *zoneFileParser) record(rrType, owner string, rdata []zoneFileToken) (DNSRecord, error) {
+	r := rdataReader{tokens: rdata}
+	var rr DNSRecord
+
+	switch rrType {
+	case "A", "AAAA":
+		addr := r.next()
+		ip := net.ParseIP(addr)
+		if ip == nil || (rrType == "A") != (ip.To4() != nil) {
+			return rr, fmt.Errorf("invalid address %q", addr)
+		}
+		rr.Content = addr
+
+	case "CNAME", "NS", "PTR":
+		target, err := p.qualify(r.next())
+		if err != nil {
+			return rr, err
+		}
+		rr.Content = target
+
+	case "MX":
+		priority := uint16(r.uint("preference", 16))
+		exchange, err := p.qualify(r.next())
+		if err != nil {
+			return rr, err
+		}
+		rr.Priority = &priority
+		rr.Content = exchange
+
+	case "TXT", "SPF":
+		rr.Content = r.join("")
+
+	default:
+		data, err := parseDNSRecordData(rrType, &r, p.qualify)
+		if err != nil {
+			return rr, err
+		}
+
+		switch d := data.(type) {
+		case SRVRecordData:
+			labels := strings.SplitN(owner, ".", 3)
+			if len(labels) != 3 || !strings.HasPrefix(labels[0], "_") || !strings.HasPrefix(labels[1], "_") {
+				return rr, fmt.Errorf("owner %q isn't of the form _service._proto.name", owner)
+			}
+			d.Service, d.Proto, d.Name = labels[0], labels[1], labels[2]
+			data = d
+		case URIRecordData:
+			rr.Priority = &d.Priority
+		}
+		rr.Data = data
+	}
+
+	if r.err != nil {
+		return rr, r.err
+	}
+
+	if r.remaining() > 0 {
+		return rr, fmt.Errorf("unexpected data %q", r.peek())
+	}
+
+	return rr, nil
+}
+
+// rdataReader consumes rdata tokens, keeping the first error.
+type rdataReader struct {
+	tokens []zoneFileToken
+	pos    int
+	err    error
+}
+
+func (r *rdataReader) remaining() int {
+	return len(r.tokens) - r.pos
+}
+
+func (r *rdataReader) peek() string {
+	if r.pos >= len(r.tokens) {
+		return ""
+	}
+	return r.tokens[r.pos].text
+}
+
+func (r *rdataReader) next() string {
+	if r.pos >= len(r.tokens) {
+		if r.err == nil {
+			r.err = errors.New("missing data")
+		}
+		return ""
+	}
+
+	r.pos++
+	return r.tokens[r.pos-1].text
+}
+
+// rest consumes the remaining tokens and returns them in presentation
+// format.
+func (r *rdataReader) rest() string {
+	parts := make([]string, 0, r.remaining())
+	for r.remaining() > 0 {
+		if r.tokens[r.pos].quoted {
+			parts = append(parts, quoteZoneFileString(r.next()))
+			continue
+		}
+		parts = append(parts, r.next())
+	}
+	return strings.Join(parts, " ")
+}
+
+// join consumes the remaining tokens.
+func (r *rdataReader) join(sep string) string {
+	if r.remaining() == 0 {
+		return r.next()
+	}
+
+	parts := make([]string, 0, r.remaining())
+	for r.remaining() > 0 {
+		parts = append(parts, r.next())
+	}
+	return strings.Join(parts, sep)
+}
+
+func (r *rdataReader) uint(name string, bits int) uint64 {
+	s := r.next()
+	if r.err != nil {
+		return 0
+	}
+
+	v, err := strconv.ParseUint(s, 10, bits)
+	if err != nil {
+		r.err = fmt.Errorf("invalid %s %q", name, s)
+	}
+	return v
+}
+
+func (r *rdataReader) float(name string) float64 {
+	s := r.next()
+	if r.err != nil {
+		return 0
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		r.err = fmt.Errorf("invalid %s %q", name, s)
+	}
+	return v
+}
+
+// meters consumes an optional distance with an optional "m" suffix.
+func (r *rdataReader) meters(name string, def float64) float64 {
+	if r.remaining() == 0 || r.err != nil {
+		return def
+	}
+
+	s := strings.TrimSuffix(strings.ToLower(r.next()), "m")
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		r.err = fmt.Errorf("invalid %s %q", name, s)
+	}
+	return v
+}
+
+// parseZoneFileTTL parses a TTL in seconds or using BIND's unit suffixes,
+// e.g. "3600", "1h" or "1h30m".
+func parseZoneFileTTL(s string) (int, error) {
+	if v, err := strconv.Atoi(s); err == nil && v >= 0 {
+		return v, nil
+	}
+
+	units := map[byte]int{'w': 604800, 'd': 86400, 'h': 3600, 'm': 60, 's': 1}
+
+	total, n, digits := 0, 0, false
+	for i := 0; i < len(s); i++ {
+		c := s[i] | 0x20 // lower case letters
+		switch {
+		case s[i] >= '0' && s[i] <= '9':
+			n = n*10 + int(s[i]-'0')
+			digits = true
+		case units[c] > 0 && digits:
+			total += n * units[c]
+			n, digits = 0, false
+		default:
+			return 0, fmt.Errorf("invalid TTL %q", s)
+		}
+	}
+
+	if digits {
+		return 0, fmt.Errorf("invalid TTL %q", s)
+	}
+
+	return total, nil
+}
+
+// ImportZoneFileParams are the parameters of ImportZoneFile.
+type ImportZoneFileParams struct {
+	// File is the BIND zone file to import.
+	File io.Reader
+
+	// Proxied sets whether A, AAAA and CNAME records are proxied by
+	// Cloudflare.
+	Proxied bool
+}
+
+// ImportZoneFileResult is the outcome of a zone file import.
+type ImportZoneFileResult struct {
+	RecordsAdded       int `json:"recs_added"`
+	TotalRecordsParsed int `json:"total_records_parsed"`
+}
+
+// ImportZoneFileResponse is the API response of ImportZoneFile.
+type ImportZoneFileResponse struct {
+	Response
+	Result ImportZoneFileResult `json:"result"`
+}
+
+// ImportZoneFile creates the records of a BIND zone file in a zone. Use
+// ParseZoneFile to inspect a zone file before importing it.
+//
+// API reference: https://api.cloudflare.com/#dns-records-for-a-zone-import-dns-records
+func (api *API) ImportZoneFile(ctx context.Context, rc *ResourceContainer, params ImportZoneFileParams) (ImportZoneFileResult, error) {
+	if rc == nil || rc.Identifier == "" {
+		return ImportZoneFileResult{}, ErrMissingZoneID
+	}
+
+	if rc.Level != ZoneRouteLevel {
+		return ImportZoneFileResult{}, fmt.Errorf(errInvalidResourceContainerAccess, rc.Level)
+	}
+
+	if params.File == nil {
+		return ImportZoneFileResult{}, ErrMissingZoneFile
+	}
+
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	part, err := w.CreateFormFile("file", "bind_config.txt")
+	if err != nil {
+		return ImportZoneFileResult{}, fmt.Errorf("error writing multipart body: %w", err)
+	}
+	if _, err := io.Copy(part, params.File); err != nil {
+		return ImportZoneFileResult{}, fmt.Errorf("error writing multipart body: %w", err)
+	}
+	if err := w.WriteField("proxied", strconv.FormatBool(params.Proxied)); err != nil {
+		return ImportZoneFileResult{}, fmt.Errorf("error writing multipart body: %w", err)
+	}
+	_ = w.Close()
+
+	// the body is sent as bytes rather than as a reader so that it can be
+	// resent when the request is retried.
+	uri := fmt.Sprintf("/zones/%s/dns_records/import", rc.Identifier)
+	res, err := api.makeRequestContextWithHeaders(ctx, http.MethodPost, uri, body.Bytes(), http.Header{
+		"Content-Type": []string{w.FormDataContentType()},
+	})
+	if err != nil {
+		return ImportZoneFileResult{}, err
+	}
+
+	var r ImportZoneFileResponse
+	if err := json.Unmarshal(res, &r); err != nil {
+		return ImportZoneFileResult{}, fmt.Errorf("%s: %w", errUnmarshalError, err)
+	}
+
+	return r.Result, nil
+}
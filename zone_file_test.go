@@ -0,0 +1,236 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testZoneFile = `;; Domain:     example.com.
+$ORIGIN example.com.
+$TTL 3600
+@	IN	SOA	ns1.example.com. hostmaster.example.com. (
+		2023010101 ; serial
+		7200       ; refresh
+		3600       ; retry
+		1209600    ; expire
+		3600 )     ; minimum
+@		IN	NS	ns1.example.com.
+@		IN	A	192.0.2.1
+		IN	AAAA	2001:db8::1
+www	300	IN	CNAME	@
+mail	IN	1h	MX	10 mx.example.net.
+@		TXT	"v=spf1 include:_spf.example.net" " ~all"
+_sip._tcp	SRV	10 20 5060 sip
+@		CAA	0 issue "letsencrypt.org"
+_443._tcp.www	TLSA	3 1 1 (
+		0C72AC70B745AC19998811B131D662C9
+		AC69DBDBE7CB23E5B514B56664C5D3D6 )
+loc		LOC	51 30 12.748 N 0 7 39.611 W 0.00m 0.00m 0.00m 0.00m
+@		HTTPS	1 . alpn="h2,h3" port=8443
+$ORIGIN sub.example.com.
+dev		A	192.0.2.2 ; relative to the new origin
+`
+
+func TestParseZoneFile(t *testing.T) {
+	records, err := ParseZoneFile(strings.NewReader(testZoneFile), "")
+	require.NoError(t, err)
+
+	mxPriority := uint16(10)
+	want := []DNSRecord{
+		{Type: "A", Name: "example.com", Content: "192.0.2.1", TTL: 3600},
+		{Type: "AAAA", Name: "example.com", Content: "2001:db8::1", TTL: 3600},
+		{Type: "CNAME", Name: "www.example.com", Content: "example.com", TTL: 300},
+		{Type: "MX", Name: "mail.example.com", Content: "mx.example.net", Priority: &mxPriority, TTL: 3600},
+		{Type: "TXT", Name: "example.com", Content: "v=spf1 include:_spf.example.net ~all", TTL: 3600},
+		{Type: "SRV", Name: "_sip._tcp.example.com", TTL: 3600, Data: SRVRecordData{
+			Service:  "_sip",
+			Proto:    "_tcp",
+			Name:     "example.com",
+			Priority: 10,
+			Weight:   20,
+			Port:     5060,
+			Target:   "sip.example.com",
+		}},
+		{Type: "CAA", Name: "example.com", TTL: 3600, Data: CAARecordData{
+			Flags: 0,
+			Tag:   "issue",
+			Value: "letsencrypt.org",
+		}},
+		{Type: "TLSA", Name: "_443._tcp.www.example.com", TTL: 3600, Data: TLSARecordData{
+			Usage:        3,
+			Selector:     1,
+			MatchingType: 1,
+			Certificate:  "0C72AC70B745AC19998811B131D662C9AC69DBDBE7CB23E5B514B56664C5D3D6",
+		}},
+		{Type: "LOC", Name: "loc.example.com", TTL: 3600, Data: LOCRecordData{
+			LatDegrees:    51,
+			LatMinutes:    30,
+			LatSeconds:    12.748,
+			LatDirection:  "N",
+			LongDegrees:   0,
+			LongMinutes:   7,
+			LongSeconds:   39.611,
+			LongDirection: "W",
+		}},
+		{Type: "HTTPS", Name: "example.com", TTL: 3600, Data: HTTPSRecordData{
+			Priority: 1,
+			Target:   ".",
+			Value:    `alpn="h2,h3" port=8443`,
+		}},
+		{Type: "A", Name: "dev.sub.example.com", Content: "192.0.2.2", TTL: 3600},
+	}
+
+	assert.Equal(t, want, records)
+}
+
+func TestParseZoneFile_DefaultTTL(t *testing.T) {
+	zone := "a 1h30m A 192.0.2.1\nb A 192.0.2.2\n"
+
+	records, err := ParseZoneFile(strings.NewReader(zone), "example.com.")
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, 5400, records[0].TTL)
+	assert.Equal(t, 5400, records[1].TTL, "the TTL of the previous record is used without $TTL")
+}
+
+func TestParseZoneFile_LOCDefaults(t *testing.T) {
+	records, err := ParseZoneFile(strings.NewReader("@ LOC 42 N 71 W -24m\n"), "example.com")
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	assert.Equal(t, LOCRecordData{
+		LatDegrees:    42,
+		LatDirection:  "N",
+		LongDegrees:   71,
+		LongDirection: "W",
+		Altitude:      -24,
+		Size:          1,
+		PrecisionHorz: 10000,
+		PrecisionVert: 10,
+	}, records[0].Data)
+}
+
+func TestParseZoneFile_Errors(t *testing.T) {
+	tests := map[string]struct {
+		zone string
+		line int
+		err  string
+	}{
+		"invalid address":       {"$ORIGIN example.com.\nwww A 2001:db8::1\n", 2, `A record: invalid address "2001:db8::1"`},
+		"missing origin":        {"www A 192.0.2.1\n", 1, `relative name "www" used without an origin`},
+		"unclosed parenthesis":  {"$ORIGIN example.com.\n@ TXT ( \"a\"\n", 2, "unclosed parenthesis"},
+		"unterminated string":   {"$ORIGIN example.com.\n@ TXT \"a\n", 2, "unterminated quoted string"},
+		"unsupported directive": {"$INCLUDE other.zone\n", 1, "unsupported directive $INCLUDE"},
+		"unsupported class":     {"$ORIGIN example.com.\n@ CH A 192.0.2.1\n", 2, "unsupported class CH"},
+		"missing data":          {"$ORIGIN example.com.\n@ MX 10\n", 2, "MX record: missing data"},
+		"extra data":            {"$ORIGIN example.com.\n@ CNAME a b\n", 2, `CNAME record: unexpected data "b"`},
+		"invalid TTL":           {"$TTL 1x\n", 1, `invalid TTL "1x"`},
+		"bad SRV owner":         {"$ORIGIN example.com.\nsip SRV 1 1 1 t\n", 2, "isn't of the form _service._proto.name"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseZoneFile(strings.NewReader(tc.zone), "")
+
+			var zfErr *ZoneFileError
+			require.ErrorAs(t, err, &zfErr)
+			assert.Equal(t, tc.line, zfErr.Line)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestImportZoneFile(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records/import", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method, "Expected method 'POST', got %s", r.Method)
+		assert.True(t, strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data"))
+
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		assert.Equal(t, "true", r.FormValue("proxied"))
+
+		f, _, err := r.FormFile("file")
+		require.NoError(t, err)
+		zone, err := io.ReadAll(f)
+		require.NoError(t, err)
+		assert.Equal(t, testZoneFile, string(zone))
+
+		w.Header().Set("content-type", "application/json")
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": {
+				"recs_added": 9,
+				"total_records_parsed": 9
+			}
+		}`)
+	})
+
+	result, err := client.ImportZoneFile(context.Background(), ZoneIdentifier(testZoneID), ImportZoneFileParams{
+		File:    strings.NewReader(testZoneFile),
+		Proxied: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ImportZoneFileResult{RecordsAdded: 9, TotalRecordsParsed: 9}, result)
+
+	_, err = client.ImportZoneFile(context.Background(), ZoneIdentifier(""), ImportZoneFileParams{File: strings.NewReader("")})
+	assert.ErrorIs(t, err, ErrMissingZoneID)
+
+	_, err = client.ImportZoneFile(context.Background(), nil, ImportZoneFileParams{File: strings.NewReader("")})
+	assert.ErrorIs(t, err, ErrMissingZoneID)
+
+	_, err = client.ImportZoneFile(context.Background(), ZoneIdentifier(testZoneID), ImportZoneFileParams{})
+	assert.ErrorIs(t, err, ErrMissingZoneFile)
+}
+
+func TestImportZoneFile_RequiresZone(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.ImportZoneFile(context.Background(), AccountIdentifier(testAccountID), ImportZoneFileParams{
+		File: strings.NewReader(testZoneFile),
+	})
+	assert.EqualError(t, err, `requested resource container ("accounts") is not supported for this endpoint`)
+}
+
+func TestImportZoneFile_Retry(t *testing.T) {
+	setup(UsingRetryPolicy(2, 0, 1))
+	defer teardown()
+
+	attempts := 0
+	mux.HandleFunc("/zones/"+testZoneID+"/dns_records/import", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		f, _, err := r.FormFile("file")
+		require.NoError(t, err)
+		zone, err := io.ReadAll(f)
+		require.NoError(t, err)
+		assert.Equal(t, testZoneFile, string(zone), "attempt %d", attempts)
+
+		w.Header().Set("content-type", "application/json")
+		if attempts == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"recs_added": 9, "total_records_parsed": 9}}`)
+	})
+
+	result, err := client.ImportZoneFile(context.Background(), ZoneIdentifier(testZoneID), ImportZoneFileParams{
+		File: strings.NewReader(testZoneFile),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 9, result.RecordsAdded)
+	assert.Equal(t, 2, attempts)
+}